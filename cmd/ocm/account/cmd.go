@@ -19,6 +19,8 @@ package account
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/openshift-online/ocm-cli/cmd/ocm/account/labels"
+	"github.com/openshift-online/ocm-cli/cmd/ocm/account/org"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/account/orgs"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/account/quota"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/account/roles"
@@ -36,8 +38,10 @@ var Cmd = &cobra.Command{
 
 func init() {
 	Cmd.AddCommand(quota.Cmd)
+	Cmd.AddCommand(org.Cmd)
 	Cmd.AddCommand(orgs.Cmd)
 	Cmd.AddCommand(status.Cmd)
 	Cmd.AddCommand(roles.Cmd)
 	Cmd.AddCommand(users.Cmd)
+	Cmd.AddCommand(labels.Cmd)
 }
@@ -0,0 +1,96 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package add
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	acc_util "github.com/openshift-online/ocm-cli/pkg/account"
+	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+var args struct {
+	account string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "add KEY=VALUE",
+	Short: "Add a label to an account",
+	Long: "Add a label to the current account, or to the account given with '--account'. " +
+		"Requires administrator privileges.",
+	Example: `  # Enable a feature for an account
+  ocm account labels add --account 1a2b3c my.feature.enabled=true`,
+	Args: cobra.ExactArgs(1),
+	RunE: run,
+}
+
+func init() {
+	fs := Cmd.Flags()
+	fs.StringVar(
+		&args.account,
+		"account",
+		"",
+		"Identifier of the account. Defaults to the current account.",
+	)
+}
+
+func run(cmd *cobra.Command, argv []string) error {
+	key, value, err := acc_util.ParseLabel(argv[0])
+	if err != nil {
+		return err
+	}
+
+	// Create the client for the OCM API:
+	connection, err := ocm.NewConnection().Build()
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+
+	accountID, err := acc_util.GetAccountID(connection, args.account)
+	if err != nil {
+		return err
+	}
+	label, err := amv1.NewLabel().
+		Key(key).
+		Value(value).
+		Build()
+	if err != nil {
+		return fmt.Errorf("Failed to create label: %v", err)
+	}
+	response, err := connection.AccountsMgmt().V1().Accounts().Account(accountID).Labels().
+		Add().
+		Body(label).
+		Send()
+	if err != nil {
+		if response != nil && response.Status() == http.StatusForbidden {
+			return fmt.Errorf(
+				"Can't add label '%s' to account '%s', administrator privileges are "+
+					"required: %v",
+				key, accountID, err,
+			)
+		}
+		return fmt.Errorf("Failed to add label '%s' to account '%s': %v", key, accountID, err)
+	}
+
+	fmt.Printf("Added label '%s=%s' to account '%s'\n", key, value, accountID)
+	return nil
+}
@@ -0,0 +1,41 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labels
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-online/ocm-cli/cmd/ocm/account/labels/add"
+	"github.com/openshift-online/ocm-cli/cmd/ocm/account/labels/delete"
+	"github.com/openshift-online/ocm-cli/cmd/ocm/account/labels/list"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "labels COMMAND",
+	Aliases: []string{"label"},
+	Short:   "Manage account labels",
+	Long: "List, add and delete the labels of an account. Labels are used, among other " +
+		"things, to enable features for the account. Adding and deleting labels requires " +
+		"administrator privileges.",
+	Args: cobra.MinimumNArgs(1),
+}
+
+func init() {
+	Cmd.AddCommand(list.Cmd)
+	Cmd.AddCommand(add.Cmd)
+	Cmd.AddCommand(delete.Cmd)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delete
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	acc_util "github.com/openshift-online/ocm-cli/pkg/account"
+	"github.com/openshift-online/ocm-cli/pkg/ocm"
+)
+
+var args struct {
+	account string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "delete KEY",
+	Short: "Delete a label from an account",
+	Long: "Delete a label from the current account, or from the account given with " +
+		"'--account'. Requires administrator privileges.",
+	Example: `  # Disable a feature for an account
+  ocm account labels delete --account 1a2b3c my.feature.enabled`,
+	Args: cobra.ExactArgs(1),
+	RunE: run,
+}
+
+func init() {
+	fs := Cmd.Flags()
+	fs.StringVar(
+		&args.account,
+		"account",
+		"",
+		"Identifier of the account. Defaults to the current account.",
+	)
+}
+
+func run(cmd *cobra.Command, argv []string) error {
+	key := argv[0]
+
+	// Create the client for the OCM API:
+	connection, err := ocm.NewConnection().Build()
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+
+	accountID, err := acc_util.GetAccountID(connection, args.account)
+	if err != nil {
+		return err
+	}
+	response, err := connection.AccountsMgmt().V1().Accounts().Account(accountID).Labels().
+		Label(key).
+		Delete().
+		Send()
+	if err != nil {
+		if response != nil && response.Status() == http.StatusForbidden {
+			return fmt.Errorf(
+				"Can't delete label '%s' from account '%s', administrator privileges are "+
+					"required: %v",
+				key, accountID, err,
+			)
+		}
+		return fmt.Errorf(
+			"Failed to delete label '%s' from account '%s': %v",
+			key, accountID, err,
+		)
+	}
+
+	fmt.Printf("Deleted label '%s' from account '%s'\n", key, accountID)
+	return nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	acc_util "github.com/openshift-online/ocm-cli/pkg/account"
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
+	"github.com/openshift-online/ocm-cli/pkg/config"
+	"github.com/openshift-online/ocm-cli/pkg/dump"
+	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	"github.com/openshift-online/ocm-cli/pkg/output"
+	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+var args struct {
+	account string
+	columns string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "list",
+	Short: "List account labels",
+	Long:  "List the labels of the current account, or of the account given with '--account'.",
+	Example: `  # List the labels of the current account
+  ocm account labels list
+
+  # List the labels of another account, including when they were created and updated
+  ocm account labels list --account 1a2b3c --output wide`,
+	Args: cobra.NoArgs,
+	RunE: run,
+}
+
+func init() {
+	fs := Cmd.Flags()
+	fs.StringVar(
+		&args.account,
+		"account",
+		"",
+		"Identifier of the account. Defaults to the current account.",
+	)
+	fs.StringVar(
+		&args.columns,
+		"columns",
+		"key,value",
+		"Comma separated list of columns to display.",
+	)
+	arguments.AddOutputFlag(fs)
+}
+
+func run(cmd *cobra.Command, argv []string) error {
+	// Create a context:
+	ctx := context.Background()
+
+	// Check the output format:
+	format, err := output.SelectedFormat()
+	if err != nil {
+		return err
+	}
+
+	// Load the configuration:
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	// Create the client for the OCM API:
+	connection, err := ocm.NewConnection().Build()
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+
+	// Get the labels:
+	accountID, err := acc_util.GetAccountID(connection, args.account)
+	if err != nil {
+		return err
+	}
+	labels, err := acc_util.GetAccountLabels(connection, accountID)
+	if err != nil {
+		return err
+	}
+
+	// Write the labels as they are if a structured output format has been requested:
+	if format.IsStructured() {
+		return dump.List(os.Stdout, format, labels, amv1.MarshalLabelList)
+	}
+
+	// Create the output printer:
+	printer, err := output.NewPrinter().
+		Writer(os.Stdout).
+		Pager(cfg.Pager).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer printer.Close()
+
+	// Create the output table:
+	table, err := printer.NewTable().
+		Name("labels").
+		Columns(args.columns).
+		Wide(format.IsWide()).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer table.Close()
+
+	// Write the header row and the labels:
+	err = table.WriteHeaders()
+	if err != nil {
+		return err
+	}
+	for _, label := range labels {
+		err = table.WriteObject(label)
+		if err != nil {
+			return err
+		}
+	}
+
+	return table.Flush()
+}
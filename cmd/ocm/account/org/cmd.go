@@ -0,0 +1,120 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package org
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
+	"github.com/openshift-online/ocm-cli/pkg/dump"
+	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	"github.com/openshift-online/ocm-cli/pkg/output"
+)
+
+var args struct {
+	account string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "org",
+	Short: "Show organization details.",
+	Long: "Display the details of the organization of the current account, or of the " +
+		"account given with the '--account' flag.",
+	Example: `  # Show the organization of the current account
+  ocm account org
+
+  # Show the organization of another account in JSON format
+  ocm account org --account 1a2b3c --output json`,
+	Args: cobra.NoArgs,
+	RunE: run,
+}
+
+func init() {
+	fs := Cmd.Flags()
+	fs.StringVar(
+		&args.account,
+		"account",
+		"",
+		"Identifier of the account whose organization will be displayed. Defaults to the "+
+			"current account. Requires permission to read other accounts.",
+	)
+	arguments.AddOutputFlag(fs)
+}
+
+func run(cmd *cobra.Command, argv []string) error {
+	// Check the output format:
+	format, err := output.SelectedFormat()
+	if err != nil {
+		return err
+	}
+
+	// Create the client for the OCM API:
+	connection, err := ocm.NewConnection().Build()
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+	accountsClient := connection.AccountsMgmt().V1()
+
+	// Get the account, either the current one or the one given by the user:
+	var account *amv1.Account
+	if args.account == "" {
+		response, err := accountsClient.CurrentAccount().Get().Send()
+		if err != nil {
+			return fmt.Errorf("Can't get current account: %v", err)
+		}
+		account = response.Body()
+	} else {
+		response, err := accountsClient.Accounts().Account(args.account).Get().Send()
+		if err != nil {
+			return fmt.Errorf("Can't get account '%s': %v", args.account, err)
+		}
+		account = response.Body()
+	}
+	orgID := account.Organization().ID()
+	if orgID == "" {
+		return fmt.Errorf("Account '%s' doesn't belong to an organization", account.ID())
+	}
+
+	// The organization embedded in the account may not contain all the details, so fetch it:
+	response, err := accountsClient.Organizations().Organization(orgID).Get().Send()
+	if err != nil {
+		return fmt.Errorf("Can't get organization '%s': %v", orgID, err)
+	}
+	org := response.Body()
+
+	if format.IsStructured() {
+		buffer := &bytes.Buffer{}
+		err = amv1.MarshalOrganization(org, buffer)
+		if err != nil {
+			return err
+		}
+		return dump.Object(os.Stdout, format, buffer.Bytes())
+	}
+
+	fmt.Printf("ID:          %s\n", org.ID())
+	fmt.Printf("Name:        %s\n", org.Name())
+	fmt.Printf("EBS account: %s\n", org.EbsAccountID())
+	fmt.Printf("External ID: %s\n", org.ExternalID())
+
+	return nil
+}
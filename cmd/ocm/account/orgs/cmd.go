@@ -33,6 +33,7 @@ import (
 var args struct {
 	columns   string
 	parameter []string
+	where     []string
 	header    []string
 }
 
@@ -49,6 +50,7 @@ func init() {
 	fs := Cmd.Flags()
 	arguments.AddParameterFlag(fs, &args.parameter)
 	arguments.AddHeaderFlag(fs, &args.header)
+	arguments.AddWhereFlag(fs, &args.where)
 	fs.StringVar(
 		&args.columns,
 		"columns",
@@ -61,6 +63,12 @@ func run(cmd *cobra.Command, argv []string) error {
 	// Create a context:
 	ctx := context.Background()
 
+	// Add the search terms for the `--where` flag:
+	parameters, err := arguments.MergeWhereFlag(args.parameter, args.where)
+	if err != nil {
+		return err
+	}
+
 	// Load the configuration:
 	cfg, err := config.Load()
 	if err != nil {
@@ -103,8 +111,14 @@ func run(cmd *cobra.Command, argv []string) error {
 	// Create the request. Note that this request can be created outside of the loop and used
 	// for all iterations just changing the values of the `size` and `page` parameters.
 	request := connection.AccountsMgmt().V1().Organizations().List()
-	arguments.ApplyParameterFlag(request, args.parameter)
-	arguments.ApplyHeaderFlag(request, args.header)
+	err = arguments.ApplyParameterFlag(request, parameters)
+	if err != nil {
+		return err
+	}
+	err = arguments.ApplyHeaderFlag(request, args.header)
+	if err != nil {
+		return err
+	}
 
 	// Send the request till we receive a page with less items than requested:
 	size := 100
@@ -124,7 +138,7 @@ func run(cmd *cobra.Command, argv []string) error {
 			return err == nil
 		})
 		if err != nil {
-			break
+			return err
 		}
 
 		// If the number of fetched items is less than requested, then this was the last
@@ -135,5 +149,5 @@ func run(cmd *cobra.Command, argv []string) error {
 		index++
 	}
 
-	return nil
+	return table.Flush()
 }
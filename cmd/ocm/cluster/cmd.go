@@ -17,6 +17,10 @@ limitations under the License.
 package cluster
 
 import (
+	"github.com/openshift-online/ocm-cli/cmd/ocm/cluster/console"
+	"github.com/openshift-online/ocm-cli/cmd/ocm/cluster/credentials"
+	"github.com/openshift-online/ocm-cli/cmd/ocm/cluster/events"
+	"github.com/openshift-online/ocm-cli/cmd/ocm/cluster/kubeconfig"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/cluster/login"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/cluster/status"
 	"github.com/spf13/cobra"
@@ -30,6 +34,10 @@ var Cmd = &cobra.Command{
 }
 
 func init() {
+	Cmd.AddCommand(console.Cmd)
+	Cmd.AddCommand(credentials.Cmd)
+	Cmd.AddCommand(events.Cmd)
+	Cmd.AddCommand(kubeconfig.Cmd)
 	Cmd.AddCommand(login.Cmd)
 	Cmd.AddCommand(status.Cmd)
 }
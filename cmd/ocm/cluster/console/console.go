@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package console
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/browser"
+	"github.com/spf13/cobra"
+
+	c "github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/ocm"
+)
+
+var args struct {
+	open bool
+}
+
+var Cmd = &cobra.Command{
+	Use:   "console [flags] {NAME|ID|EXTERNAL_ID}",
+	Short: "Print the URL of the web console of a cluster",
+	Long: "Print the URL of the web console of a cluster identified by name, identifier or " +
+		"external identifier, and optionally open it in the default browser.",
+	Example: `  # Print the URL of the console of a cluster
+  ocm cluster console mycluster
+
+  # Open the console of a cluster in the default browser
+  ocm cluster console --open mycluster`,
+	RunE: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.BoolVar(
+		&args.open,
+		"open",
+		false,
+		"Open the console in the default browser, in addition to printing the URL.",
+	)
+}
+
+func run(cmd *cobra.Command, argv []string) error {
+
+	if len(argv) != 1 {
+		return fmt.Errorf("Expected exactly one cluster name, identifier or external identifier")
+	}
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	key := argv[0]
+	if !c.IsValidClusterKey(key) {
+		return fmt.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			key,
+		)
+	}
+
+	// Create the client for the OCM API:
+	connection, err := ocm.NewConnection().Build()
+	if err != nil {
+		return fmt.Errorf("Failed to create OCM connection: %v", err)
+	}
+	defer connection.Close()
+
+	cluster, err := c.GetCluster(connection, key)
+	if err != nil {
+		return fmt.Errorf("Failed to get cluster '%s': %v", key, err)
+	}
+
+	// The console URL is only known once the cluster has been installed:
+	url := cluster.Console().URL()
+	if url == "" {
+		return fmt.Errorf(
+			"Cluster '%s' doesn't have a console URL yet, its state is '%s'",
+			key, cluster.State(),
+		)
+	}
+	fmt.Fprintln(os.Stdout, url)
+
+	if args.open {
+		err = browser.OpenURL(url)
+		if err != nil {
+			return fmt.Errorf("Failed to open console of cluster '%s': %v", key, err)
+		}
+	}
+
+	return nil
+}
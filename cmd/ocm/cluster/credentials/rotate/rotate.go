@@ -0,0 +1,252 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rotate
+
+import (
+	"fmt"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
+	c "github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/ocm"
+)
+
+const (
+	awsAccessKeyIDFlag     = "aws-access-key-id"
+	awsSecretAccessKeyFlag = "aws-secret-access-key"
+	serviceAccountFileFlag = "service-account-file"
+)
+
+var args struct {
+	interactive        bool
+	confirm            bool
+	aws                c.AWSCredentials
+	serviceAccountFile arguments.FilePath
+}
+
+var Cmd = &cobra.Command{
+	Use:   "rotate [flags] {NAME|ID|EXTERNAL_ID}",
+	Short: "Replace the cloud credentials of a cluster",
+	Long: "Replace the static AWS or GCP credentials of a customer cloud subscription " +
+		"cluster identified by name, identifier or external identifier. Clusters that use " +
+		"AWS STS or GCP Workload Identity Federation don't have static credentials, so " +
+		"they don't support this.",
+	Example: `  # Replace the AWS credentials of a cluster, asking for the secret access key so that it
+  # isn't visible in the command line
+  ocm cluster credentials rotate mycluster --interactive --aws-access-key-id AKIA...
+
+  # Replace the GCP service account of a cluster
+  ocm cluster credentials rotate mycluster --service-account-file osd-ccs-admin.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: run,
+}
+
+func init() {
+	fs := Cmd.Flags()
+	arguments.AddInteractiveFlag(fs, &args.interactive)
+	fs.StringVar(
+		&args.aws.AccessKeyID,
+		awsAccessKeyIDFlag,
+		"",
+		"New AWS access key ID, for AWS clusters.",
+	)
+	arguments.SetQuestion(fs, awsAccessKeyIDFlag, "AWS access key ID:")
+	fs.StringVar(
+		&args.aws.SecretAccessKey,
+		awsSecretAccessKeyFlag,
+		"",
+		"New AWS secret access key, for AWS clusters. Use --interactive to type it instead "+
+			"of passing it in the command line.",
+	)
+	arguments.SetQuestion(fs, awsSecretAccessKeyFlag, "AWS secret access key:")
+	fs.Var(
+		&args.serviceAccountFile,
+		serviceAccountFileFlag,
+		"JSON file containing the key of the new GCP service account, for GCP clusters.",
+	)
+	arguments.AddConfirmFlag(fs, &args.confirm)
+}
+
+func run(cmd *cobra.Command, argv []string) error {
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	key := argv[0]
+	if !c.IsValidClusterKey(key) {
+		return fmt.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			key,
+		)
+	}
+
+	err := arguments.ConfirmProduction(cmd.Flags(), fmt.Sprintf(
+		"rotate the cloud credentials of cluster '%s'", key,
+	))
+	if err != nil {
+		return err
+	}
+
+	// Create the client for the OCM API:
+	connection, err := ocm.NewConnection().Build()
+	if err != nil {
+		return fmt.Errorf("Failed to create OCM connection: %v", err)
+	}
+	defer connection.Close()
+
+	cluster, err := c.GetCluster(connection, key)
+	if err != nil {
+		return fmt.Errorf("Failed to get cluster '%s': %v", key, err)
+	}
+	err = checkStaticCredentials(cluster)
+	if err != nil {
+		return err
+	}
+
+	// Build the patch with the new credentials of the provider of the cluster. Note that the
+	// credentials are never written to the output:
+	fs := cmd.Flags()
+	patch := cmv1.NewCluster()
+	switch cluster.CloudProvider().ID() {
+	case c.ProviderAWS:
+		if fs.Changed(serviceAccountFileFlag) {
+			return fmt.Errorf("Flag --%s is only supported for GCP clusters", serviceAccountFileFlag)
+		}
+		aws, err := awsCredentials(fs)
+		if err != nil {
+			return err
+		}
+		patch.AWS(aws)
+	case c.ProviderGCP:
+		for _, name := range []string{awsAccessKeyIDFlag, awsSecretAccessKeyFlag} {
+			if fs.Changed(name) {
+				return fmt.Errorf("Flag --%s is only supported for AWS clusters", name)
+			}
+		}
+		gcp, err := gcpCredentials(fs, cluster)
+		if err != nil {
+			return err
+		}
+		patch.GCP(gcp)
+	default:
+		return fmt.Errorf(
+			"Can't rotate the credentials of cluster '%s', cloud provider '%s' isn't supported",
+			key, cluster.CloudProvider().ID(),
+		)
+	}
+	body, err := patch.Build()
+	if err != nil {
+		return fmt.Errorf("Failed to create cluster patch: %v", err)
+	}
+	_, err = connection.ClustersMgmt().V1().Clusters().
+		Cluster(cluster.ID()).
+		Update().
+		Body(body).
+		Send()
+	if err != nil {
+		return fmt.Errorf("Failed to update the credentials of cluster '%s': %v", key, err)
+	}
+
+	fmt.Printf("Updated the cloud credentials of cluster '%s'\n", key)
+	return nil
+}
+
+// checkStaticCredentials checks that the cluster uses static credentials from a customer cloud
+// subscription, as Red Hat manages the credentials of other clusters, and clusters that use AWS
+// STS or GCP Workload Identity Federation use short lived credentials instead.
+func checkStaticCredentials(cluster *cmv1.Cluster) error {
+	if !cluster.CCS().Enabled() {
+		return fmt.Errorf(
+			"Cluster '%s' doesn't use a customer cloud subscription, its cloud credentials "+
+				"are managed by Red Hat",
+			cluster.Name(),
+		)
+	}
+	if cluster.AWS().STS().RoleARN() != "" {
+		return fmt.Errorf(
+			"Cluster '%s' uses AWS STS, it doesn't have static credentials to rotate",
+			cluster.Name(),
+		)
+	}
+	if cluster.GCP().Authentication().Kind() == cmv1.WifConfigKind {
+		return fmt.Errorf(
+			"Cluster '%s' uses GCP Workload Identity Federation, it doesn't have static "+
+				"credentials to rotate",
+			cluster.Name(),
+		)
+	}
+	return nil
+}
+
+// awsCredentials returns the new AWS access key, asking for it in interactive mode.
+func awsCredentials(fs *pflag.FlagSet) (*cmv1.AWSBuilder, error) {
+	err := arguments.PromptString(fs, awsAccessKeyIDFlag)
+	if err != nil {
+		return nil, err
+	}
+	err = arguments.PromptPassword(fs, awsSecretAccessKeyFlag)
+	if err != nil {
+		return nil, err
+	}
+	if args.aws.AccessKeyID == "" || args.aws.SecretAccessKey == "" {
+		return nil, fmt.Errorf(
+			"Flags --%s and --%s are required for AWS clusters",
+			awsAccessKeyIDFlag, awsSecretAccessKeyFlag,
+		)
+	}
+	return cmv1.NewAWS().
+		AccessKeyID(args.aws.AccessKeyID).
+		SecretAccessKey(args.aws.SecretAccessKey), nil
+}
+
+// gcpCredentials returns the new GCP service account key, read from the file given in the command
+// line. The service account must belong to the project of the cluster.
+func gcpCredentials(fs *pflag.FlagSet, cluster *cmv1.Cluster) (*cmv1.GCPBuilder, error) {
+	err := arguments.PromptFilePath(fs, serviceAccountFileFlag, true)
+	if err != nil {
+		return nil, err
+	}
+	file := args.serviceAccountFile.String()
+	if file == "" {
+		return nil, fmt.Errorf("Flag --%s is required for GCP clusters", serviceAccountFileFlag)
+	}
+	key, err := c.ReadGCPCredentials(file)
+	if err != nil {
+		return nil, err
+	}
+	project := cluster.GCP().ProjectID()
+	if project != "" && key.ProjectID != project {
+		return nil, fmt.Errorf(
+			"Service account in file '%s' belongs to project '%s', but cluster '%s' is "+
+				"in project '%s'",
+			file, key.ProjectID, cluster.Name(), project,
+		)
+	}
+	return cmv1.NewGCP().
+		Type(key.Type).
+		ProjectID(key.ProjectID).
+		PrivateKeyID(key.PrivateKeyID).
+		PrivateKey(key.PrivateKey).
+		ClientEmail(key.ClientEmail).
+		ClientID(key.ClientID).
+		AuthURI(key.AuthURI).
+		TokenURI(key.TokenURI).
+		AuthProviderX509CertURL(key.AuthProviderX509CertURL).
+		ClientX509CertURL(key.ClientX509CertURL), nil
+}
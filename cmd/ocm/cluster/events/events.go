@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
+	c "github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/dump"
+	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	"github.com/openshift-online/ocm-cli/pkg/output"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "events {NAME|ID|EXTERNAL_ID}",
+	Short: "Status transitions of a cluster",
+	Long: "Show the history of the state of a cluster identified by name, identifier or " +
+		"external identifier, oldest first.\n\n" +
+		"The history is built from the service log entries that record the changes of state " +
+		"of the cluster, and it ends with the current state.",
+	Example: `  # Show how the state of a cluster named "mycluster" changed over time
+  ocm cluster events mycluster
+
+  # Write the service log entries behind the history as JSON
+  ocm cluster events --output json mycluster`,
+	Args: cobra.ExactArgs(1),
+	RunE: run,
+}
+
+func init() {
+	arguments.AddOutputFlag(Cmd.Flags())
+}
+
+// pageSize is the number of service log entries requested in each page.
+const pageSize = 100
+
+func run(cmd *cobra.Command, argv []string) error {
+	// Check the output format:
+	format, err := output.SelectedFormat()
+	if err != nil {
+		return err
+	}
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	key := argv[0]
+	if !c.IsValidClusterKey(key) {
+		return fmt.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			key,
+		)
+	}
+
+	// Create the client for the OCM API:
+	connection, err := ocm.NewConnection().Build()
+	if err != nil {
+		return fmt.Errorf("Failed to create OCM connection: %v", err)
+	}
+	defer connection.Close()
+
+	cluster, err := c.GetCluster(connection, key)
+	if err != nil {
+		return fmt.Errorf("Failed to get cluster '%s': %v", key, err)
+	}
+
+	events, err := getStateEvents(connection, cluster.ID())
+	if err != nil {
+		return fmt.Errorf("Failed to get events of cluster '%s': %v", key, err)
+	}
+
+	// Write the entries as they are if a structured output format has been requested:
+	if format.IsStructured() {
+		return dump.List(os.Stdout, format, events, slv1.MarshalLogEntryList)
+	}
+
+	// Create the writer that will be used to print the tabulated results:
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(writer, "TIMESTAMP\tSTATE\tREASON\n")
+	for _, event := range events {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n",
+			event.Timestamp().Format(time.RFC3339),
+			event.Summary(),
+			event.Description(),
+		)
+	}
+	reason := cluster.Status().ProvisionErrorMessage()
+	if reason == "" {
+		reason = cluster.Status().Description()
+	}
+	fmt.Fprintf(writer, "%s\t%s\t%s\n", "now", cluster.State(), reason)
+
+	//nolint:gosec
+	writer.Flush()
+
+	return nil
+}
+
+// getStateEvents returns the service log entries that record the changes of state of the given
+// cluster, oldest first.
+func getStateEvents(connection *sdk.Connection, clusterID string) ([]*slv1.LogEntry, error) {
+	search := fmt.Sprintf("log_type = '%s'", slv1.LogTypeClusterStateUpdates)
+	var events []*slv1.LogEntry
+	for page := 1; ; page++ {
+		response, err := connection.ServiceLogs().V1().Clusters().ClusterLogs().List().
+			ClusterID(clusterID).
+			Search(search).
+			Order("timestamp asc").
+			Page(page).
+			Size(pageSize).
+			Send()
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, response.Items().Slice()...)
+		if response.Size() < pageSize {
+			break
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
+	c "github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/dump"
+	"github.com/openshift-online/ocm-cli/pkg/ocm"
+)
+
+var args struct {
+	outFile string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "kubeconfig [flags] {NAME|ID|EXTERNAL_ID}",
+	Short: "Get the admin kubeconfig of a cluster",
+	Long: "Get the admin kubeconfig of a cluster identified by name, identifier or external " +
+		"identifier, and write it to the standard output or to the file given with " +
+		"--output-file. Only clusters whose admin credentials are managed by OCM support this.",
+	Example: `  # Save the admin kubeconfig of a cluster and use it
+  ocm cluster kubeconfig mycluster --output-file ~/.kube/mycluster
+  oc --kubeconfig ~/.kube/mycluster get nodes`,
+	Args: cobra.ExactArgs(1),
+	RunE: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	arguments.AddOutputFileFlag(flags, &args.outFile)
+}
+
+func run(cmd *cobra.Command, argv []string) error {
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	key := argv[0]
+	if !c.IsValidClusterKey(key) {
+		return fmt.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			key,
+		)
+	}
+
+	// Create the client for the OCM API:
+	connection, err := ocm.NewConnection().Build()
+	if err != nil {
+		return fmt.Errorf("Failed to create OCM connection: %v", err)
+	}
+	defer connection.Close()
+
+	cluster, err := c.GetCluster(connection, key)
+	if err != nil {
+		return fmt.Errorf("Failed to get cluster '%s': %v", key, err)
+	}
+	if cluster.Hypershift().Enabled() {
+		return fmt.Errorf(
+			"Admin credentials of hosted control plane cluster '%s' can't be retrieved, "+
+				"use an identity provider to log in instead",
+			key,
+		)
+	}
+	if cluster.State() != cmv1.ClusterStateReady {
+		return fmt.Errorf(
+			"Admin credentials of cluster '%s' aren't available because it isn't ready, "+
+				"its state is '%s'",
+			key, cluster.State(),
+		)
+	}
+
+	// Note that the SDK redacts the kubeconfig when dumping responses in debug mode, so this
+	// doesn't leak the credentials to the log:
+	response, err := connection.ClustersMgmt().V1().Clusters().
+		Cluster(cluster.ID()).
+		Credentials().
+		Get().
+		Send()
+	if err != nil {
+		return fmt.Errorf("Can't get admin credentials of cluster '%s': %v", key, err)
+	}
+	kubeconfig := response.Body().Kubeconfig()
+	if kubeconfig == "" {
+		return fmt.Errorf("Cluster '%s' doesn't have an admin kubeconfig", key)
+	}
+	if !strings.HasSuffix(kubeconfig, "\n") {
+		kubeconfig += "\n"
+	}
+
+	stdout := os.Stdout
+	if args.outFile != "" {
+		stdout, err = dump.CreateFile(args.outFile)
+		if err != nil {
+			return fmt.Errorf("Can't create output file: %v", err)
+		}
+	}
+	_, err = stdout.WriteString(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("Can't write kubeconfig: %v", err)
+	}
+
+	// Check that the output file has been written completely:
+	if stdout != os.Stdout {
+		err = stdout.Close()
+		if err != nil {
+			return fmt.Errorf("Can't close output file: %v", err)
+		}
+	}
+
+	return nil
+}
@@ -18,22 +18,57 @@ package status
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
-	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
+
+	c "github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/ocm"
 )
 
+var args struct {
+	short bool
+}
+
 var Cmd = &cobra.Command{
-	Use:   "status [flags] CLUSTER_ID",
+	Use:   "status [flags] {NAME|ID|EXTERNAL_ID}",
 	Short: "Status of a cluster",
-	Long:  "Get the status of a cluster identified by its cluster ID",
-	RunE:  run,
+	Long: "Get the status of a cluster identified by name, identifier or external identifier.\n\n" +
+		"With --short it prints a single line containing the state, the reason of the state, " +
+		"the version, the region and the provider of the cluster, and it fails if the cluster " +
+		"is in error state.",
+	Example: `  # Check the state of a cluster every few seconds
+  watch ocm cluster status --short mycluster`,
+	RunE: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.BoolVar(
+		&args.short,
+		"short",
+		false,
+		"Print the status in a single line and exit with an error if the cluster is in error state.",
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) error {
 
 	if len(argv) != 1 {
-		return fmt.Errorf("Expected exactly one cluster id")
+		return fmt.Errorf("Expected exactly one cluster name, identifier or external identifier")
+	}
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	key := argv[0]
+	if !c.IsValidClusterKey(key) {
+		return fmt.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			key,
+		)
 	}
 
 	// Create the client for the OCM API:
@@ -43,26 +78,24 @@ func run(cmd *cobra.Command, argv []string) error {
 	}
 	defer connection.Close()
 
-	// Get the client for the resource that manages the collection of clusters:
-	resource := connection.ClustersMgmt().V1().Clusters()
-
-	// Get the resource that manages the cluster that we want to display:
-	clusterID := argv[0]
-	clusterResource := resource.Cluster(clusterID)
-
-	// Retrieve the collection of clusters:
-	response, err := clusterResource.Get().Send()
+	cluster, err := c.GetCluster(connection, key)
 	if err != nil {
-		return fmt.Errorf("Can't retrieve clusters: %s", err)
+		return fmt.Errorf("Failed to get cluster '%s': %v", key, err)
 	}
 
-	cluster := response.Body()
-
 	// Get data out of the response
 	state := cluster.State()
 
+	if args.short {
+		fmt.Fprintln(os.Stdout, shortStatus(cluster))
+		if state == cmv1.ClusterStateError {
+			return fmt.Errorf("Cluster '%s' is in error state", key)
+		}
+		return nil
+	}
+
 	// Fetch metrics from AMS
-	search := fmt.Sprintf("cluster_id = '%s'", clusterID)
+	search := fmt.Sprintf("cluster_id = '%s'", cluster.ID())
 	subsList, err := connection.AccountsMgmt().V1().Subscriptions().List().Search(search).Send()
 	if err != nil {
 		return fmt.Errorf("Can't retrieve subscriptions: %s", err)
@@ -96,3 +129,24 @@ func run(cmd *cobra.Command, argv []string) error {
 
 	return nil
 }
+
+// shortStatus returns the single line status of the cluster, for example:
+//
+//	ready 4.15.3 us-east-1 aws
+//	error (Provisioning failed) 4.15.3 us-east-1 aws
+func shortStatus(cluster *cmv1.Cluster) string {
+	fields := []string{string(cluster.State())}
+	reason := cluster.Status().ProvisionErrorMessage()
+	if reason == "" {
+		reason = cluster.Status().Description()
+	}
+	if reason != "" {
+		fields = append(fields, fmt.Sprintf("(%s)", reason))
+	}
+	version := cluster.OpenshiftVersion()
+	if version == "" {
+		version = cluster.Version().RawID()
+	}
+	fields = append(fields, version, cluster.Region().ID(), cluster.CloudProvider().ID())
+	return strings.Join(fields, " ")
+}
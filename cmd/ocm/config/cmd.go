@@ -23,7 +23,9 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift-online/ocm-cli/cmd/ocm/config/current"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/config/get"
+	"github.com/openshift-online/ocm-cli/cmd/ocm/config/migrate"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/config/set"
 	"github.com/openshift-online/ocm-cli/pkg/config"
 	"github.com/openshift-online/ocm-cli/pkg/properties"
@@ -52,8 +54,8 @@ func longHelp() (ret string) {
 	}
 	ret = fmt.Sprintf(`Get or set variables from a configuration file.
 
-The location of the configuration file is gleaned from the 'OCM_CONFIG' environment variable,
-or ~/.ocm.json if that variable is unset. Currently using: %s
+The location of the configuration file is gleaned from the '--config' flag or the 'OCM_CONFIG'
+environment variable, or ~/.ocm.json if neither is set. Currently using: %s
 
 The following variables are supported:
 
@@ -70,6 +72,11 @@ following backends are supported for the keyring:
 - Windows: wincred
 
 Available Keyrings on your OS: %s
+
+Use "ocm config migrate --keyring BACKEND" to move the credentials from an existing configuration
+file to a keyring.
+
+Use "ocm config current" to see which configuration, URLs and keyring are in use.
 `, loc, configVarDocs(), properties.KeyringEnvKey, strings.Join(config.GetKeyrings(), ", "))
 	return
 }
@@ -83,4 +90,6 @@ var Cmd = &cobra.Command{
 func init() {
 	Cmd.AddCommand(get.Cmd)
 	Cmd.AddCommand(set.Cmd)
+	Cmd.AddCommand(migrate.Cmd)
+	Cmd.AddCommand(current.Cmd)
 }
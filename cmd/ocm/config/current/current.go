@@ -0,0 +1,174 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package current
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
+	"github.com/openshift-online/ocm-cli/pkg/config"
+	"github.com/openshift-online/ocm-cli/pkg/dump"
+	"github.com/openshift-online/ocm-cli/pkg/output"
+	"github.com/openshift-online/ocm-cli/pkg/utils"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "current",
+	Aliases: []string{"whereami"},
+	Short:   "Prints the environment used by the current configuration",
+	Long: "Prints where the configuration is loaded from, the URLs of the API gateway and of " +
+		"the token service, and whether the access and refresh tokens are still valid. The " +
+		"tokens themselves are never printed.",
+	Example: `  # Show the current environment
+  ocm config current
+
+  # Show the current environment in JSON format
+  ocm config current --output json`,
+	Args: cobra.NoArgs,
+	RunE: run,
+}
+
+func init() {
+	arguments.AddOutputFlag(Cmd.Flags())
+}
+
+// Token states:
+const (
+	tokenMissing = "missing"
+	tokenValid   = "valid"
+	tokenExpired = "expired"
+	tokenUnknown = "unknown"
+)
+
+// environment describes the configuration currently in use.
+type environment struct {
+	ConfigFile   string      `json:"config_file,omitempty"`
+	Keyring      string      `json:"keyring,omitempty"`
+	URL          string      `json:"url"`
+	TokenURL     string      `json:"token_url"`
+	AccessToken  tokenStatus `json:"access_token"`
+	RefreshToken tokenStatus `json:"refresh_token"`
+}
+
+// tokenStatus describes the validity of a token without revealing it.
+type tokenStatus struct {
+	State     string     `json:"state"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func run(cmd *cobra.Command, argv []string) error {
+	// Check the output format:
+	format, err := output.SelectedFormat()
+	if err != nil {
+		return err
+	}
+
+	// Load the configuration, which may not exist yet:
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("Can't load config file: %v", err)
+	}
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+
+	now := time.Now()
+	env := &environment{
+		URL:          cfg.URL,
+		TokenURL:     cfg.TokenURL,
+		AccessToken:  checkToken(cfg.AccessToken, now),
+		RefreshToken: checkToken(cfg.RefreshToken, now),
+	}
+	keyring, ok := config.IsKeyringManaged()
+	if ok {
+		env.Keyring = keyring
+	} else {
+		env.ConfigFile, err = config.Location()
+		if err != nil {
+			return fmt.Errorf("Can't find config file: %v", err)
+		}
+	}
+
+	if format.IsStructured() {
+		data, err := json.Marshal(env)
+		if err != nil {
+			return err
+		}
+		return dump.Object(os.Stdout, format, data)
+	}
+
+	fmt.Printf("Config file:   %s\n", valueOrNotAvailable(env.ConfigFile))
+	fmt.Printf("Keyring:       %s\n", valueOrNotAvailable(env.Keyring))
+	fmt.Printf("URL:           %s\n", valueOrNotAvailable(env.URL))
+	fmt.Printf("Token URL:     %s\n", valueOrNotAvailable(env.TokenURL))
+	fmt.Printf("Access token:  %s\n", describeToken(env.AccessToken, now))
+	fmt.Printf("Refresh token: %s\n", describeToken(env.RefreshToken, now))
+
+	return nil
+}
+
+// checkToken checks if the given token is still valid at the given time. Encrypted tokens and
+// tokens that can't be parsed are reported as unknown.
+func checkToken(token string, now time.Time) tokenStatus {
+	if token == "" {
+		return tokenStatus{State: tokenMissing}
+	}
+	if config.IsEncryptedToken(token) {
+		return tokenStatus{State: tokenUnknown}
+	}
+	expiresAt, err := config.TokenExpiresAt(token)
+	if err != nil {
+		return tokenStatus{State: tokenUnknown}
+	}
+	if expiresAt.IsZero() {
+		return tokenStatus{State: tokenValid}
+	}
+	state := tokenValid
+	if !expiresAt.After(now) {
+		state = tokenExpired
+	}
+	return tokenStatus{State: state, ExpiresAt: &expiresAt}
+}
+
+// describeToken returns the human readable description of the given token status.
+func describeToken(status tokenStatus, now time.Time) string {
+	switch {
+	case status.State == tokenMissing:
+		return "not set"
+	case status.State == tokenUnknown:
+		return "set, expiration unknown"
+	case status.ExpiresAt == nil:
+		return "valid, doesn't expire"
+	case status.State == tokenExpired:
+		return fmt.Sprintf("expired %s", utils.RelativeTime(*status.ExpiresAt, now))
+	default:
+		return fmt.Sprintf("valid, expires %s", utils.RelativeTime(*status.ExpiresAt, now))
+	}
+}
+
+// valueOrNotAvailable returns the given value, or N/A if it is empty.
+func valueOrNotAvailable(value string) string {
+	if value == "" {
+		return "N/A"
+	}
+	return value
+}
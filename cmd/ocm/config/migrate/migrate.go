@@ -0,0 +1,77 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-online/ocm-cli/pkg/config"
+	"github.com/openshift-online/ocm-cli/pkg/properties"
+)
+
+var args struct {
+	keyring string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "migrate --keyring BACKEND",
+	Short: "Moves the credentials from the config file to a keyring",
+	Long: fmt.Sprintf("Stores the configuration in the given OS keyring and removes the tokens, "+
+		"passwords and secrets from the config file. A backup of the original config file is "+
+		"kept next to it. Once migrated, set '%s' to the name of the keyring so that the "+
+		"configuration is loaded from it.", properties.KeyringEnvKey),
+	Example: "  ocm config migrate --keyring secret-service",
+	Args:    cobra.NoArgs,
+	RunE:    run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.StringVar(
+		&args.keyring,
+		"keyring",
+		"",
+		fmt.Sprintf(
+			"Keyring backend where the configuration will be stored. Available keyrings on "+
+				"your OS: %s.",
+			strings.Join(config.GetKeyrings(), ", "),
+		),
+	)
+	//nolint:gosec
+	Cmd.MarkFlagRequired("keyring")
+}
+
+func run(cmd *cobra.Command, argv []string) error {
+	migration, err := config.MigrateToKeyring(args.keyring)
+	if err != nil {
+		return fmt.Errorf("Can't migrate config file: %v", err)
+	}
+
+	fmt.Printf(
+		"Migrated %s from '%s' to keyring '%s'\n",
+		strings.Join(migration.Credentials, ", "), migration.File, migration.Keyring,
+	)
+	fmt.Printf("Backup of the original config file saved to '%s'\n", migration.Backup)
+	fmt.Printf(
+		"Set '%s=%s' to use the configuration stored in the keyring\n",
+		properties.KeyringEnvKey, migration.Keyring,
+	)
+	return nil
+}
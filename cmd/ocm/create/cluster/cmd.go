@@ -17,11 +17,13 @@ limitations under the License.
 package cluster
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -31,10 +33,13 @@ import (
 	"github.com/openshift-online/ocm-cli/pkg/arguments"
 	"github.com/openshift-online/ocm-cli/pkg/billing"
 	c "github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/dump"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	"github.com/openshift-online/ocm-cli/pkg/output"
 	"github.com/openshift-online/ocm-cli/pkg/provider"
 	"github.com/openshift-online/ocm-cli/pkg/utils"
 	sdk "github.com/openshift-online/ocm-sdk-go"
+	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/spf13/cobra"
@@ -53,11 +58,35 @@ const (
 		gcpTermsAgreementsHyperlink + ". Set the flag --marketplace-gcp-terms to true " +
 		"once agreed in order to proceed further."
 
+	likeFlag               = "like"
 	privateFlag            = "private"
+	privateLinkFlag        = "private-link"
+	publicAPIFlag          = "public-api"
 	vpcNameFlag            = "vpc-name"
 	controlPlaneSubnetFlag = "control-plane-subnet"
 	computePlaneSubnetFlag = "compute-subnet"
 	pscSubnetFlag          = "psc-subnet"
+	auditLogForwardingFlag = "audit-log-forwarding"
+	imdsFlag               = "imds"
+	workerDiskSizeFlag     = "worker-disk-size"
+	workerVolumeIOPSFlag   = "worker-volume-iops"
+	etcdEncryptionFlag     = "etcd-encryption"
+	etcdKMSKeyARNFlag      = "etcd-kms-key-arn"
+	instanceCategoryFlag   = "instance-type-category"
+
+	sharedVPCHostedZoneIDFlag   = "shared-vpc-hosted-zone-id"
+	sharedVPCRoute53RoleARNFlag = "shared-vpc-route53-role-arn"
+
+	defaultPoolAZsFlag = "default-pool-availability-zones"
+
+	fedrampFlag      = "fedramp"
+	releaseImageFlag = "release-image"
+
+	autoscalerMaxNodeProvisionTimeFlag       = "autoscaler-max-node-provision-time"
+	autoscalerScaleDownDelayAfterAddFlag     = "autoscaler-scale-down-delay-after-add"
+	autoscalerScaleDownDelayAfterDeleteFlag  = "autoscaler-scale-down-delay-after-delete"
+	autoscalerScaleDownDelayAfterFailureFlag = "autoscaler-scale-down-delay-after-failure"
+	autoscalerScaleDownUnneededTimeFlag      = "autoscaler-scale-down-unneeded-time"
 )
 
 var args struct {
@@ -66,18 +95,22 @@ var args struct {
 	domainPrefix string
 
 	// flags
-	interactive bool
-	dryRun      bool
+	interactive   bool
+	resumeAnswers bool
+	dryRun        bool
+	like          string
 
 	region                string
 	version               string
 	channelGroup          string
+	releaseImage          string
 	flavour               string
 	provider              string
 	expirationTime        string
 	expirationSeconds     time.Duration
 	private               bool
 	multiAZ               bool
+	defaultPoolAZs        []string
 	ccs                   c.CCS
 	existingVPC           c.ExistingVPC
 	clusterWideProxy      c.ClusterWideProxy
@@ -86,14 +119,25 @@ var args struct {
 	gcpAuthentication     c.GcpAuthentication
 	gcpPrivateSvcConnect  c.GcpPrivateSvcConnect
 	gcpWifConfig          string
+	auditLogForwarding    bool
 	etcdEncryption        bool
+	etcdKMSKeyARN         string
+	fedramp               bool
+	privateLink           bool
+	publicAPI             bool
+	sharedVPC             c.SharedVPC
+	imds                  string
 	subscriptionType      string
 	marketplaceGcpTerms   bool
 
 	// Scaling options
 	computeMachineType string
+	instanceCategory   string
 	computeNodes       int
 	autoscaling        c.Autoscaling
+	workerDiskSize     int
+	workerVolumeIOPS   int
+	clusterAutoscaler  c.ClusterAutoscaler
 
 	// Networking options
 	networkType string
@@ -114,7 +158,11 @@ const clusterNameHelp = "The name can be used as the identifier of the cluster."
 
 const subnetTemplate = "%s (%s)"
 
-const subscriptionTypeTemplate = "%s (%s)"
+// Initial delay and total time of the retries of each page of flavours.
+const (
+	fetchFlavoursRetryDelay   = 250 * time.Millisecond
+	fetchFlavoursRetryTimeout = 5 * time.Second
+)
 
 // Creates a subnet options using a predefined template.
 func setSubnetOption(subnet, zone string) string {
@@ -126,10 +174,6 @@ func parseSubnet(subnetOption string) string {
 	return strings.Split(subnetOption, " ")[0]
 }
 
-func setSubscriptionTypeOption(id, description string) string {
-	return fmt.Sprintf(subscriptionTypeTemplate, id, description)
-}
-
 func parseSubscriptionType(subscriptionTypeOption string) string {
 	return strings.Split(subscriptionTypeOption, " ")[0]
 }
@@ -157,12 +201,29 @@ var Cmd = &cobra.Command{
 func init() {
 	fs := Cmd.Flags()
 	arguments.AddInteractiveFlag(fs, &args.interactive)
+	fs.BoolVar(
+		&args.resumeAnswers,
+		"resume-answers",
+		false,
+		"In interactive mode, save the answers and use the answers saved by a previous run "+
+			"in the same terminal session as defaults. The saved answers are removed when "+
+			"the cluster is created.",
+	)
 	fs.BoolVar(
 		&args.dryRun,
 		"dry-run",
 		false,
 		"Simulate creating the cluster.",
 	)
+	fs.StringVar(
+		&args.like,
+		likeFlag,
+		"",
+		"Name, identifier or external identifier of an existing cluster to copy the settings "+
+			"from. The provider, region, version, compute nodes and networking of that cluster "+
+			"are used as defaults for the flags that aren't given, and as the default answers "+
+			"in interactive mode.",
+	)
 
 	arguments.AddProviderFlag(fs, &args.provider)
 	Cmd.RegisterFlagCompletionFunc("provider", arguments.MakeCompleteFunc(osdProviderOptions))
@@ -180,16 +241,16 @@ func init() {
 		&args.region,
 		"region",
 		"",
-		"The cloud provider region to create the cluster in. See `ocm list regions`.",
+		"The cloud provider region to create the cluster in. See `ocm list regions`. "+
+			"Required unless it is taken from the cluster given with --like.",
 	)
-	Cmd.MarkFlagRequired("region")
-	Cmd.RegisterFlagCompletionFunc("region", arguments.MakeCompleteFunc(getRegionOptions))
+	Cmd.RegisterFlagCompletionFunc("region", regionCompletion)
 
-	fs.StringVar(
-		&args.version,
+	fs.Var(
+		(*arguments.Version)(&args.version),
 		"version",
-		"",
-		"The OpenShift version to create the cluster at (for example, \"4.1.16\")",
+		"The OpenShift version to create the cluster at (for example, \"4.1.16\"). "+
+			"The \"openshift-v\" prefix is optional.",
 	)
 	arguments.SetQuestion(fs, "version", "OpenShift version:")
 	Cmd.RegisterFlagCompletionFunc("version", arguments.MakeCompleteFunc(getVersionOptions))
@@ -213,6 +274,15 @@ func init() {
 		"",
 		"The channel group to create the cluster at (for example, \"stable\")",
 	)
+	fs.StringVar(
+		&args.releaseImage,
+		releaseImageFlag,
+		"",
+		"Pull spec of a custom release image for the cluster. Only allowed with channel groups "+
+			"other than 'stable', and requires --channel-group.",
+	)
+	//nolint:gosec
+	fs.MarkHidden(releaseImageFlag)
 
 	fs.StringVar(
 		&args.flavour,
@@ -244,6 +314,34 @@ func init() {
 		false,
 		"Restrict master API endpoint and application routes to direct, private connectivity.",
 	)
+	fs.BoolVar(
+		&args.privateLink,
+		privateLinkFlag,
+		false,
+		"Use AWS PrivateLink for the connectivity between Red Hat SRE and the cluster. "+
+			"Implies --private and requires --subnet-ids. Only supported for AWS CCS clusters.",
+	)
+	fs.BoolVar(
+		&args.publicAPI,
+		publicAPIFlag,
+		false,
+		"Keep the API endpoint public when the cluster is private, so that only the "+
+			"application routes are restricted to private connectivity. Requires --private.",
+	)
+	fs.StringVar(
+		&args.sharedVPC.HostedZoneID,
+		sharedVPCHostedZoneIDFlag,
+		"",
+		"ID of the private hosted zone of a VPC shared by another AWS account. "+
+			"Requires --subnet-ids and --"+sharedVPCRoute53RoleARNFlag+". Only supported for AWS CCS clusters.",
+	)
+	fs.StringVar(
+		&args.sharedVPC.Route53RoleARN,
+		sharedVPCRoute53RoleARNFlag,
+		"",
+		"ARN of the role, in the AWS account that owns the shared VPC, used to manage the records "+
+			"of the private hosted zone. Requires --"+sharedVPCHostedZoneIDFlag+".",
+	)
 	arguments.SetQuestion(fs, "private", "Private cluster (optional):")
 	fs.BoolVar(
 		&args.multiAZ,
@@ -252,13 +350,44 @@ func init() {
 		"Deploy to multiple data centers.",
 	)
 	arguments.SetQuestion(fs, "multi-az", "Multiple AZ:")
+	fs.StringSliceVar(
+		&args.defaultPoolAZs,
+		defaultPoolAZsFlag,
+		nil,
+		"Comma-separated list of the availability zones that the default machine pool is "+
+			"created in. Requires --multi-az. It can't be used with --subnet-ids or "+
+			"--availability-zones, as the subnets already determine the zones.",
+	)
 
 	fs.BoolVar(
 		&args.etcdEncryption,
-		"etcd-encryption",
+		etcdEncryptionFlag,
 		false,
 		"Encrypt etcd.",
 	)
+	fs.StringVar(
+		&args.etcdKMSKeyARN,
+		etcdKMSKeyARNFlag,
+		"",
+		"ARN of the customer AWS KMS key used to encrypt etcd. Implies --etcd-encryption. "+
+			"Only supported for AWS CCS clusters.",
+	)
+	fs.BoolVar(
+		&args.fedramp,
+		fedrampFlag,
+		false,
+		"Create a FedRAMP cluster in an AWS GovCloud region. Implies FIPS validated cryptography "+
+			"and --etcd-encryption. Only supported for AWS CCS clusters.",
+	)
+
+	fs.StringVar(
+		&args.imds,
+		imdsFlag,
+		"",
+		fmt.Sprintf("Whether the use of IMDSv2 is %s or %s for the instance metadata service of "+
+			"the AWS instances of the cluster.", c.ImdsRequired, c.ImdsOptional),
+	)
+	Cmd.RegisterFlagCompletionFunc(imdsFlag, imdsCompletion)
 
 	// Scaling options
 	fs.StringVar(
@@ -268,6 +397,14 @@ func init() {
 		"Instance type for the compute nodes. Determines the amount of memory and vCPU allocated to each compute node.",
 	)
 	Cmd.RegisterFlagCompletionFunc("compute-machine-type", arguments.MakeCompleteFunc(getMachineTypeOptions))
+	fs.StringVar(
+		&args.instanceCategory,
+		instanceCategoryFlag,
+		"",
+		fmt.Sprintf("Only offer compute machine types of this category, one of: %s.",
+			strings.Join(provider.MachineTypeCategories, ", ")),
+	)
+	Cmd.RegisterFlagCompletionFunc(instanceCategoryFlag, instanceCategoryCompletion)
 
 	fs.IntVar(
 		&args.computeNodes,
@@ -278,13 +415,70 @@ func init() {
 			"%d on CCS. "+
 			"Multi-AZ at least %d nodes on Red Hat infra, "+
 			"%d on CCS, and must be a multiple of 3. "+
-			"If omitted, uses minimum.",
+			"If omitted or 0, uses minimum. Not allowed with --enable-autoscaling.",
 			minComputeNodes(false, false), minComputeNodes(true, false),
 			minComputeNodes(false, true), minComputeNodes(true, true),
 		),
 	)
 	arguments.AddAutoscalingFlags(fs, &args.autoscaling)
 
+	fs.IntVar(
+		&args.workerDiskSize,
+		workerDiskSizeFlag,
+		0,
+		fmt.Sprintf("Size, in GiB, of the root volume of the worker nodes of the default machine pool. "+
+			"Must be at least %d GiB. If omitted, uses the default of the cloud provider.",
+			c.MinWorkerDiskSize,
+		),
+	)
+
+	fs.IntVar(
+		&args.workerVolumeIOPS,
+		workerVolumeIOPSFlag,
+		0,
+		fmt.Sprintf("IOPS of the gp3 root volume of the worker nodes of the default machine pool. "+
+			"Must be between %d and %d. Only supported on AWS. If omitted, uses the default of "+
+			"the cloud provider.",
+			c.MinWorkerVolumeIOPS, c.MaxWorkerVolumeIOPS,
+		),
+	)
+
+	fs.StringVar(
+		&args.clusterAutoscaler.MaxNodeProvisionTime,
+		autoscalerMaxNodeProvisionTimeFlag,
+		"",
+		"Maximum time that the cluster autoscaler waits for a node to be provisioned, for "+
+			"example '15m'. Requires --enable-autoscaling.",
+	)
+	fs.StringVar(
+		&args.clusterAutoscaler.ScaleDownDelayAfterAdd,
+		autoscalerScaleDownDelayAfterAddFlag,
+		"",
+		"Time that the cluster autoscaler waits after adding a node before evaluating scale "+
+			"down, for example '10m'. Requires --enable-autoscaling.",
+	)
+	fs.StringVar(
+		&args.clusterAutoscaler.ScaleDownDelayAfterDelete,
+		autoscalerScaleDownDelayAfterDeleteFlag,
+		"",
+		"Time that the cluster autoscaler waits after deleting a node before evaluating scale "+
+			"down, for example '10s'. Requires --enable-autoscaling.",
+	)
+	fs.StringVar(
+		&args.clusterAutoscaler.ScaleDownDelayAfterFailure,
+		autoscalerScaleDownDelayAfterFailureFlag,
+		"",
+		"Time that the cluster autoscaler waits after a scale down failure before evaluating "+
+			"scale down again, for example '3m'. Requires --enable-autoscaling.",
+	)
+	fs.StringVar(
+		&args.clusterAutoscaler.ScaleDownUnneededTime,
+		autoscalerScaleDownUnneededTimeFlag,
+		"",
+		"Time that a node has to be unneeded before the cluster autoscaler removes it, for "+
+			"example '10m'. Requires --enable-autoscaling.",
+	)
+
 	fs.StringVar(
 		&args.networkType,
 		"network-type",
@@ -399,6 +593,17 @@ func init() {
 	)
 	arguments.SetQuestion(fs, "wif-config", "WIF configuration:")
 	Cmd.RegisterFlagCompletionFunc("wif-config", arguments.MakeCompleteFunc(getWifConfigNameOptions))
+
+	fs.BoolVar(
+		&args.auditLogForwarding,
+		auditLogForwardingFlag,
+		false,
+		"Checks that the GCP Workload Identity Federation config grants the roles needed to "+
+			"forward the audit logs of the cluster.",
+	)
+
+	// The structured output formats write the created cluster together with its warnings:
+	arguments.AddOutputFlag(fs)
 }
 
 func osdProviderOptions(_ *sdk.Connection) ([]arguments.Option, error) {
@@ -409,20 +614,48 @@ func osdProviderOptions(_ *sdk.Connection) ([]arguments.Option, error) {
 }
 
 func getRegionOptions(connection *sdk.Connection) ([]arguments.Option, error) {
-	regions, err := provider.GetRegions(connection.ClustersMgmt().V1(), args.provider, args.ccs)
+	return regionOptions(connection, args.provider, args.ccs, args.multiAZ, args.fedramp)
+}
+
+// regionCompletion completes the region flag with the same regions that the validation accepts.
+// Cobra doesn't guarantee that the '--ccs', '--multi-az' and '--fedramp' flags have been parsed
+// into the arguments when the completion runs, so their values are read from the flag set instead.
+func regionCompletion(cmd *cobra.Command, argv []string,
+	toComplete string) ([]string, cobra.ShellCompDirective) {
+	fs := cmd.Flags()
+	ccs := args.ccs
+	ccs.Enabled, _ = fs.GetBool("ccs")
+	multiAZ, _ := fs.GetBool("multi-az")
+	fedramp, _ := fs.GetBool(fedrampFlag)
+	cloudProvider, _ := fs.GetString("provider")
+	complete := arguments.MakeCompleteFunc(func(connection *sdk.Connection) ([]arguments.Option, error) {
+		return regionOptions(connection, cloudProvider, ccs, multiAZ, fedramp)
+	})
+	return complete(cmd, argv, toComplete)
+}
+
+// regionOptions returns the regions where a cluster can be created. FedRAMP clusters can only use
+// AWS GovCloud regions. Other clusters can use any region, including GovCloud ones, as in the gov
+// environment all the regions are GovCloud regions.
+func regionOptions(connection *sdk.Connection, cloudProvider string, ccs c.CCS,
+	multiAZ bool, fedramp bool) ([]arguments.Option, error) {
+	regions, err := provider.GetRegions(connection.ClustersMgmt().V1(), cloudProvider, ccs)
 	if err != nil {
 		return nil, err
 	}
 	options := []arguments.Option{}
 	for _, region := range regions {
-		if !args.ccs.Enabled && region.CCSOnly() {
+		if !ccs.Enabled && region.CCSOnly() {
+			continue
+		}
+		if multiAZ && !region.SupportsMultiAZ() {
 			continue
 		}
-		if args.multiAZ && !region.SupportsMultiAZ() {
+		if fedramp && !region.GovCloud() {
 			continue
 		}
 		// `enabled` flag only affects Red Hat infra. All regions enabled on CCS.
-		if args.ccs.Enabled || region.Enabled() {
+		if ccs.Enabled || region.Enabled() {
 			options = append(options, arguments.Option{
 				Value:       region.ID(),
 				Description: region.DisplayName(),
@@ -508,12 +741,29 @@ func getVersionOptionsWithDefault(
 	return
 }
 
+// hasVersionOption returns true if the given version is one of the given options.
+func hasVersionOption(options []arguments.Option, version string) bool {
+	for _, option := range options {
+		if option.Value == version {
+			return true
+		}
+	}
+	return false
+}
+
 func getSubscriptionTypeOptions(connection *sdk.Connection) ([]arguments.Option, error) {
-	options := []arguments.Option{}
 	billingModels, err := billing.GetBillingModels(connection)
 	if err != nil {
-		return options, err
+		return []arguments.Option{}, err
 	}
+	return subscriptionTypeOptions(billingModels), nil
+}
+
+// subscriptionTypeOptions returns the options of the subscription type menu, with the description
+// of each billing model. The standard billing model is always the first option, so that it is the
+// one selected by default.
+func subscriptionTypeOptions(billingModels []*amv1.BillingModelItem) []arguments.Option {
+	options := []arguments.Option{}
 	for _, billingModel := range billingModels {
 		option := subscriptionTypeOption(billingModel.ID(), billingModel.Description())
 		//Standard billing model should always be the first option
@@ -523,12 +773,13 @@ func getSubscriptionTypeOptions(connection *sdk.Connection) ([]arguments.Option,
 			options = append(options, option)
 		}
 	}
-	return options, nil
+	return options
 }
 
 func subscriptionTypeOption(id string, description string) arguments.Option {
 	option := arguments.Option{
-		Value: setSubscriptionTypeOption(id, description),
+		Value:       id,
+		Description: description,
 	}
 	return option
 }
@@ -536,7 +787,7 @@ func subscriptionTypeOption(id string, description string) arguments.Option {
 func getMachineTypeOptions(connection *sdk.Connection) ([]arguments.Option, error) {
 	return provider.GetMachineTypeOptions(
 		connection.ClustersMgmt().V1(),
-		args.provider, args.ccs.Enabled)
+		args.provider, args.ccs.Enabled, args.instanceCategory)
 }
 
 func getWifConfigOptions(wifConfigs []*cmv1.WifConfig) ([]arguments.Option, error) {
@@ -564,6 +815,15 @@ func networkTypeCompletion(cmd *cobra.Command, args []string, toComplete string)
 	return []string{c.NetworkTypeSDN, c.NetworkTypeOVN}, cobra.ShellCompDirectiveDefault
 }
 
+func imdsCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{c.ImdsRequired, c.ImdsOptional}, cobra.ShellCompDirectiveDefault
+}
+
+func instanceCategoryCompletion(cmd *cobra.Command, args []string,
+	toComplete string) ([]string, cobra.ShellCompDirective) {
+	return provider.MachineTypeCategories, cobra.ShellCompDirectiveDefault
+}
+
 func minComputeNodes(ccs bool, multiAZ bool) (min int) {
 	if ccs {
 		if multiAZ {
@@ -582,15 +842,48 @@ func minComputeNodes(ccs bool, multiAZ bool) (min int) {
 }
 
 func preRun(cmd *cobra.Command, argv []string) error {
-	var err error
-	// Create the client for the OCM API:
-	connection, err := ocm.NewConnection().Build()
+	err := checkRegionRequired(cmd)
 	if err != nil {
-		return fmt.Errorf("Failed to create OCM connection: %v", err)
+		return err
 	}
-	defer connection.Close()
 
-	err = promptName(argv)
+	// Check the output format before asking for anything:
+	_, err = output.SelectedFormat()
+	if err != nil {
+		return err
+	}
+
+	return ocm.WithConnection(func(connection *sdk.Connection) error {
+		return promptArgs(cmd, argv, connection)
+	})
+}
+
+// checkRegionRequired makes the --region flag required when --like isn't used. It can't be marked
+// as required in init, because cobra checks the required flags before the region is taken from the
+// cluster given with --like.
+func checkRegionRequired(cmd *cobra.Command) error {
+	if args.like != "" {
+		return nil
+	}
+	err := cobra.MarkFlagRequired(cmd.Flags(), "region")
+	if err != nil {
+		return err
+	}
+	return cmd.ValidateRequiredFlags()
+}
+
+func promptArgs(cmd *cobra.Command, argv []string, connection *sdk.Connection) error {
+	if args.resumeAnswers {
+		if !args.interactive {
+			return fmt.Errorf("Flag --resume-answers can only be used with --interactive")
+		}
+		err := arguments.ResumeAnswers("create-cluster")
+		if err != nil {
+			return err
+		}
+	}
+
+	err := promptName(argv)
 	if err != nil {
 		return err
 	}
@@ -598,9 +891,16 @@ func preRun(cmd *cobra.Command, argv []string) error {
 	// Validate flags / ask for missing data.
 	fs := cmd.Flags()
 
+	if args.like != "" {
+		err = applyLikeDefaults(fs, connection, args.like)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Get options for subscription type
 	subscriptionTypeOptions, _ := getSubscriptionTypeOptions(connection)
-	err = arguments.PromptOneOf(fs, "subscription-type", subscriptionTypeOptions)
+	err = arguments.PromptOneOfWithDescriptions(fs, "subscription-type", subscriptionTypeOptions)
 	if err != nil {
 		return err
 	}
@@ -645,6 +945,14 @@ func preRun(cmd *cobra.Command, argv []string) error {
 		return err
 	}
 
+	err = arguments.CheckOneOf(fs, imdsFlag, []arguments.Option{
+		{Value: c.ImdsRequired},
+		{Value: c.ImdsOptional},
+	})
+	if err != nil {
+		return err
+	}
+
 	if wasClusterWideProxyReceived() {
 		args.ccs.Enabled = true
 		args.existingVPC.Enabled = true
@@ -661,11 +969,31 @@ func preRun(cmd *cobra.Command, argv []string) error {
 		return err
 	}
 
+	err = validateFedRAMP(fs)
+	if err != nil {
+		return err
+	}
+
+	err = validateEtcdKMSKeyARN(fs)
+	if err != nil {
+		return err
+	}
+
+	if args.auditLogForwarding && args.gcpAuthentication.Type != c.AuthenticationWif {
+		return fmt.Errorf("--%s is only supported for clusters using a WIF configuration",
+			auditLogForwardingFlag)
+	}
+
 	err = arguments.PromptBool(fs, "multi-az")
 	if err != nil {
 		return err
 	}
 
+	err = checkMultiAZComputeNodes(fs)
+	if err != nil {
+		return err
+	}
+
 	err = promptSecureBoot(fs)
 	if err != nil {
 		return err
@@ -679,6 +1007,9 @@ func preRun(cmd *cobra.Command, argv []string) error {
 	if err != nil {
 		return err
 	}
+	if args.region == "" {
+		return fmt.Errorf("Flag --region is required, unless it is taken from --%s", likeFlag)
+	}
 
 	var gcpMarketplaceEnabled string
 	if isGcpMarketplace {
@@ -690,11 +1021,15 @@ func preRun(cmd *cobra.Command, argv []string) error {
 	if err != nil {
 		return err
 	}
-	if args.version == "" {
+	if !fs.Changed("version") && !hasVersionOption(versions, args.version) {
+		// Also replaces a version taken from --like that is no longer enabled:
 		args.version = defaultVersion
 	}
-	args.version = c.DropOpenshiftVPrefix(args.version)
-	err = arguments.PromptOrCheckOneOf(fs, "version", versions)
+	err = arguments.PromptOneOfWithDescriptions(fs, "version", versions)
+	if err != nil {
+		return err
+	}
+	err = arguments.CheckOneOf(fs, "version", versions)
 	if err != nil {
 		return err
 	}
@@ -703,6 +1038,13 @@ func preRun(cmd *cobra.Command, argv []string) error {
 		return fmt.Errorf("Version is required for channel group '%s'", args.channelGroup)
 	}
 
+	if args.releaseImage != "" {
+		err = c.ValidateReleaseImage(args.releaseImage, args.channelGroup)
+		if err != nil {
+			return fmt.Errorf("Invalid --%s: %v", releaseImageFlag, err)
+		}
+	}
+
 	// Retrieve valid flavours
 	flavours, err := getFlavourOptions(connection)
 	if err != nil {
@@ -714,6 +1056,10 @@ func preRun(cmd *cobra.Command, argv []string) error {
 	}
 
 	// Compute node instance type:
+	err = provider.ValidateMachineTypeCategory(args.instanceCategory)
+	if err != nil {
+		return err
+	}
 	machineTypes, err := getMachineTypeOptions(connection)
 	if err != nil {
 		return err
@@ -728,7 +1074,12 @@ func preRun(cmd *cobra.Command, argv []string) error {
 		return err
 	}
 
-	err = arguments.CheckAutoscalingFlags(args.autoscaling, args.computeNodes)
+	err = arguments.CheckAutoscalingFlags(fs, args.autoscaling, args.computeNodes)
+	if err != nil {
+		return err
+	}
+
+	err = validateClusterAutoscaler(fs)
 	if err != nil {
 		return err
 	}
@@ -744,11 +1095,35 @@ func preRun(cmd *cobra.Command, argv []string) error {
 		}
 	}
 
+	if fs.Changed(workerDiskSizeFlag) {
+		err = c.ValidateWorkerDiskSize(args.provider, args.workerDiskSize)
+		if err != nil {
+			return fmt.Errorf("Invalid --%s: %v", workerDiskSizeFlag, err)
+		}
+	}
+
+	if fs.Changed(workerVolumeIOPSFlag) {
+		err = c.ValidateWorkerVolumeIOPS(args.provider, args.workerVolumeIOPS)
+		if err != nil {
+			return fmt.Errorf("Invalid --%s: %v", workerVolumeIOPSFlag, err)
+		}
+	}
+
+	err = validatePrivateLink(fs)
+	if err != nil {
+		return err
+	}
+
 	err = promptClusterPrivacy(fs)
 	if err != nil {
 		return err
 	}
 
+	err = validatePublicAPI()
+	if err != nil {
+		return err
+	}
+
 	if args.existingVPC.SubnetIDs != "" {
 		args.existingVPC.Enabled = true
 	}
@@ -758,7 +1133,22 @@ func preRun(cmd *cobra.Command, argv []string) error {
 		return err
 	}
 
-	err = promptPrivateServiceConnect(fs)
+	err = checkPrivateLinkSubnets()
+	if err != nil {
+		return err
+	}
+
+	err = validateSharedVPC()
+	if err != nil {
+		return err
+	}
+
+	err = validateDefaultPoolAZs()
+	if err != nil {
+		return err
+	}
+
+	err = promptPrivateServiceConnect(fs, connection)
 	if err != nil {
 		return err
 	}
@@ -768,7 +1158,9 @@ func preRun(cmd *cobra.Command, argv []string) error {
 		return err
 	}
 
-	if args.interactive {
+	// The networking of the cluster given with --like takes precedence over the defaults of the
+	// flavour:
+	if args.interactive && args.like == "" {
 		machineCIDR, podCIDR, serviceCIDR, hostPrefix := GetDefaultClusterFlavors(connection, args.flavour)
 		args.machineCIDR, args.podCIDR, args.serviceCIDR, args.hostPrefix = *machineCIDR, *podCIDR, *serviceCIDR, hostPrefix
 	}
@@ -778,6 +1170,11 @@ func preRun(cmd *cobra.Command, argv []string) error {
 		return err
 	}
 
+	err = c.ValidateNetworkCIDRs(args.machineCIDR, args.serviceCIDR, args.podCIDR, args.hostPrefix)
+	if err != nil {
+		return err
+	}
+
 	err = arguments.PromptString(fs, "domain-prefix")
 	if err != nil {
 		return err
@@ -786,32 +1183,157 @@ func preRun(cmd *cobra.Command, argv []string) error {
 	return nil
 }
 
+// likeDefault is the value of a flag taken from the cluster given with --like.
+type likeDefault struct {
+	flag  string
+	value string
+}
+
+// likeDefaults returns the values of the flags that describe the given cluster, skipping the
+// settings that the cluster doesn't have.
+func likeDefaults(cluster *cmv1.Cluster) []likeDefault {
+	var defaults []likeDefault
+	add := func(flag, value string) {
+		if value != "" {
+			defaults = append(defaults, likeDefault{flag: flag, value: value})
+		}
+	}
+	add("provider", cluster.CloudProvider().ID())
+	add("ccs", strconv.FormatBool(cluster.CCS().Enabled()))
+	add("multi-az", strconv.FormatBool(cluster.MultiAZ()))
+	add("region", cluster.Region().ID())
+	add("version", c.DropOpenshiftVPrefix(cluster.Version().ID()))
+	add("channel-group", cluster.Version().ChannelGroup())
+	add("compute-machine-type", cluster.Nodes().ComputeMachineType().ID())
+	if compute := cluster.Nodes().Compute(); compute > 0 {
+		add("compute-nodes", strconv.Itoa(compute))
+	}
+	add("network-type", cluster.Network().Type())
+	add("machine-cidr", cluster.Network().MachineCIDR())
+	add("service-cidr", cluster.Network().ServiceCIDR())
+	add("pod-cidr", cluster.Network().PodCIDR())
+	if hostPrefix := cluster.Network().HostPrefix(); hostPrefix > 0 {
+		add("host-prefix", strconv.Itoa(hostPrefix))
+	}
+	return defaults
+}
+
+// applyLikeDefaults copies the settings of the given existing cluster to the flags that weren't
+// given in the command line.
+func applyLikeDefaults(fs *pflag.FlagSet, connection *sdk.Connection, key string) error {
+	if !c.IsValidClusterKey(key) {
+		return fmt.Errorf(
+			"Invalid --%s: cluster name, identifier or external identifier '%s' isn't "+
+				"valid, it must contain only letters, digits, dashes and underscores",
+			likeFlag, key,
+		)
+	}
+	cluster, err := c.GetCluster(connection, key)
+	if err != nil {
+		return fmt.Errorf("Failed to get cluster '%s' given with --%s: %v", key, likeFlag, err)
+	}
+	return setLikeDefaults(fs, likeDefaults(cluster))
+}
+
+// likeConflictFlags are the flags that, when given in the command line, must have the same value as
+// the cluster given with --like, as the rest of the copied settings only make sense for it.
+var likeConflictFlags = []string{"provider"}
+
+// setLikeDefaults sets the given values of the flags that weren't given in the command line. The
+// flags aren't marked as changed, so that interactive prompts still ask for them, offering the
+// values as defaults.
+func setLikeDefaults(fs *pflag.FlagSet, defaults []likeDefault) error {
+	for _, like := range defaults {
+		flag := fs.Lookup(like.flag)
+		if flag.Changed {
+			if slices.Contains(likeConflictFlags, like.flag) && flag.Value.String() != like.value {
+				return fmt.Errorf(
+					"Flag --%s '%s' conflicts with value '%s' of the cluster given with --%s",
+					like.flag, flag.Value.String(), like.value, likeFlag,
+				)
+			}
+			continue
+		}
+		err := flag.Value.Set(like.value)
+		if err != nil {
+			return fmt.Errorf(
+				"Can't use value '%s' for flag --%s from --%s: %v",
+				like.value, like.flag, likeFlag, err,
+			)
+		}
+	}
+	return nil
+}
+
 func run(cmd *cobra.Command, argv []string) error {
 	// TODO: can we reuse the connection from preRun()?
 	// TODO: call config.Save (https://github.com/openshift-online/ocm-cli/issues/153).
-	connection, err := ocm.NewConnection().Build()
+	return ocm.WithConnection(createCluster)
+}
+
+func createCluster(connection *sdk.Connection) error {
+	clusterConfig, err := buildClusterSpec()
 	if err != nil {
-		return fmt.Errorf("Failed to create OCM connection: %v", err)
+		return err
+	}
+
+	cluster, err := c.CreateCluster(connection.ClustersMgmt().V1(), clusterConfig, args.dryRun)
+	if err != nil {
+		return fmt.Errorf("Failed to create cluster: %v", err)
 	}
-	defer connection.Close()
 
+	// Print the result:
+	if cluster == nil {
+		if args.dryRun {
+			fmt.Println("dry run: Would be successful.")
+		}
+	} else {
+		// The cluster has already been created, so failing to remove the saved answers is
+		// only worth a warning:
+		err = arguments.ClearAnswers()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		format, err := output.SelectedFormat()
+		if err != nil {
+			return err
+		}
+		if format.IsStructured() {
+			return dumpCreatedCluster(os.Stdout, connection, cluster, format)
+		}
+		err = c.PrintClusterDescription(connection, cluster)
+		if err != nil {
+			return err
+		}
+		err = c.PrintClusterWarnings(connection, cluster)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// buildClusterSpec creates the specification of the cluster from the values of the flags, after
+// they have been checked and completed by preRun.
+func buildClusterSpec() (c.Spec, error) {
 	clusterVersion := c.EnsureOpenshiftVPrefix(args.version)
 
 	expiration, err := c.ValidateClusterExpiration(args.expirationTime, args.expirationSeconds)
 	if err != nil {
-		return err
+		return c.Spec{}, err
 	}
 
 	defaultIngress, err := buildDefaultIngressSpec()
 	if err != nil {
-		return err
+		return c.Spec{}, err
 	}
 
 	if args.interactive {
 		args.subscriptionType = parseSubscriptionType(args.subscriptionType)
 	}
 
-	clusterConfig := c.Spec{
+	return c.Spec{
 		Name:                 args.clusterName,
 		DomainPrefix:         args.domainPrefix,
 		Region:               args.region,
@@ -821,48 +1343,67 @@ func run(cmd *cobra.Command, argv []string) error {
 		ClusterWideProxy:     args.clusterWideProxy,
 		Flavour:              args.flavour,
 		MultiAZ:              args.multiAZ,
+		DefaultPoolAZs:       args.defaultPoolAZs,
 		Version:              clusterVersion,
 		ChannelGroup:         args.channelGroup,
+		ReleaseImage:         args.releaseImage,
 		Expiration:           expiration,
 		ComputeMachineType:   args.computeMachineType,
 		ComputeNodes:         args.computeNodes,
 		Autoscaling:          args.autoscaling,
+		WorkerDiskSize:       args.workerDiskSize,
+		WorkerVolumeIOPS:     args.workerVolumeIOPS,
+		ClusterAutoscaler:    args.clusterAutoscaler,
 		NetworkType:          args.networkType,
 		MachineCIDR:          args.machineCIDR,
 		ServiceCIDR:          args.serviceCIDR,
 		PodCIDR:              args.podCIDR,
 		HostPrefix:           args.hostPrefix,
 		Private:              &args.private,
+		PublicAPI:            args.publicAPI,
 		EtcdEncryption:       args.etcdEncryption,
+		FIPS:                 args.fedramp,
+		EtcdKMSKeyARN:        args.etcdKMSKeyARN,
+		Imds:                 args.imds,
+		PrivateLink:          args.privateLink,
+		SharedVPC:            args.sharedVPC,
 		DefaultIngress:       defaultIngress,
 		SubscriptionType:     args.subscriptionType,
 		GcpSecurity:          args.gcpSecureBoot,
 		GcpAuthentication:    args.gcpAuthentication,
 		GcpPrivateSvcConnect: args.gcpPrivateSvcConnect,
-	}
+	}, nil
+}
 
-	cluster, err := c.CreateCluster(connection.ClustersMgmt().V1(), clusterConfig, args.dryRun)
+// createdCluster is the document written when a structured output format is selected. It contains
+// the cluster returned by the API and the warnings that would otherwise be printed after the
+// description of the cluster.
+type createdCluster struct {
+	Cluster  json.RawMessage    `json:"cluster"`
+	Warnings []c.ClusterWarning `json:"warnings"`
+}
+
+// dumpCreatedCluster writes the created cluster and its warnings to the given stream using the given
+// structured output format.
+func dumpCreatedCluster(stream io.Writer, connection *sdk.Connection, cluster *cmv1.Cluster,
+	format output.Format) error {
+	warnings, err := c.GetClusterWarnings(connection, cluster)
 	if err != nil {
-		return fmt.Errorf("Failed to create cluster: %v", err)
+		return err
 	}
-
-	// Print the result:
-	if cluster == nil {
-		if args.dryRun {
-			fmt.Println("dry run: Would be successful.")
-		}
-	} else {
-		err = c.PrintClusterDescription(connection, cluster)
-		if err != nil {
-			return err
-		}
-		err = c.PrintClusterWarnings(connection, cluster)
-		if err != nil {
-			return err
-		}
+	buffer := &bytes.Buffer{}
+	err = cmv1.MarshalCluster(cluster, buffer)
+	if err != nil {
+		return err
 	}
-
-	return nil
+	body, err := json.Marshal(createdCluster{
+		Cluster:  buffer.Bytes(),
+		Warnings: warnings,
+	})
+	if err != nil {
+		return err
+	}
+	return dump.Object(stream, format, body)
 }
 
 func buildDefaultIngressSpec() (c.DefaultIngressSpec, error) {
@@ -907,7 +1448,12 @@ func promptName(argv []string) error {
 			Message: "Cluster name:",
 			Help:    clusterNameHelp,
 		}
-		return survey.AskOne(prompt, &args.clusterName, survey.WithValidator(survey.Required))
+		prompt.Default, _ = arguments.SavedAnswer("name")
+		err := survey.AskOne(prompt, &args.clusterName, survey.WithValidator(survey.Required))
+		if err != nil {
+			return err
+		}
+		return arguments.SaveAnswer("name", args.clusterName)
 	}
 
 	return fmt.Errorf("A cluster name must be specified")
@@ -1395,6 +1941,178 @@ func promptCCS(fs *pflag.FlagSet, presetCCS bool) error {
 	return nil
 }
 
+// validatePrivateLink checks the --private-link flag and makes the cluster private when it is used.
+func validatePrivateLink(fs *pflag.FlagSet) error {
+	if !args.privateLink {
+		return nil
+	}
+	if args.provider != c.ProviderAWS || !args.ccs.Enabled {
+		return fmt.Errorf("Flag --%s is only supported for AWS CCS clusters", privateLinkFlag)
+	}
+	if fs.Changed(privateFlag) && !args.private {
+		return fmt.Errorf("Flag --%s can't be used with --%s=false", privateLinkFlag, privateFlag)
+	}
+	return fs.Set(privateFlag, "true")
+}
+
+// checkPrivateLinkSubnets checks that AWS PrivateLink clusters are installed in the subnets of an
+// existing VPC. It needs to run after the subnets have been asked for.
+func checkPrivateLinkSubnets() error {
+	if args.privateLink && args.existingVPC.SubnetIDs == "" {
+		return fmt.Errorf("Flag --%s requires the subnets of an existing VPC, use --subnet-ids", privateLinkFlag)
+	}
+	return nil
+}
+
+// validatePublicAPI checks that the --public-api flag is only used with private clusters, and not
+// with the options that connect to the API endpoint privately.
+func validatePublicAPI() error {
+	if !args.publicAPI {
+		return nil
+	}
+	if !args.private {
+		return fmt.Errorf("Flag --%s requires --%s", publicAPIFlag, privateFlag)
+	}
+	if args.privateLink {
+		return fmt.Errorf(
+			"Flags --%s and --%s can't be used at the same time, as AWS PrivateLink "+
+				"requires a private API endpoint",
+			publicAPIFlag, privateLinkFlag,
+		)
+	}
+	if args.gcpPrivateSvcConnect.SvcAttachmentSubnet != "" {
+		return fmt.Errorf(
+			"Flags --%s and --%s can't be used at the same time, as Private Service "+
+				"Connect requires a private API endpoint",
+			publicAPIFlag, pscSubnetFlag,
+		)
+	}
+	return nil
+}
+
+// validateFedRAMP checks the --fedramp flag and enables etcd encryption when it is used. The region
+// is checked later, as only AWS GovCloud regions are offered for FedRAMP clusters.
+func validateFedRAMP(fs *pflag.FlagSet) error {
+	if !args.fedramp {
+		return nil
+	}
+	if args.provider != c.ProviderAWS || !args.ccs.Enabled {
+		return fmt.Errorf("Flag --%s is only supported for AWS CCS clusters", fedrampFlag)
+	}
+	if fs.Changed(etcdEncryptionFlag) && !args.etcdEncryption {
+		return fmt.Errorf("Flag --%s can't be used with --%s=false", fedrampFlag, etcdEncryptionFlag)
+	}
+	args.etcdEncryption = true
+	return nil
+}
+
+// validateDefaultPoolAZs checks that the availability zones of the default machine pool are
+// only given for multi-zone clusters whose zones aren't determined by subnets, and that they are
+// zones of the region.
+func validateDefaultPoolAZs() error {
+	if len(args.defaultPoolAZs) == 0 {
+		return nil
+	}
+	if !args.multiAZ {
+		return fmt.Errorf("Flag --%s requires --multi-az", defaultPoolAZsFlag)
+	}
+	if args.existingVPC.SubnetIDs != "" || len(args.existingVPC.AvailabilityZones) > 0 {
+		return fmt.Errorf(
+			"Flag --%s can't be used with --subnet-ids or --availability-zones, as the "+
+				"subnets already determine the zones",
+			defaultPoolAZsFlag,
+		)
+	}
+	err := c.ValidateAvailabilityZones(args.defaultPoolAZs, args.region)
+	if err != nil {
+		return fmt.Errorf("Invalid --%s: %v", defaultPoolAZsFlag, err)
+	}
+	return nil
+}
+
+// validateSharedVPC checks the flags of the private hosted zone of a shared VPC. They must be used
+// together, only for AWS CCS clusters installed in the subnets of an existing VPC.
+func validateSharedVPC() error {
+	zone := args.sharedVPC.HostedZoneID
+	role := args.sharedVPC.Route53RoleARN
+	if zone == "" && role == "" {
+		return nil
+	}
+	if zone == "" || role == "" {
+		return fmt.Errorf(
+			"Flags --%s and --%s must be used together",
+			sharedVPCHostedZoneIDFlag, sharedVPCRoute53RoleARNFlag,
+		)
+	}
+	if args.provider != c.ProviderAWS || !args.ccs.Enabled {
+		return fmt.Errorf("Flag --%s is only supported for AWS CCS clusters", sharedVPCHostedZoneIDFlag)
+	}
+	if args.existingVPC.SubnetIDs == "" {
+		return fmt.Errorf(
+			"Flag --%s requires the subnets of an existing VPC, use --subnet-ids",
+			sharedVPCHostedZoneIDFlag,
+		)
+	}
+	err := c.ValidateIAMRoleARN(role)
+	if err != nil {
+		return fmt.Errorf("Invalid --%s: %v", sharedVPCRoute53RoleARNFlag, err)
+	}
+	return nil
+}
+
+// validateEtcdKMSKeyARN checks the --etcd-kms-key-arn flag and enables etcd encryption when it is
+// used.
+func validateEtcdKMSKeyARN(fs *pflag.FlagSet) error {
+	if args.etcdKMSKeyARN == "" {
+		return nil
+	}
+	if !args.ccs.Enabled {
+		return fmt.Errorf("Flag --%s is only supported for CCS clusters", etcdKMSKeyARNFlag)
+	}
+	if fs.Changed(etcdEncryptionFlag) && !args.etcdEncryption {
+		return fmt.Errorf(
+			"Flag --%s can't be used with --%s=false",
+			etcdKMSKeyARNFlag, etcdEncryptionFlag,
+		)
+	}
+	err := c.ValidateKMSKeyARN(args.etcdKMSKeyARN)
+	if err != nil {
+		return fmt.Errorf("Invalid --%s: %v", etcdKMSKeyARNFlag, err)
+	}
+	args.etcdEncryption = true
+	return nil
+}
+
+// validateClusterAutoscaler checks the flags of the cluster autoscaler. They are only accepted
+// together with --enable-autoscaling, and their values must be durations within the supported
+// range. The maximum node provision time can't be zero, but the scale down delays can.
+func validateClusterAutoscaler(fs *pflag.FlagSet) error {
+	flags := []struct {
+		name  string
+		value string
+		min   time.Duration
+	}{
+		{autoscalerMaxNodeProvisionTimeFlag, args.clusterAutoscaler.MaxNodeProvisionTime, time.Second},
+		{autoscalerScaleDownDelayAfterAddFlag, args.clusterAutoscaler.ScaleDownDelayAfterAdd, 0},
+		{autoscalerScaleDownDelayAfterDeleteFlag, args.clusterAutoscaler.ScaleDownDelayAfterDelete, 0},
+		{autoscalerScaleDownDelayAfterFailureFlag, args.clusterAutoscaler.ScaleDownDelayAfterFailure, 0},
+		{autoscalerScaleDownUnneededTimeFlag, args.clusterAutoscaler.ScaleDownUnneededTime, 0},
+	}
+	for _, flag := range flags {
+		if !fs.Changed(flag.name) {
+			continue
+		}
+		if !args.autoscaling.Enabled {
+			return fmt.Errorf("Flag --%s requires --enable-autoscaling", flag.name)
+		}
+		err := c.ValidateAutoscalerDuration(flag.value, flag.min)
+		if err != nil {
+			return fmt.Errorf("Invalid --%s: %v", flag.name, err)
+		}
+	}
+	return nil
+}
+
 func promptAuthentication(fs *pflag.FlagSet, connection *sdk.Connection) error {
 	var err error
 	if !args.ccs.Enabled {
@@ -1474,7 +2192,7 @@ func promptGcpAuth(fs *pflag.FlagSet, connection *sdk.Connection) error {
 		if args.gcpServiceAccountFile == "" {
 			return fmt.Errorf("a valid GCP service account file must be specified for CCS clusters")
 		}
-		err = constructGCPCredentials(args.gcpServiceAccountFile, &args.ccs)
+		args.ccs.GCP, err = c.ReadGCPCredentials(args.gcpServiceAccountFile.String())
 		if err != nil {
 			return err
 		}
@@ -1495,7 +2213,7 @@ func promptWifConfig(fs *pflag.FlagSet, connection *sdk.Connection) error {
 			return err
 		}
 		args.gcpAuthentication.Id = wifConfig.ID()
-		return nil
+		return checkAuditLogForwardingRoles(wifConfig)
 	}
 
 	// if the flag was not set, prompt the user
@@ -1514,11 +2232,29 @@ func promptWifConfig(fs *pflag.FlagSet, connection *sdk.Connection) error {
 	args.gcpWifConfig = parseWifConfigOption(args.gcpWifConfig)
 
 	// map wif name to wif id
-	wifMapping := map[string]string{}
+	wifMapping := map[string]*cmv1.WifConfig{}
 	for _, wc := range wifConfigs {
-		wifMapping[wc.DisplayName()] = wc.ID()
+		wifMapping[wc.DisplayName()] = wc
+	}
+	wifConfig := wifMapping[args.gcpWifConfig]
+	args.gcpAuthentication.Id = wifConfig.ID()
+	return checkAuditLogForwardingRoles(wifConfig)
+}
+
+// checkAuditLogForwardingRoles verifies that the WIF config grants the roles needed to forward
+// the audit logs, when audit log forwarding was requested.
+func checkAuditLogForwardingRoles(wifConfig *cmv1.WifConfig) error {
+	if !args.auditLogForwarding {
+		return nil
+	}
+	missing := provider.MissingWifConfigRoles(wifConfig, provider.AuditLogForwardingRoles)
+	if len(missing) > 0 {
+		return fmt.Errorf(
+			"WIF configuration '%s' is missing the roles needed for audit log forwarding: %s. "+
+				"Run 'ocm gcp update wif-config %s' to grant them and try again",
+			wifConfig.DisplayName(), strings.Join(missing, ", "), wifConfig.ID(),
+		)
 	}
-	args.gcpAuthentication.Id = wifMapping[args.gcpWifConfig]
 	return nil
 }
 
@@ -1550,7 +2286,7 @@ func promptSecureBoot(fs *pflag.FlagSet) error {
 	return nil
 }
 
-func promptPrivateServiceConnect(fs *pflag.FlagSet) error {
+func promptPrivateServiceConnect(fs *pflag.FlagSet, connection *sdk.Connection) error {
 	if args.provider != c.ProviderGCP ||
 		!args.existingVPC.Enabled || !args.private {
 		return nil
@@ -1583,6 +2319,40 @@ func promptPrivateServiceConnect(fs *pflag.FlagSet) error {
 			"flag '%s' is required when cluster is '%s' and GCP authentication type is %s",
 			pscSubnetFlag, privateFlag, c.AuthenticationWif)
 	}
+	if args.gcpPrivateSvcConnect.SvcAttachmentSubnet == "" {
+		return nil
+	}
+
+	// A subnet of purpose 'Private Service Connect' can't be used for the cluster nodes:
+	pscSubnet := args.gcpPrivateSvcConnect.SvcAttachmentSubnet
+	if pscSubnet == args.existingVPC.ControlPlaneSubnet || pscSubnet == args.existingVPC.ComputeSubnet {
+		return fmt.Errorf("The %s '%s' must be a subnet of purpose 'Private Service Connect', "+
+			"different from the %s and the %s", pscSubnetFlag, pscSubnet,
+			controlPlaneSubnetFlag, computePlaneSubnetFlag)
+	}
+
+	// skip validation if shared vpc is used, as the subnets aren't in the cluster project
+	if args.existingVPC.VPCProjectID != "" {
+		return nil
+	}
+
+	// Verify that the psc-subnet provided in the command does exist. Note that the purpose of
+	// the subnet isn't returned by the API, so that is only checked when the cluster is created.
+	subnetList, err := provider.GetGCPSubnetList(connection.ClustersMgmt().V1(), args.provider,
+		args.ccs, args.gcpAuthentication, args.region)
+	if err != nil {
+		return err
+	}
+	verifiedPscSubnet := false
+	for _, subnetID := range subnetList {
+		if subnetID == pscSubnet {
+			verifiedPscSubnet = true
+			break
+		}
+	}
+	if !verifiedPscSubnet {
+		return fmt.Errorf("Could not find the following %s provided: '%s'", pscSubnetFlag, pscSubnet)
+	}
 	return nil
 }
 
@@ -1592,11 +2362,69 @@ func validateComputeNodes() error {
 		return fmt.Errorf("Minimum is %d nodes", min)
 	}
 	if args.multiAZ && args.computeNodes%3 != 0 {
-		return fmt.Errorf("Multi-zone clusters require nodes to be multiple of 3")
+		return fmt.Errorf(
+			"Multi-zone clusters require nodes to be multiple of 3, the nearest valid value is %d",
+			multiAZComputeNodes(args.computeNodes),
+		)
+	}
+	return nil
+}
+
+// multiAZNodeFlags are the flags that set numbers of compute nodes, that need to be multiples of 3
+// for multi-zone clusters.
+var multiAZNodeFlags = []string{"compute-nodes", "min-replicas", "max-replicas"}
+
+// checkMultiAZComputeNodes checks, as soon as it is known that the cluster is multi-zone, that the
+// numbers of compute nodes given in the command line, either fixed or the autoscaling limits, are
+// multiples of 3, so that the error isn't reported after all the other questions. In interactive
+// mode it offers to round the numbers up.
+func checkMultiAZComputeNodes(fs *pflag.FlagSet) error {
+	if !args.multiAZ {
+		return nil
+	}
+	for _, flagName := range multiAZNodeFlags {
+		if !fs.Changed(flagName) {
+			continue
+		}
+		nodes, err := fs.GetInt(flagName)
+		if err != nil {
+			return err
+		}
+		if nodes%3 == 0 {
+			continue
+		}
+		nearest := multiAZComputeNodes(nodes)
+		if !args.interactive {
+			return fmt.Errorf(
+				"Invalid --%s: multi-zone clusters require nodes to be multiple "+
+					"of 3, the nearest valid value is %d",
+				flagName, nearest,
+			)
+		}
+		round, err := arguments.PromptConfirm(fs, fmt.Sprintf(
+			"Multi-zone clusters require nodes to be multiple of 3, round --%s %d up to %d?",
+			flagName, nodes, nearest,
+		))
+		if err != nil {
+			return err
+		}
+		if !round {
+			// The question will be asked again later, as the value isn't valid.
+			continue
+		}
+		err = fs.Set(flagName, strconv.Itoa(nearest))
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// multiAZComputeNodes rounds the given number of compute nodes up to the nearest multiple of 3.
+func multiAZComputeNodes(nodes int) int {
+	return (nodes + 2) / 3 * 3
+}
+
 func validateAutoscalingMin() error {
 	min := minComputeNodes(args.ccs.Enabled, args.multiAZ)
 
@@ -1626,11 +2454,26 @@ func fetchFlavours(client *cmv1.Client) (flavours []*cmv1.Flavour, err error) {
 	page := 1
 	size := 100
 	for {
+		// Retry each page for a short time, so that a transient error doesn't abort the
+		// creation of the cluster before the user even sees the questions. Other errors won't
+		// go away by retrying, so they are returned immediately:
 		var response *cmv1.FlavoursListResponse
-		response, err = collection.List().
-			Page(page).
-			Size(size).
-			Send()
+		var permanentErr error
+		err = utils.RetryWithBackoffandTimeout(func() error {
+			var sendErr error
+			response, sendErr = collection.List().
+				Page(page).
+				Size(size).
+				Send()
+			if sendErr != nil && response.Status() != 0 && !ocm.IsTransientStatus(response.Status()) {
+				permanentErr = sendErr
+				return nil
+			}
+			return sendErr
+		}, fetchFlavoursRetryDelay, fetchFlavoursRetryTimeout)
+		if permanentErr != nil {
+			err = permanentErr
+		}
 		if err != nil {
 			return
 		}
@@ -1643,21 +2486,6 @@ func fetchFlavours(client *cmv1.Client) (flavours []*cmv1.Flavour, err error) {
 	return
 }
 
-func constructGCPCredentials(filePath arguments.FilePath, value *c.CCS) error {
-	// Open our jsonFile
-	jsonFile, err := os.Open(filePath.String())
-	if err != nil {
-		return err
-	}
-	defer jsonFile.Close()
-	byteValue, _ := io.ReadAll(jsonFile)
-	err = json.Unmarshal(byteValue, &value.GCP)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
 func promptAutoscaling(fs *pflag.FlagSet) error {
 	err := arguments.PromptBool(fs, "enable-autoscaling")
 	if err != nil {
@@ -0,0 +1,1080 @@
+package cluster
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
+	"github.com/openshift-online/ocm-cli/pkg/billing"
+	c "github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/output"
+	"github.com/openshift-online/ocm-cli/pkg/provider"
+)
+
+var _ = Describe("Subscription type options", func() {
+	makeBillingModels := func(ids ...string) []*amv1.BillingModelItem {
+		var result []*amv1.BillingModelItem
+		for _, id := range ids {
+			item, err := amv1.NewBillingModelItem().
+				ID(id).
+				Description("Description of " + id).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			result = append(result, item)
+		}
+		return result
+	}
+
+	DescribeTable(
+		"Always puts the standard billing model first",
+		func(ids []string, expected []string) {
+			options := subscriptionTypeOptions(makeBillingModels(ids...))
+			var values []string
+			for _, option := range options {
+				values = append(values, option.Value)
+			}
+			Expect(values).To(Equal(expected))
+		},
+		Entry(
+			"Already first",
+			[]string{
+				billing.StandardSubscriptionType,
+				billing.MarketplaceRhmSubscriptionType,
+				billing.MarketplaceGcpSubscriptionType,
+			},
+			[]string{
+				billing.StandardSubscriptionType,
+				billing.MarketplaceRhmSubscriptionType,
+				billing.MarketplaceGcpSubscriptionType,
+			},
+		),
+		Entry(
+			"Last",
+			[]string{
+				billing.MarketplaceRhmSubscriptionType,
+				billing.MarketplaceGcpSubscriptionType,
+				billing.StandardSubscriptionType,
+			},
+			[]string{
+				billing.StandardSubscriptionType,
+				billing.MarketplaceRhmSubscriptionType,
+				billing.MarketplaceGcpSubscriptionType,
+			},
+		),
+		Entry(
+			"Missing",
+			[]string{
+				billing.MarketplaceGcpSubscriptionType,
+				billing.MarketplaceRhmSubscriptionType,
+			},
+			[]string{
+				billing.MarketplaceGcpSubscriptionType,
+				billing.MarketplaceRhmSubscriptionType,
+			},
+		),
+	)
+
+	It("Keeps the descriptions of the billing models", func() {
+		options := subscriptionTypeOptions(makeBillingModels(
+			billing.MarketplaceGcpSubscriptionType,
+			billing.StandardSubscriptionType,
+		))
+		Expect(options).To(HaveLen(2))
+		Expect(options[0].Description).To(Equal("Description of standard"))
+		Expect(options[1].Description).To(Equal("Description of marketplace-gcp"))
+	})
+
+	It("Returns an empty list without billing models", func() {
+		Expect(subscriptionTypeOptions(nil)).To(BeEmpty())
+	})
+
+	DescribeTable(
+		"Parses the subscription type from the option",
+		func(id string) {
+			option := subscriptionTypeOption(id, "Description of "+id)
+			Expect(parseSubscriptionType(option.Value)).To(Equal(id))
+		},
+		Entry("Standard", billing.StandardSubscriptionType),
+		Entry("Red Hat Marketplace", billing.MarketplaceRhmSubscriptionType),
+		Entry("Google Cloud Marketplace", billing.MarketplaceGcpSubscriptionType),
+	)
+})
+
+var _ = Describe("Public API validation", func() {
+	AfterEach(func() {
+		args.private = false
+		args.privateLink = false
+		args.publicAPI = false
+		args.gcpPrivateSvcConnect = c.GcpPrivateSvcConnect{}
+		args.clusterName = ""
+		args.region = ""
+		args.defaultIngressRouteSelectors = ""
+		args.defaultIngressWildcardPolicy = ""
+	})
+
+	DescribeTable(
+		"Accepts valid combinations",
+		func(private, publicAPI bool) {
+			args.private = private
+			args.publicAPI = publicAPI
+			Expect(validatePublicAPI()).To(Succeed())
+		},
+		Entry("Public cluster", false, false),
+		Entry("Private cluster", true, false),
+		Entry("Private cluster with public API", true, true),
+	)
+
+	It("Rejects public API without private cluster", func() {
+		args.publicAPI = true
+		Expect(validatePublicAPI()).To(MatchError("Flag --public-api requires --private"))
+	})
+
+	It("Rejects public API with AWS PrivateLink", func() {
+		args.private = true
+		args.privateLink = true
+		args.publicAPI = true
+		Expect(validatePublicAPI()).To(MatchError(
+			"Flags --public-api and --private-link can't be used at the same time, as AWS " +
+				"PrivateLink requires a private API endpoint",
+		))
+	})
+
+	It("Rejects public API with Private Service Connect", func() {
+		args.private = true
+		args.publicAPI = true
+		args.gcpPrivateSvcConnect.SvcAttachmentSubnet = "my-psc-subnet"
+		Expect(validatePublicAPI()).To(MatchError(
+			"Flags --public-api and --psc-subnet can't be used at the same time, as Private " +
+				"Service Connect requires a private API endpoint",
+		))
+	})
+
+	It("Sends a public API and a private default ingress", func() {
+		args.clusterName = "my-cluster"
+		args.region = "us-east-1"
+		args.private = true
+		args.publicAPI = true
+		Expect(validatePublicAPI()).To(Succeed())
+		spec, err := buildClusterSpec()
+		Expect(err).ToNot(HaveOccurred())
+		cluster := createClusterRequest(spec)
+		Expect(cluster.API().Listening()).To(Equal(cmv1.ListeningMethodExternal))
+		ingresses := cluster.Ingresses().Slice()
+		Expect(ingresses).To(HaveLen(1))
+		Expect(ingresses[0].Default()).To(BeTrue())
+		Expect(ingresses[0].Listening()).To(Equal(cmv1.ListeningMethodInternal))
+	})
+
+	It("Keeps the default ingress settings of a private default ingress", func() {
+		args.clusterName = "my-cluster"
+		args.region = "us-east-1"
+		args.private = true
+		args.publicAPI = true
+		args.defaultIngressRouteSelectors = "route=internal"
+		args.defaultIngressWildcardPolicy = "WildcardsAllowed"
+		spec, err := buildClusterSpec()
+		Expect(err).ToNot(HaveOccurred())
+		cluster := createClusterRequest(spec)
+		Expect(cluster.API().Listening()).To(Equal(cmv1.ListeningMethodExternal))
+		ingresses := cluster.Ingresses().Slice()
+		Expect(ingresses).To(HaveLen(1))
+		Expect(ingresses[0].Default()).To(BeTrue())
+		Expect(ingresses[0].Listening()).To(Equal(cmv1.ListeningMethodInternal))
+		Expect(ingresses[0].RouteSelectors()).To(Equal(map[string]string{"route": "internal"}))
+		Expect(ingresses[0].RouteWildcardPolicy()).To(Equal(cmv1.WildcardPolicyWildcardsAllowed))
+	})
+
+	It("Sends a private API without a default ingress", func() {
+		args.clusterName = "my-cluster"
+		args.region = "us-east-1"
+		args.private = true
+		spec, err := buildClusterSpec()
+		Expect(err).ToNot(HaveOccurred())
+		cluster := createClusterRequest(spec)
+		Expect(cluster.API().Listening()).To(Equal(cmv1.ListeningMethodInternal))
+		Expect(cluster.Ingresses().Len()).To(BeZero())
+	})
+})
+
+var _ = Describe("Like defaults", func() {
+	It("Takes the settings of the cluster", func() {
+		cluster, err := cmv1.NewCluster().
+			CloudProvider(cmv1.NewCloudProvider().ID("aws")).
+			CCS(cmv1.NewCCS().Enabled(true)).
+			MultiAZ(true).
+			Region(cmv1.NewCloudRegion().ID("us-east-1")).
+			Version(cmv1.NewVersion().
+				ID("openshift-v4.16.3-candidate").
+				RawID("4.16.3").
+				ChannelGroup("candidate")).
+			Nodes(cmv1.NewClusterNodes().
+				ComputeMachineType(cmv1.NewMachineType().ID("m5.xlarge")).
+				Compute(6)).
+			Network(cmv1.NewNetwork().
+				Type("OVNKubernetes").
+				MachineCIDR("10.0.0.0/16").
+				ServiceCIDR("172.30.0.0/16").
+				PodCIDR("10.128.0.0/14").
+				HostPrefix(23)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(likeDefaults(cluster)).To(Equal([]likeDefault{
+			{flag: "provider", value: "aws"},
+			{flag: "ccs", value: "true"},
+			{flag: "multi-az", value: "true"},
+			{flag: "region", value: "us-east-1"},
+			{flag: "version", value: "4.16.3-candidate"},
+			{flag: "channel-group", value: "candidate"},
+			{flag: "compute-machine-type", value: "m5.xlarge"},
+			{flag: "compute-nodes", value: "6"},
+			{flag: "network-type", value: "OVNKubernetes"},
+			{flag: "machine-cidr", value: "10.0.0.0/16"},
+			{flag: "service-cidr", value: "172.30.0.0/16"},
+			{flag: "pod-cidr", value: "10.128.0.0/14"},
+			{flag: "host-prefix", value: "23"},
+		}))
+	})
+
+	It("Skips the settings that the cluster doesn't have", func() {
+		cluster, err := cmv1.NewCluster().
+			CloudProvider(cmv1.NewCloudProvider().ID("gcp")).
+			Region(cmv1.NewCloudRegion().ID("us-east1")).
+			Nodes(cmv1.NewClusterNodes().
+				AutoscaleCompute(cmv1.NewMachinePoolAutoscaling().MinReplicas(3).MaxReplicas(6))).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(likeDefaults(cluster)).To(Equal([]likeDefault{
+			{flag: "provider", value: "gcp"},
+			{flag: "ccs", value: "false"},
+			{flag: "multi-az", value: "false"},
+			{flag: "region", value: "us-east1"},
+		}))
+	})
+
+	It("Doesn't replace the flags given in the command line", func() {
+		var region, version string
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.StringVar(&region, "region", "", "")
+		fs.StringVar(&version, "version", "", "")
+		Expect(fs.Parse([]string{"--region", "eu-west-1"})).To(Succeed())
+
+		err := setLikeDefaults(fs, []likeDefault{
+			{flag: "region", value: "us-east-1"},
+			{flag: "version", value: "4.16.3"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(region).To(Equal("eu-west-1"))
+		Expect(version).To(Equal("4.16.3"))
+		Expect(fs.Changed("version")).To(BeFalse())
+	})
+
+	It("Rejects a provider that isn't the provider of the cluster", func() {
+		var provider, region string
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.StringVar(&provider, "provider", "", "")
+		fs.StringVar(&region, "region", "", "")
+		Expect(fs.Parse([]string{"--provider", "gcp"})).To(Succeed())
+
+		err := setLikeDefaults(fs, []likeDefault{
+			{flag: "provider", value: "aws"},
+			{flag: "region", value: "us-east-1"},
+		})
+		Expect(err).To(MatchError(
+			"Flag --provider 'gcp' conflicts with value 'aws' of the cluster given with --like",
+		))
+	})
+
+	It("Accepts the provider of the cluster", func() {
+		var provider string
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.StringVar(&provider, "provider", "", "")
+		Expect(fs.Parse([]string{"--provider", "aws"})).To(Succeed())
+
+		err := setLikeDefaults(fs, []likeDefault{
+			{flag: "provider", value: "aws"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("Region requirement", func() {
+		var cmd *cobra.Command
+
+		BeforeEach(func() {
+			cmd = &cobra.Command{}
+			cmd.Flags().StringVar(&args.region, "region", "", "")
+		})
+
+		AfterEach(func() {
+			args.like = ""
+			args.region = ""
+		})
+
+		It("Requires the region without --like", func() {
+			Expect(cmd.Flags().Parse(nil)).To(Succeed())
+			Expect(checkRegionRequired(cmd)).To(MatchError(`required flag(s) "region" not set`))
+		})
+
+		It("Accepts the region without --like", func() {
+			Expect(cmd.Flags().Parse([]string{"--region", "us-east-1"})).To(Succeed())
+			Expect(checkRegionRequired(cmd)).To(Succeed())
+		})
+
+		It("Doesn't require the region with --like", func() {
+			args.like = "my-cluster"
+			Expect(cmd.Flags().Parse(nil)).To(Succeed())
+			Expect(checkRegionRequired(cmd)).To(Succeed())
+		})
+	})
+
+	It("Checks if the version is one of the enabled versions", func() {
+		options := []arguments.Option{
+			{Value: "4.16.3"},
+			{Value: "4.16.3-candidate", Description: "default"},
+		}
+		Expect(hasVersionOption(options, "4.16.3-candidate")).To(BeTrue())
+		Expect(hasVersionOption(options, "4.15.0")).To(BeFalse())
+		Expect(hasVersionOption(options, "")).To(BeFalse())
+	})
+})
+
+var _ = Describe("Fetch flavours", func() {
+	var server *Server
+	var connection *sdk.Connection
+
+	BeforeEach(func() {
+		server = MakeTCPServer()
+		var err error
+		connection, err = sdk.NewConnectionBuilder().
+			URL(server.URL()).
+			Tokens(MakeTokenString("Bearer", 15*time.Minute)).
+			RetryLimit(0).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() {
+			connection.Close()
+			server.Close()
+		})
+	})
+
+	It("Retries transient errors", func() {
+		server.AppendHandlers(
+			RespondWithJSON(http.StatusServiceUnavailable, `{
+				"kind": "Error",
+				"id": "503"
+			}`),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "FlavourList",
+				"page": 1,
+				"size": 1,
+				"total": 1,
+				"items": [
+					{
+						"kind": "Flavour",
+						"id": "osd-4"
+					}
+				]
+			}`),
+		)
+		flavours, err := fetchFlavours(connection.ClustersMgmt().V1())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(flavours).To(HaveLen(1))
+		Expect(flavours[0].ID()).To(Equal("osd-4"))
+	})
+
+	It("Returns the error when transient errors don't stop", func() {
+		server.SetAllowUnhandledRequests(true)
+		server.SetUnhandledRequestStatusCode(http.StatusServiceUnavailable)
+		_, err := fetchFlavours(connection.ClustersMgmt().V1())
+		Expect(err).To(HaveOccurred())
+		Expect(len(server.ReceivedRequests())).To(BeNumerically(">", 1))
+	})
+
+	It("Doesn't retry other errors", func() {
+		server.AppendHandlers(
+			RespondWithJSON(http.StatusForbidden, `{
+				"kind": "Error",
+				"id": "403"
+			}`),
+		)
+		_, err := fetchFlavours(connection.ClustersMgmt().V1())
+		Expect(err).To(HaveOccurred())
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+	})
+})
+
+// createClusterRequest sends the request to create a cluster with the given specification to a
+// test server, and returns the cluster that the server received.
+func createClusterRequest(spec c.Spec) *cmv1.Cluster {
+	server := MakeTCPServer()
+	defer server.Close()
+	var body []byte
+	server.AppendHandlers(
+		CombineHandlers(
+			VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+			func(w http.ResponseWriter, r *http.Request) {
+				var err error
+				body, err = io.ReadAll(r.Body)
+				Expect(err).ToNot(HaveOccurred())
+			},
+			RespondWithJSON(http.StatusCreated, `{
+				"kind": "Cluster",
+				"id": "123"
+			}`),
+		),
+	)
+	connection, err := sdk.NewConnectionBuilder().
+		URL(server.URL()).
+		Tokens(MakeTokenString("Bearer", 15*time.Minute)).
+		Build()
+	Expect(err).ToNot(HaveOccurred())
+	defer connection.Close()
+	_, err = c.CreateCluster(connection.ClustersMgmt().V1(), spec, false)
+	Expect(err).ToNot(HaveOccurred())
+	cluster, err := cmv1.UnmarshalCluster(body)
+	Expect(err).ToNot(HaveOccurred())
+	return cluster
+}
+
+var _ = Describe("Default pool availability zones", func() {
+	AfterEach(func() {
+		args.multiAZ = false
+		args.region = ""
+		args.defaultPoolAZs = nil
+		args.existingVPC = c.ExistingVPC{}
+	})
+
+	It("Accepts zones of the region for multi-zone clusters", func() {
+		args.multiAZ = true
+		args.region = "us-east-1"
+		args.defaultPoolAZs = []string{"us-east-1a", "us-east-1b"}
+		Expect(validateDefaultPoolAZs()).To(Succeed())
+	})
+
+	It("Rejects single zone clusters", func() {
+		args.region = "us-east-1"
+		args.defaultPoolAZs = []string{"us-east-1a"}
+		Expect(validateDefaultPoolAZs()).To(MatchError(
+			"Flag --default-pool-availability-zones requires --multi-az",
+		))
+	})
+
+	It("Rejects zones outside of the region", func() {
+		args.multiAZ = true
+		args.region = "us-east-1"
+		args.defaultPoolAZs = []string{"us-west-2a"}
+		Expect(validateDefaultPoolAZs()).To(MatchError(
+			"Invalid --default-pool-availability-zones: Availability zone 'us-west-2a' isn't " +
+				"in region 'us-east-1'",
+		))
+	})
+
+	DescribeTable(
+		"Rejects clusters whose zones are determined by subnets",
+		func(vpc c.ExistingVPC) {
+			args.multiAZ = true
+			args.region = "us-east-1"
+			args.defaultPoolAZs = []string{"us-east-1a"}
+			args.existingVPC = vpc
+			Expect(validateDefaultPoolAZs()).To(MatchError(
+				"Flag --default-pool-availability-zones can't be used with --subnet-ids or " +
+					"--availability-zones, as the subnets already determine the zones",
+			))
+		},
+		Entry("Subnets", c.ExistingVPC{SubnetIDs: "subnet-1,subnet-2,subnet-3"}),
+		Entry("Zones", c.ExistingVPC{AvailabilityZones: []string{"us-east-1a"}}),
+	)
+
+	It("Sends the zones as the zones of the cluster nodes", func() {
+		cluster := createClusterRequest(c.Spec{
+			Name:           "my-cluster",
+			Provider:       c.ProviderAWS,
+			Region:         "us-east-1",
+			MultiAZ:        true,
+			DefaultPoolAZs: []string{"us-east-1a", "us-east-1b"},
+		})
+		Expect(cluster.Nodes().AvailabilityZones()).To(Equal([]string{"us-east-1a", "us-east-1b"}))
+	})
+
+	It("Doesn't replace the zones of the subnets", func() {
+		cluster := createClusterRequest(c.Spec{
+			Name:     "my-cluster",
+			Provider: c.ProviderAWS,
+			Region:   "us-east-1",
+			MultiAZ:  true,
+			ExistingVPC: c.ExistingVPC{
+				AvailabilityZones: []string{"us-east-1a", "us-east-1b", "us-east-1c"},
+			},
+			DefaultPoolAZs: []string{"us-east-1a"},
+		})
+		Expect(cluster.Nodes().AvailabilityZones()).To(Equal(
+			[]string{"us-east-1a", "us-east-1b", "us-east-1c"},
+		))
+	})
+})
+
+var _ = Describe("Audit log forwarding roles", func() {
+	makeWifConfig := func(roles ...string) *cmv1.WifConfig {
+		var wifRoles []*cmv1.WifRoleBuilder
+		for _, role := range roles {
+			wifRoles = append(wifRoles, cmv1.NewWifRole().RoleId(role).Predefined(true))
+		}
+		wifConfig, err := cmv1.NewWifConfig().
+			ID("123").
+			DisplayName("my-wif-config").
+			Gcp(cmv1.NewWifGcp().ServiceAccounts(
+				cmv1.NewWifServiceAccount().
+					ServiceAccountId("my-service-account").
+					Roles(wifRoles...),
+			)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		return wifConfig
+	}
+
+	AfterEach(func() {
+		args.auditLogForwarding = false
+	})
+
+	It("Accepts a WIF config that has the roles", func() {
+		args.auditLogForwarding = true
+		wifConfig := makeWifConfig(append([]string{"compute.admin"}, provider.AuditLogForwardingRoles...)...)
+		Expect(checkAuditLogForwardingRoles(wifConfig)).To(Succeed())
+	})
+
+	It("Rejects a WIF config without the roles and explains how to grant them", func() {
+		args.auditLogForwarding = true
+		wifConfig := makeWifConfig("compute.admin")
+		Expect(checkAuditLogForwardingRoles(wifConfig)).To(MatchError(
+			"WIF configuration 'my-wif-config' is missing the roles needed for audit log " +
+				"forwarding: " + strings.Join(provider.AuditLogForwardingRoles, ", ") + ". " +
+				"Run 'ocm gcp update wif-config 123' to grant them and try again",
+		))
+	})
+
+	It("Doesn't check the roles without audit log forwarding", func() {
+		Expect(checkAuditLogForwardingRoles(makeWifConfig())).To(Succeed())
+	})
+})
+
+var _ = Describe("Created cluster output", func() {
+	It("Writes the cluster and its warnings in JSON format", func() {
+		server := MakeTCPServer()
+		defer server.Close()
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/service_logs/v1/clusters/cluster_logs"),
+				VerifyFormKV("cluster_id", "123"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "ClusterLogList",
+					"page": 1,
+					"size": 2,
+					"total": 2,
+					"items": [
+						{
+							"kind": "ClusterLog",
+							"id": "456",
+							"severity": "Warning",
+							"summary": "Quota almost exhausted",
+							"description": "Only 2 vCPUs are left"
+						},
+						{
+							"kind": "ClusterLog",
+							"id": "789",
+							"severity": "Info",
+							"summary": "Cluster created"
+						}
+					]
+				}`),
+			),
+		)
+		connection, err := sdk.NewConnectionBuilder().
+			URL(server.URL()).
+			Tokens(MakeTokenString("Bearer", 15*time.Minute)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		defer connection.Close()
+		cluster, err := cmv1.NewCluster().ID("123").Name("my-cluster").Build()
+		Expect(err).ToNot(HaveOccurred())
+
+		buffer := &bytes.Buffer{}
+		err = dumpCreatedCluster(buffer, connection, cluster, output.FormatJSON)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(MatchJSON(`{
+			"cluster": {
+				"kind": "Cluster",
+				"id": "123",
+				"name": "my-cluster"
+			},
+			"warnings": [
+				{
+					"code": "456",
+					"summary": "Quota almost exhausted",
+					"message": "Only 2 vCPUs are left"
+				}
+			]
+		}`))
+	})
+})
+
+var _ = Describe("Multi-zone compute nodes", func() {
+	var fs *pflag.FlagSet
+
+	BeforeEach(func() {
+		fs = pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.IntVar(&args.computeNodes, "compute-nodes", 0, "")
+		arguments.AddAutoscalingFlags(fs, &args.autoscaling)
+	})
+
+	AfterEach(func() {
+		args.multiAZ = false
+		args.computeNodes = 0
+		args.autoscaling = c.Autoscaling{}
+	})
+
+	DescribeTable(
+		"Accepts valid numbers of nodes",
+		func(multiAZ bool, argv ...string) {
+			args.multiAZ = multiAZ
+			Expect(fs.Parse(argv)).To(Succeed())
+			Expect(checkMultiAZComputeNodes(fs)).To(Succeed())
+		},
+		Entry("Multiple of 3", true, "--compute-nodes", "9"),
+		Entry("Not given", true),
+		Entry("Single zone", false, "--compute-nodes", "4"),
+		Entry("Autoscaling", true, "--enable-autoscaling", "--min-replicas", "3", "--max-replicas", "6"),
+		Entry("Single zone autoscaling", false, "--enable-autoscaling", "--min-replicas", "2",
+			"--max-replicas", "5"),
+	)
+
+	DescribeTable(
+		"Rejects numbers of nodes that aren't multiples of 3 suggesting the nearest valid one",
+		func(message string, argv ...string) {
+			args.multiAZ = true
+			Expect(fs.Parse(argv)).To(Succeed())
+			Expect(checkMultiAZComputeNodes(fs)).To(MatchError(message))
+		},
+		Entry(
+			"Compute nodes",
+			"Invalid --compute-nodes: multi-zone clusters require nodes to be multiple of 3, "+
+				"the nearest valid value is 6",
+			"--compute-nodes", "4",
+		),
+		Entry(
+			"Autoscaling minimum",
+			"Invalid --min-replicas: multi-zone clusters require nodes to be multiple of 3, "+
+				"the nearest valid value is 6",
+			"--enable-autoscaling", "--min-replicas", "5", "--max-replicas", "9",
+		),
+		Entry(
+			"Autoscaling maximum",
+			"Invalid --max-replicas: multi-zone clusters require nodes to be multiple of 3, "+
+				"the nearest valid value is 12",
+			"--enable-autoscaling", "--min-replicas", "3", "--max-replicas", "10",
+		),
+	)
+})
+
+var _ = Describe("AWS PrivateLink", func() {
+	var fs *pflag.FlagSet
+
+	BeforeEach(func() {
+		fs = pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.BoolVar(&args.private, privateFlag, false, "")
+		args.privateLink = true
+		args.provider = c.ProviderAWS
+		args.ccs = c.CCS{Enabled: true}
+	})
+
+	AfterEach(func() {
+		args.private = false
+		args.privateLink = false
+		args.provider = ""
+		args.ccs = c.CCS{}
+		args.existingVPC = c.ExistingVPC{}
+	})
+
+	It("Makes the cluster private", func() {
+		Expect(validatePrivateLink(fs)).To(Succeed())
+		Expect(args.private).To(BeTrue())
+		Expect(fs.Changed(privateFlag)).To(BeTrue())
+	})
+
+	It("Rejects clusters that aren't in AWS", func() {
+		args.provider = c.ProviderGCP
+		Expect(validatePrivateLink(fs)).To(MatchError(
+			"Flag --private-link is only supported for AWS CCS clusters",
+		))
+	})
+
+	It("Rejects clusters that aren't CCS", func() {
+		args.ccs = c.CCS{}
+		Expect(validatePrivateLink(fs)).To(MatchError(
+			"Flag --private-link is only supported for AWS CCS clusters",
+		))
+	})
+
+	It("Rejects public clusters", func() {
+		Expect(fs.Parse([]string{"--private=false"})).To(Succeed())
+		Expect(validatePrivateLink(fs)).To(MatchError(
+			"Flag --private-link can't be used with --private=false",
+		))
+	})
+
+	It("Requires the subnets of an existing VPC", func() {
+		Expect(checkPrivateLinkSubnets()).To(MatchError(
+			"Flag --private-link requires the subnets of an existing VPC, use --subnet-ids",
+		))
+		args.existingVPC.SubnetIDs = "subnet-1"
+		Expect(checkPrivateLinkSubnets()).To(Succeed())
+	})
+
+	It("Sends the PrivateLink setting in the request", func() {
+		private := true
+		cluster := createClusterRequest(c.Spec{
+			Name:     "my-cluster",
+			Provider: c.ProviderAWS,
+			Region:   "us-east-1",
+			CCS:      c.CCS{Enabled: true},
+			ExistingVPC: c.ExistingVPC{
+				SubnetIDs: "subnet-1",
+			},
+			Private:     &private,
+			PrivateLink: true,
+		})
+		Expect(cluster.AWS().PrivateLink()).To(BeTrue())
+		Expect(cluster.AWS().SubnetIDs()).To(Equal([]string{"subnet-1"}))
+		Expect(cluster.API().Listening()).To(Equal(cmv1.ListeningMethodInternal))
+	})
+})
+
+var _ = Describe("Shared VPC", func() {
+	const roleARN = "arn:aws:iam::123456789012:role/my-route53-role"
+
+	BeforeEach(func() {
+		args.provider = c.ProviderAWS
+		args.ccs = c.CCS{Enabled: true}
+		args.existingVPC = c.ExistingVPC{SubnetIDs: "subnet-1"}
+		args.sharedVPC = c.SharedVPC{
+			HostedZoneID:   "Z0123456789",
+			Route53RoleARN: roleARN,
+		}
+	})
+
+	AfterEach(func() {
+		args.provider = ""
+		args.ccs = c.CCS{}
+		args.existingVPC = c.ExistingVPC{}
+		args.sharedVPC = c.SharedVPC{}
+	})
+
+	It("Accepts both flags for AWS CCS clusters with subnets", func() {
+		Expect(validateSharedVPC()).To(Succeed())
+	})
+
+	It("Accepts clusters that don't use a shared VPC", func() {
+		args.sharedVPC = c.SharedVPC{}
+		Expect(validateSharedVPC()).To(Succeed())
+	})
+
+	DescribeTable(
+		"Rejects each flag alone",
+		func(vpc c.SharedVPC) {
+			args.sharedVPC = vpc
+			Expect(validateSharedVPC()).To(MatchError(
+				"Flags --shared-vpc-hosted-zone-id and --shared-vpc-route53-role-arn must be " +
+					"used together",
+			))
+		},
+		Entry("Hosted zone", c.SharedVPC{HostedZoneID: "Z0123456789"}),
+		Entry("Role", c.SharedVPC{Route53RoleARN: roleARN}),
+	)
+
+	It("Rejects clusters that aren't in AWS", func() {
+		args.provider = c.ProviderGCP
+		Expect(validateSharedVPC()).To(MatchError(
+			"Flag --shared-vpc-hosted-zone-id is only supported for AWS CCS clusters",
+		))
+	})
+
+	It("Rejects clusters that aren't CCS", func() {
+		args.ccs = c.CCS{}
+		Expect(validateSharedVPC()).To(MatchError(
+			"Flag --shared-vpc-hosted-zone-id is only supported for AWS CCS clusters",
+		))
+	})
+
+	It("Requires the subnets of an existing VPC", func() {
+		args.existingVPC = c.ExistingVPC{}
+		Expect(validateSharedVPC()).To(MatchError(
+			"Flag --shared-vpc-hosted-zone-id requires the subnets of an existing VPC, use " +
+				"--subnet-ids",
+		))
+	})
+
+	It("Rejects a role that isn't an IAM role ARN", func() {
+		args.sharedVPC.Route53RoleARN = "my-role"
+		Expect(validateSharedVPC()).To(MatchError(
+			HavePrefix("Invalid --shared-vpc-route53-role-arn: "),
+		))
+	})
+
+	It("Sends the hosted zone and the role in the request", func() {
+		cluster := createClusterRequest(c.Spec{
+			Name:     "my-cluster",
+			Provider: c.ProviderAWS,
+			Region:   "us-east-1",
+			CCS:      c.CCS{Enabled: true},
+			ExistingVPC: c.ExistingVPC{
+				SubnetIDs: "subnet-1",
+			},
+			SharedVPC: c.SharedVPC{
+				HostedZoneID:   "Z0123456789",
+				Route53RoleARN: roleARN,
+			},
+		})
+		Expect(cluster.AWS().PrivateHostedZoneID()).To(Equal("Z0123456789"))
+		Expect(cluster.AWS().PrivateHostedZoneRoleARN()).To(Equal(roleARN))
+	})
+})
+
+var _ = Describe("FedRAMP", func() {
+	var fs *pflag.FlagSet
+
+	BeforeEach(func() {
+		fs = pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.BoolVar(&args.etcdEncryption, etcdEncryptionFlag, false, "")
+		args.clusterName = "my-cluster"
+		args.region = "us-gov-west-1"
+		args.fedramp = true
+		args.provider = c.ProviderAWS
+		args.ccs = c.CCS{Enabled: true}
+	})
+
+	AfterEach(func() {
+		args.clusterName = ""
+		args.region = ""
+		args.fedramp = false
+		args.provider = ""
+		args.ccs = c.CCS{}
+		args.etcdEncryption = false
+	})
+
+	It("Enables etcd encryption", func() {
+		Expect(validateFedRAMP(fs)).To(Succeed())
+		Expect(args.etcdEncryption).To(BeTrue())
+	})
+
+	It("Rejects clusters that aren't in AWS", func() {
+		args.provider = c.ProviderGCP
+		Expect(validateFedRAMP(fs)).To(MatchError(
+			"Flag --fedramp is only supported for AWS CCS clusters",
+		))
+	})
+
+	It("Rejects clusters that aren't CCS", func() {
+		args.ccs = c.CCS{}
+		Expect(validateFedRAMP(fs)).To(MatchError(
+			"Flag --fedramp is only supported for AWS CCS clusters",
+		))
+	})
+
+	It("Rejects disabling etcd encryption", func() {
+		Expect(fs.Parse([]string{"--etcd-encryption=false"})).To(Succeed())
+		Expect(validateFedRAMP(fs)).To(MatchError(
+			"Flag --fedramp can't be used with --etcd-encryption=false",
+		))
+	})
+
+	It("Sends FIPS and etcd encryption in the request", func() {
+		Expect(validateFedRAMP(fs)).To(Succeed())
+		spec, err := buildClusterSpec()
+		Expect(err).ToNot(HaveOccurred())
+		cluster := createClusterRequest(spec)
+		Expect(cluster.FIPS()).To(BeTrue())
+		Expect(cluster.EtcdEncryption()).To(BeTrue())
+		Expect(cluster.Region().ID()).To(Equal("us-gov-west-1"))
+	})
+})
+
+var _ = Describe("Private Service Connect", func() {
+	var server *Server
+	var connection *sdk.Connection
+	var fs *pflag.FlagSet
+
+	BeforeEach(func() {
+		server = MakeTCPServer()
+		var err error
+		connection, err = sdk.NewConnectionBuilder().
+			URL(server.URL()).
+			Tokens(MakeTokenString("Bearer", 15*time.Minute)).
+			RetryLimit(0).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() {
+			connection.Close()
+			server.Close()
+		})
+
+		fs = pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.BoolVar(&args.interactive, "interactive", false, "")
+		fs.StringVar(&args.gcpPrivateSvcConnect.SvcAttachmentSubnet, pscSubnetFlag, "", "")
+		args.provider = c.ProviderGCP
+		args.ccs = c.CCS{Enabled: true}
+		args.region = "us-east1"
+		args.private = true
+		args.gcpAuthentication = c.GcpAuthentication{Type: c.AuthenticationWif, Id: "my-wif"}
+		args.existingVPC = c.ExistingVPC{
+			Enabled:            true,
+			VPCName:            "my-vpc",
+			ControlPlaneSubnet: "my-control-plane-subnet",
+			ComputeSubnet:      "my-compute-subnet",
+		}
+	})
+
+	AfterEach(func() {
+		args.provider = ""
+		args.ccs = c.CCS{}
+		args.region = ""
+		args.private = false
+		args.gcpAuthentication = c.GcpAuthentication{}
+		args.existingVPC = c.ExistingVPC{}
+		args.gcpPrivateSvcConnect = c.GcpPrivateSvcConnect{}
+	})
+
+	respondWithSubnets := func(subnets ...string) {
+		vpc, err := cmv1.NewCloudVPC().Name("my-vpc").Subnets(subnets...).Build()
+		Expect(err).ToNot(HaveOccurred())
+		var buffer bytes.Buffer
+		Expect(cmv1.MarshalCloudVPCList([]*cmv1.CloudVPC{vpc}, &buffer)).To(Succeed())
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/gcp_inquiries/vpcs"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "CloudVPCList",
+					"page": 1,
+					"size": 1,
+					"total": 1,
+					"items": `+buffer.String()+`
+				}`),
+			),
+		)
+	}
+
+	It("Accepts a subnet that exists", func() {
+		args.gcpPrivateSvcConnect.SvcAttachmentSubnet = "my-psc-subnet"
+		respondWithSubnets("my-control-plane-subnet", "my-compute-subnet", "my-psc-subnet")
+		Expect(promptPrivateServiceConnect(fs, connection)).To(Succeed())
+	})
+
+	It("Isn't needed for public clusters", func() {
+		args.private = false
+		Expect(promptPrivateServiceConnect(fs, connection)).To(Succeed())
+		Expect(server.ReceivedRequests()).To(BeEmpty())
+	})
+
+	It("Requires the subnet for private WIF clusters", func() {
+		Expect(promptPrivateServiceConnect(fs, connection)).To(MatchError(
+			"flag 'psc-subnet' is required when cluster is 'private' and GCP authentication " +
+				"type is Workload Identity Federation (WIF)",
+		))
+	})
+
+	It("Isn't required for private clusters using a service account key", func() {
+		args.gcpAuthentication = c.GcpAuthentication{Type: c.AuthenticationKey}
+		Expect(promptPrivateServiceConnect(fs, connection)).To(Succeed())
+		Expect(server.ReceivedRequests()).To(BeEmpty())
+	})
+
+	DescribeTable(
+		"Rejects the subnets of the cluster nodes",
+		func(subnet string) {
+			args.gcpPrivateSvcConnect.SvcAttachmentSubnet = subnet
+			Expect(promptPrivateServiceConnect(fs, connection)).To(MatchError(
+				"The psc-subnet '" + subnet + "' must be a subnet of purpose 'Private Service " +
+					"Connect', different from the control-plane-subnet and the compute-subnet",
+			))
+		},
+		Entry("Control plane subnet", "my-control-plane-subnet"),
+		Entry("Compute subnet", "my-compute-subnet"),
+	)
+
+	It("Doesn't look up the subnet in a shared VPC", func() {
+		args.existingVPC.VPCProjectID = "my-host-project"
+		args.gcpPrivateSvcConnect.SvcAttachmentSubnet = "my-psc-subnet"
+		Expect(promptPrivateServiceConnect(fs, connection)).To(Succeed())
+		Expect(server.ReceivedRequests()).To(BeEmpty())
+	})
+
+	It("Fails if the subnets can't be retrieved", func() {
+		args.gcpPrivateSvcConnect.SvcAttachmentSubnet = "my-psc-subnet"
+		server.AppendHandlers(
+			RespondWithJSON(http.StatusForbidden, `{
+				"kind": "Error",
+				"id": "403",
+				"reason": "Forbidden"
+			}`),
+		)
+		Expect(promptPrivateServiceConnect(fs, connection)).To(MatchError(ContainSubstring("Forbidden")))
+	})
+
+	It("Rejects a subnet that doesn't exist", func() {
+		args.gcpPrivateSvcConnect.SvcAttachmentSubnet = "my-psc-subnet"
+		respondWithSubnets("my-control-plane-subnet", "my-compute-subnet")
+		Expect(promptPrivateServiceConnect(fs, connection)).To(MatchError(
+			"Could not find the following psc-subnet provided: 'my-psc-subnet'",
+		))
+	})
+})
+
+var _ = Describe("Instance metadata", func() {
+	DescribeTable(
+		"Sends the protection mode in the AWS settings",
+		func(ccs bool) {
+			private := false
+			cluster := createClusterRequest(c.Spec{
+				Name:     "my-cluster",
+				Region:   "us-east-1",
+				Provider: c.ProviderAWS,
+				CCS: c.CCS{
+					Enabled: ccs,
+					AWS:     c.AWSCredentials{AccountID: "123456789012"},
+				},
+				Private: &private,
+				Imds:    c.ImdsRequired,
+			})
+			Expect(cluster.AWS().Ec2MetadataHttpTokens()).To(Equal(cmv1.Ec2MetadataHttpTokensRequired))
+			if ccs {
+				Expect(cluster.AWS().AccountID()).To(Equal("123456789012"))
+			} else {
+				Expect(cluster.AWS().AccountID()).To(BeEmpty())
+			}
+		},
+		Entry("CCS cluster", true),
+		Entry("Cluster that isn't CCS", false),
+	)
+
+	It("Doesn't send AWS settings for clusters that aren't CCS by default", func() {
+		private := false
+		cluster := createClusterRequest(c.Spec{
+			Name:     "my-cluster",
+			Region:   "us-east-1",
+			Provider: c.ProviderAWS,
+			Private:  &private,
+		})
+		_, ok := cluster.GetAWS()
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -58,11 +58,13 @@ var args struct {
 	openidEmail       string
 	openidName        string
 	openidUsername    string
+	openidGroups      string
 	openidExtraScopes string
 
 	// HTPasswd
 	htpasswdUsername string
 	htpasswdPassword string
+	htpasswdFile     string
 }
 
 var validIdps = []string{"github", "google", "ldap", "openid", "htpasswd"}
@@ -227,6 +229,13 @@ func init() {
 		"",
 		"OpenID: List of claims to use as the preferred username when provisioning a user.\n",
 	)
+	flags.StringVar(
+		&args.openidGroups,
+		"groups-claims",
+		"",
+		"OpenID: List of claims to use as the groups of the user, needed to synchronize group "+
+			"membership when the user logs in.\n",
+	)
 	flags.StringVar(
 		&args.openidExtraScopes,
 		"extra-scopes",
@@ -248,6 +257,15 @@ func init() {
 		"",
 		"HTPasswd: Password.\n",
 	)
+
+	flags.StringVar(
+		&args.htpasswdFile,
+		"htpasswd-from-file",
+		"",
+		"HTPasswd: File containing the users to create, one 'username:password' per line. The "+
+			"password can be in clear text or hashed with bcrypt, as generated by 'htpasswd -B'. "+
+			"Can't be used together with --username and --password.\n",
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) error {
@@ -312,6 +330,11 @@ func run(cmd *cobra.Command, argv []string) error {
 		}
 	}
 
+	err = checkTypeFlags(idpType)
+	if err != nil {
+		return err
+	}
+
 	var idpBuilder cmv1.IdentityProviderBuilder
 	if idpName == "" {
 		idpName = getNextName(idpType, idps)
@@ -361,6 +384,19 @@ func run(cmd *cobra.Command, argv []string) error {
 	return nil
 }
 
+// checkTypeFlags checks that the flags that only make sense for one type of identity provider
+// aren't used with other types.
+func checkTypeFlags(idpType string) error {
+	if args.openidGroups != "" && idpType != "openid" {
+		return fmt.Errorf(
+			"Flag --groups-claims can only be used with identity providers of type 'openid', "+
+				"but the type is '%s'",
+			idpType,
+		)
+	}
+	return nil
+}
+
 func getNextName(idpType string, idps []*cmv1.IdentityProvider) string {
 	nextSuffix := 0
 	for _, idp := range idps {
@@ -16,6 +16,8 @@ package idp
 import (
 	"errors"
 	"fmt"
+	"os"
+	"strings"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 
@@ -28,6 +30,25 @@ func buildHtpasswdIdp(cluster *cmv1.Cluster, idpName string) (cmv1.IdentityProvi
 	message := fmt.Sprintf("Securely store your username and password.\n" +
 		"If you lose these credentials, you will have to delete and recreate the IDP.\n")
 
+	if args.htpasswdFile != "" {
+		if args.htpasswdUsername != "" || args.htpasswdPassword != "" {
+			return idpBuilder, "", errors.New(
+				"Flag --htpasswd-from-file can't be used together with --username and --password",
+			)
+		}
+		users, err := parseHtpasswdFile(args.htpasswdFile)
+		if err != nil {
+			return idpBuilder, "", err
+		}
+		idpBuilder.
+			Type("HTPasswdIdentityProvider"). // FIXME: ocm-api-model has the wrong enum values
+			Name(idpName).
+			MappingMethod(cmv1.IdentityProviderMappingMethod(args.mappingMethod)).
+			Htpasswd(cmv1.NewHTPasswdIdentityProvider().Users(cmv1.NewHTPasswdUserList().Items(users...)))
+		message = fmt.Sprintf("%d users have been added from file '%s'.\n", len(users), args.htpasswdFile)
+		return idpBuilder, message, nil
+	}
+
 	username := args.htpasswdUsername
 	password := args.htpasswdPassword
 
@@ -77,3 +98,87 @@ func buildHtpasswdIdp(cluster *cmv1.Cluster, idpName string) (cmv1.IdentityProvi
 
 	return idpBuilder, message, nil
 }
+
+// parseHtpasswdFile reads the users from a file in htpasswd format, where each line contains the
+// name of the user and the password separated by a colon. Passwords can be in clear text or
+// hashed with bcrypt, which is the only hashing algorithm supported by the API. Empty lines and
+// lines starting with '#' are ignored.
+func parseHtpasswdFile(file string) ([]*cmv1.HTPasswdUserBuilder, error) {
+	// #nosec G304
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("Can't read htpasswd file '%s': %v", file, err)
+	}
+
+	var users []*cmv1.HTPasswdUserBuilder
+	usernames := map[string]bool{}
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		username, password, found := strings.Cut(line, ":")
+		if !found || username == "" || password == "" {
+			return nil, fmt.Errorf(
+				"Line %d of htpasswd file '%s' isn't valid: it must be 'username:password'",
+				i+1, file,
+			)
+		}
+		err = validateHtpasswdUsername(username)
+		if err != nil {
+			return nil, fmt.Errorf("Line %d of htpasswd file '%s' isn't valid: %v", i+1, file, err)
+		}
+		if usernames[username] {
+			return nil, fmt.Errorf("User '%s' appears more than once in htpasswd file '%s'", username, file)
+		}
+		usernames[username] = true
+
+		user := cmv1.NewHTPasswdUser().Username(username)
+		switch {
+		case isBcryptHash(password):
+			user.HashedPassword(password)
+		case isUnsupportedHash(password):
+			return nil, fmt.Errorf(
+				"Password of user '%s' in htpasswd file '%s' isn't hashed with bcrypt, which is the "+
+					"only supported algorithm, use 'htpasswd -B' to generate it",
+				username, file,
+			)
+		default:
+			user.Password(password)
+		}
+		users = append(users, user)
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("Htpasswd file '%s' doesn't contain any user", file)
+	}
+	return users, nil
+}
+
+// validateHtpasswdUsername checks that the given user name can be used in an htpasswd identity
+// provider.
+func validateHtpasswdUsername(username string) error {
+	if username == "." || username == ".." || username == "~" {
+		return fmt.Errorf("user name '%s' isn't allowed", username)
+	}
+	if strings.ContainsAny(username, "/%") {
+		return fmt.Errorf("user name '%s' must not contain '/' or '%%'", username)
+	}
+	return nil
+}
+
+// isBcryptHash checks if the given password is hashed with bcrypt.
+func isBcryptHash(password string) bool {
+	return strings.HasPrefix(password, "$2a$") ||
+		strings.HasPrefix(password, "$2b$") ||
+		strings.HasPrefix(password, "$2y$")
+}
+
+// isUnsupportedHash checks if the given password is hashed with one of the other algorithms
+// supported by the htpasswd tool, like MD5 or SHA1.
+func isUnsupportedHash(password string) bool {
+	return strings.HasPrefix(password, "$apr1$") ||
+		strings.HasPrefix(password, "{SHA}") ||
+		strings.HasPrefix(password, "$1$") ||
+		strings.HasPrefix(password, "$5$") ||
+		strings.HasPrefix(password, "$6$")
+}
@@ -0,0 +1,69 @@
+package idp
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Parse htpasswd file", func() {
+	var file string
+
+	writeFile := func(content string) {
+		file = filepath.Join(GinkgoT().TempDir(), "htpasswd")
+		Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+	}
+
+	It("Reads clear text and bcrypt hashed passwords", func() {
+		writeFile(
+			"# Cluster users\n" +
+				"alice:$2y$05$Kq1m2n3o4p5q6r7s8t9u0uQ0xI5ZrW2Zb8aVn8m1cJ7kqS3d9e6fG\n" +
+				"\n" +
+				"bob:MyPassword123!\n",
+		)
+		builders, err := parseHtpasswdFile(file)
+		Expect(err).ToNot(HaveOccurred())
+		users, err := cmv1.NewHTPasswdUserList().Items(builders...).Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(users.Len()).To(Equal(2))
+		Expect(users.Get(0).Username()).To(Equal("alice"))
+		Expect(users.Get(0).HashedPassword()).To(HavePrefix("$2y$05$"))
+		Expect(users.Get(0).Password()).To(BeEmpty())
+		Expect(users.Get(1).Username()).To(Equal("bob"))
+		Expect(users.Get(1).Password()).To(Equal("MyPassword123!"))
+		Expect(users.Get(1).HashedPassword()).To(BeEmpty())
+	})
+
+	It("Rejects lines without password", func() {
+		writeFile("alice\n")
+		_, err := parseHtpasswdFile(file)
+		Expect(err).To(MatchError(ContainSubstring("Line 1")))
+	})
+
+	It("Rejects duplicated users", func() {
+		writeFile("alice:MyPassword123!\nalice:YourPassword123!\n")
+		_, err := parseHtpasswdFile(file)
+		Expect(err).To(MatchError(ContainSubstring("more than once")))
+	})
+
+	It("Rejects passwords hashed with algorithms other than bcrypt", func() {
+		writeFile("alice:$apr1$abcdefgh$0123456789abcdefghijk.\n")
+		_, err := parseHtpasswdFile(file)
+		Expect(err).To(MatchError(ContainSubstring("isn't hashed with bcrypt")))
+	})
+
+	It("Rejects invalid user names", func() {
+		writeFile("al/ice:MyPassword123!\n")
+		_, err := parseHtpasswdFile(file)
+		Expect(err).To(MatchError(ContainSubstring("must not contain")))
+	})
+
+	It("Rejects files without users", func() {
+		writeFile("# No users yet\n")
+		_, err := parseHtpasswdFile(file)
+		Expect(err).To(MatchError(ContainSubstring("doesn't contain any user")))
+	})
+})
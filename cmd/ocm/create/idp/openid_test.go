@@ -0,0 +1,55 @@
+package idp
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OpenID identity provider", func() {
+	BeforeEach(func() {
+		args.clientID = "my-client"
+		args.clientSecret = "my-secret"
+		args.openidIssuerURL = "https://issuer.example.com"
+		args.openidEmail = "email"
+		args.mappingMethod = "claim"
+		DeferCleanup(func() {
+			args.clientID = ""
+			args.clientSecret = ""
+			args.openidIssuerURL = ""
+			args.openidEmail = ""
+			args.openidGroups = ""
+			args.mappingMethod = ""
+		})
+	})
+
+	It("Sets the groups claims", func() {
+		args.openidGroups = "groups,roles"
+		builder, err := buildOpenidIdp(nil, "openid-1")
+		Expect(err).ToNot(HaveOccurred())
+		idp, err := builder.Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(idp.OpenID().Claims().Groups()).To(Equal([]string{"groups", "roles"}))
+		Expect(idp.OpenID().Claims().Email()).To(Equal([]string{"email"}))
+	})
+
+	It("Doesn't set the groups claims by default", func() {
+		builder, err := buildOpenidIdp(nil, "openid-1")
+		Expect(err).ToNot(HaveOccurred())
+		idp, err := builder.Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(idp.OpenID().Claims().Groups()).To(BeEmpty())
+	})
+
+	It("Accepts the groups claims with the openid type", func() {
+		args.openidGroups = "groups"
+		Expect(checkTypeFlags("openid")).To(Succeed())
+	})
+
+	It("Rejects the groups claims with other types", func() {
+		args.openidGroups = "groups"
+		Expect(checkTypeFlags("github")).To(MatchError(
+			"Flag --groups-claims can only be used with identity providers of type 'openid', " +
+				"but the type is 'github'",
+		))
+	})
+})
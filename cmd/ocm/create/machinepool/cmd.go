@@ -17,26 +17,36 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/AlecAivazis/survey/v2"
 	"github.com/openshift-online/ocm-cli/pkg/arguments"
 	c "github.com/openshift-online/ocm-cli/pkg/cluster"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
 	"github.com/openshift-online/ocm-cli/pkg/provider"
+	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 )
 
 var args struct {
+	interactive                bool
 	clusterKey                 string
+	machinePoolID              string
 	instanceType               string
 	replicas                   int
 	autoscaling                c.Autoscaling
 	labels                     string
+	labelsFile                 string
 	taints                     string
+	taintsFile                 string
 	additionalSecurityGroupIds []string
+	kubeletConfig              string
 }
 
 const (
 	additionalSecurityGroupIdsFlag = "additional-security-group-ids"
+	kubeletConfigFlag              = "kubelet-config"
+	labelsFileFlag                 = "labels-file"
+	taintsFileFlag                 = "taints-file"
 )
 
 var Cmd = &cobra.Command{
@@ -52,13 +62,24 @@ var Cmd = &cobra.Command{
   # Add a machine pool mp-1 with labels and m5.xlarge instance type to a cluster
   ocm create machinepool --cluster mycluster --instance-type m5.xlarge --replicas 3 --labels "foo=bar,bar=baz" mp-1
   # Add a machine pool mp-1 with taints and m5.xlarge instance type to a cluster
-  ocm create machinepool --cluster mycluster --instance-type m5.xlarge --replicas 3 --taints "foo=bar:NoSchedule" mp-1`,
-	RunE: run,
+  ocm create machinepool --cluster mycluster --instance-type m5.xlarge --replicas 3 --taints "foo=bar:NoSchedule" mp-1
+  # Add a machine pool mp-1 with the labels and taints read from YAML files to a cluster
+  ocm create machinepool --cluster mycluster --instance-type m5.xlarge --replicas 3 \
+  --labels-file labels.yaml --taints-file taints.yaml mp-1
+  # Add a machine pool mp-1 using the kubelet config my-kubelet-config to a hosted control plane cluster
+  ocm create machinepool --cluster mycluster --instance-type m5.xlarge --replicas 3 \
+  --kubelet-config my-kubelet-config mp-1
+  # Add a machine pool to a cluster answering questions for the missing details
+  ocm create machinepool --interactive --cluster mycluster`,
+	PreRunE: preRun,
+	RunE:    run,
 }
 
 func init() {
 	flags := Cmd.Flags()
 
+	arguments.AddInteractiveFlag(flags, &args.interactive)
+
 	flags.StringVarP(
 		&args.clusterKey,
 		"cluster",
@@ -68,6 +89,7 @@ func init() {
 	)
 	//nolint:gosec
 	Cmd.MarkFlagRequired("cluster")
+	arguments.SetQuestion(flags, "cluster", "Cluster name or ID:")
 
 	flags.StringVar(
 		&args.instanceType,
@@ -78,6 +100,8 @@ func init() {
 
 	//nolint:gosec
 	Cmd.MarkFlagRequired("instance-type")
+	arguments.SetQuestion(flags, "instance-type", "Instance type:")
+	Cmd.RegisterFlagCompletionFunc("instance-type", provider.ClusterMachineTypeCompletion("cluster"))
 
 	flags.IntVar(
 		&args.replicas,
@@ -85,6 +109,7 @@ func init() {
 		0,
 		"Count of machines for this machine pool.",
 	)
+	arguments.SetQuestion(flags, "replicas", "Replicas:")
 
 	arguments.AddAutoscalingFlags(flags, &args.autoscaling)
 
@@ -95,6 +120,7 @@ func init() {
 		"Labels for machine pool. Format should be a comma-separated list of 'key=value'. "+
 			"This list will overwrite any modifications made to Node labels on an ongoing basis.",
 	)
+	arguments.SetQuestion(flags, "labels", "Labels (optional):")
 
 	flags.StringVar(
 		&args.taints,
@@ -103,6 +129,24 @@ func init() {
 		"Taints for machine pool. Format should be a comma-separated list of 'key=value:scheduleType'. "+
 			"This list will overwrite any modifications made to Node taints on an ongoing basis.",
 	)
+	arguments.SetQuestion(flags, "taints", "Taints (optional):")
+
+	flags.StringVar(
+		&args.labelsFile,
+		labelsFileFlag,
+		"",
+		"YAML file containing a map of labels for the machine pool. Labels given with the "+
+			"'--labels' flag are added to them, replacing the ones with the same key.",
+	)
+
+	flags.StringVar(
+		&args.taintsFile,
+		taintsFileFlag,
+		"",
+		"YAML file containing a list of taints for the machine pool, each with a 'key', "+
+			"a 'value' and an 'effect'. Taints given with the '--taints' flag are added to "+
+			"them, replacing the ones with the same key and effect.",
+	)
 
 	flags.StringSliceVar(&args.additionalSecurityGroupIds,
 		additionalSecurityGroupIdsFlag,
@@ -110,6 +154,106 @@ func init() {
 		"The additional Security Group IDs to be added to the machine pool. "+
 			"Format should be a comma-separated list.",
 	)
+
+	flags.StringVar(
+		&args.kubeletConfig,
+		kubeletConfigFlag,
+		"",
+		"Name of the kubelet config that should be used by the nodes of the machine pool. "+
+			"Only supported for Hosted Control Plane clusters.",
+	)
+}
+
+// preRun asks for the missing details of the machine pool in interactive mode. It runs before
+// the required flags are checked, so that they can also be answered interactively.
+func preRun(cmd *cobra.Command, argv []string) error {
+	if !args.interactive {
+		return nil
+	}
+	return ocm.WithConnection(func(connection *sdk.Connection) error {
+		return promptArgs(cmd, argv, connection)
+	})
+}
+
+func promptArgs(cmd *cobra.Command, argv []string, connection *sdk.Connection) error {
+	fs := cmd.Flags()
+
+	err := arguments.PromptString(fs, "cluster")
+	if err != nil {
+		return err
+	}
+	if args.clusterKey == "" {
+		return fmt.Errorf("A cluster name or ID must be specified")
+	}
+	if !c.IsValidClusterKey(args.clusterKey) {
+		return fmt.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			args.clusterKey,
+		)
+	}
+	cluster, err := c.GetCluster(connection, args.clusterKey)
+	if err != nil {
+		return fmt.Errorf("Failed to get cluster '%s': %v", args.clusterKey, err)
+	}
+
+	if len(argv) == 0 || argv[0] == "" {
+		prompt := &survey.Input{
+			Message: "Machine pool ID:",
+		}
+		err = survey.AskOne(prompt, &args.machinePoolID, survey.WithValidator(survey.Required))
+		if err != nil {
+			return err
+		}
+	}
+
+	machineTypes, err := provider.GetMachineTypeOptions(connection.ClustersMgmt().V1(),
+		cluster.CloudProvider().ID(),
+		cluster.CCS().Enabled(), "")
+	if err != nil {
+		return err
+	}
+	err = arguments.PromptOneOf(fs, "instance-type", machineTypes)
+	if err != nil {
+		return err
+	}
+
+	err = arguments.PromptBool(fs, "enable-autoscaling")
+	if err != nil {
+		return err
+	}
+	if args.autoscaling.Enabled {
+		err = arguments.PromptInt(fs, "min-replicas", nil)
+		if err != nil {
+			return err
+		}
+		// set default for interactive mode
+		if args.autoscaling.MaxReplicas == 0 {
+			args.autoscaling.MaxReplicas = args.autoscaling.MinReplicas
+		}
+		err = arguments.PromptInt(fs, "max-replicas", validateMaxReplicas)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = arguments.PromptInt(fs, "replicas", nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = arguments.PromptString(fs, "labels")
+	if err != nil {
+		return err
+	}
+	return arguments.PromptString(fs, "taints")
+}
+
+func validateMaxReplicas() error {
+	if args.autoscaling.MinReplicas > args.autoscaling.MaxReplicas {
+		return fmt.Errorf("max-replicas must be greater or equal to min-replicas")
+	}
+	return nil
 }
 
 func run(cmd *cobra.Command, argv []string) error {
@@ -125,12 +269,22 @@ func run(cmd *cobra.Command, argv []string) error {
 		)
 	}
 
-	if len(argv) < 1 || argv[0] == "" {
+	machinePoolID := args.machinePoolID
+	if len(argv) > 0 && argv[0] != "" {
+		machinePoolID = argv[0]
+	}
+	if machinePoolID == "" {
 		return fmt.Errorf("Missing machine pool ID")
 	}
-	machinePoolID := argv[0]
 
 	labels := make(map[string]string)
+	if args.labelsFile != "" {
+		fileLabels, err := arguments.ReadLabelsFile(args.labelsFile)
+		if err != nil {
+			return err
+		}
+		labels = fileLabels
+	}
 	if args.labels != "" {
 		for _, label := range strings.Split(args.labels, ",") {
 			if !strings.Contains(label, "=") {
@@ -141,15 +295,18 @@ func run(cmd *cobra.Command, argv []string) error {
 		}
 	}
 
-	taintBuilders := []*cmv1.TaintBuilder{}
-
-	if args.taints != "" {
-		for _, taint := range strings.Split(args.taints, ",") {
-			if !strings.Contains(taint, "=") || !strings.Contains(taint, ":") {
-				return fmt.Errorf("Expected key=value:scheduleType format for taints")
-			}
-			tokens := strings.FieldsFunc(taint, arguments.Split)
-			taintBuilders = append(taintBuilders, cmv1.NewTaint().Key(tokens[0]).Value(tokens[1]).Effect(tokens[2]))
+	taintBuilders, err := arguments.ParseTaints(args.taints)
+	if err != nil {
+		return err
+	}
+	if args.taintsFile != "" {
+		fileTaintBuilders, err := arguments.ReadTaintsFile(args.taintsFile)
+		if err != nil {
+			return err
+		}
+		taintBuilders, err = arguments.MergeTaints(fileTaintBuilders, taintBuilders)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -194,17 +351,37 @@ func run(cmd *cobra.Command, argv []string) error {
 		return fmt.Errorf("Cluster '%s' is not yet ready", clusterKey)
 	}
 
+	isHypershift := cluster.Hypershift().Enabled()
+	if args.kubeletConfig != "" {
+		if !isHypershift {
+			return fmt.Errorf("--%s is only supported for Hosted Control Plane clusters", kubeletConfigFlag)
+		}
+		err = checkKubeletConfig(clusterCollection, cluster.ID(), args.kubeletConfig)
+		if err != nil {
+			return err
+		}
+	}
+
 	machineTypeList, err := provider.GetMachineTypeOptions(connection.ClustersMgmt().V1(),
 		cluster.CloudProvider().ID(),
-		cluster.CCS().Enabled())
+		cluster.CCS().Enabled(), "")
 	if err != nil {
 		return err
 	}
-	err = arguments.CheckOneOf(cmd.Flags(), "instance-type", machineTypeList)
+	err = arguments.CheckOneOfWithSuggestions(cmd.Flags(), "instance-type", machineTypeList)
 	if err != nil {
 		return err
 	}
 
+	for i, sg := range args.additionalSecurityGroupIds {
+		args.additionalSecurityGroupIds[i] = strings.TrimSpace(sg)
+	}
+
+	// Kubelet configs can only be set through the node pools API:
+	if args.kubeletConfig != "" {
+		return addNodePool(clusterCollection, cluster.ID(), clusterKey, machinePoolID, labels, taintBuilders)
+	}
+
 	mpBuilder := cmv1.NewMachinePool().
 		ID(machinePoolID).
 		InstanceType(args.instanceType).
@@ -212,9 +389,6 @@ func run(cmd *cobra.Command, argv []string) error {
 		Taints(taintBuilders...)
 
 	if len(args.additionalSecurityGroupIds) != 0 {
-		for i, sg := range args.additionalSecurityGroupIds {
-			args.additionalSecurityGroupIds[i] = strings.TrimSpace(sg)
-		}
 		mpBuilder.AWS(
 			cmv1.NewAWSMachinePool().
 				AdditionalSecurityGroupIds(args.additionalSecurityGroupIds...))
@@ -244,3 +418,66 @@ func run(cmd *cobra.Command, argv []string) error {
 	}
 	return nil
 }
+
+// checkKubeletConfig verifies that a kubelet config with the given name exists in the cluster.
+func checkKubeletConfig(client *cmv1.ClustersClient, clusterID string, name string) error {
+	kubeletConfigs, err := c.GetKubeletConfigs(client, clusterID)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(kubeletConfigs))
+	for _, kubeletConfig := range kubeletConfigs {
+		if kubeletConfig.Name() == name {
+			return nil
+		}
+		names = append(names, kubeletConfig.Name())
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("Kubelet config '%s' doesn't exist: the cluster has no kubelet configs", name)
+	}
+	return fmt.Errorf("Kubelet config '%s' doesn't exist, valid values are: %s", name, strings.Join(names, ", "))
+}
+
+// addNodePool adds the machine pool to a Hosted Control Plane cluster as a node pool, so that it
+// can use a kubelet config.
+func addNodePool(client *cmv1.ClustersClient, clusterID string, clusterKey string, nodePoolID string,
+	labels map[string]string, taintBuilders []*cmv1.TaintBuilder) error {
+	awsNodePool := cmv1.NewAWSNodePool().InstanceType(args.instanceType)
+	if len(args.additionalSecurityGroupIds) != 0 {
+		awsNodePool.AdditionalSecurityGroupIds(args.additionalSecurityGroupIds...)
+	}
+
+	npBuilder := cmv1.NewNodePool().
+		ID(nodePoolID).
+		AWSNodePool(awsNodePool).
+		Labels(labels).
+		Taints(taintBuilders...)
+
+	if args.kubeletConfig != "" {
+		npBuilder = npBuilder.KubeletConfigs(args.kubeletConfig)
+	}
+
+	if args.autoscaling.Enabled {
+		npBuilder = npBuilder.Autoscaling(
+			cmv1.NewNodePoolAutoscaling().
+				MinReplica(args.autoscaling.MinReplicas).
+				MaxReplica(args.autoscaling.MaxReplicas))
+	} else {
+		npBuilder = npBuilder.Replicas(args.replicas)
+	}
+
+	nodePool, err := npBuilder.Build()
+	if err != nil {
+		return fmt.Errorf("Failed to create machine pool for cluster '%s': %v", clusterKey, err)
+	}
+
+	_, err = client.Cluster(clusterID).
+		NodePools().
+		Add().
+		Body(nodePool).
+		Send()
+	if err != nil {
+		return fmt.Errorf("Failed to add machine pool to cluster '%s': %v", clusterKey, err)
+	}
+	return nil
+}
@@ -18,7 +18,9 @@ package delete
 
 import (
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -35,10 +37,15 @@ import (
 )
 
 var args struct {
-	parameter []string
-	header    []string
+	parameter     []string
+	header        []string
+	confirm       bool
+	verboseTiming bool
+	retry         arguments.RetryOptions
 }
 
+const clustersPath = "/api/clusters_mgmt/v1/clusters/"
+
 var Cmd = &cobra.Command{
 	Use:       "delete [flags] (PATH | RESOURCE_ALIAS RESOURCE_ID)",
 	Short:     "Send a DELETE request",
@@ -86,6 +93,9 @@ func init() {
 	fs := Cmd.Flags()
 	arguments.AddParameterFlag(fs, &args.parameter)
 	arguments.AddHeaderFlag(fs, &args.header)
+	arguments.AddVerboseTimingFlag(fs, &args.verboseTiming)
+	arguments.AddRetryFlags(fs, &args.retry)
+	arguments.AddConfirmFlag(fs, &args.confirm)
 	Cmd.AddCommand(idp.Cmd)
 	Cmd.AddCommand(ingress.Cmd)
 	Cmd.AddCommand(machinepool.Cmd)
@@ -94,13 +104,29 @@ func init() {
 }
 
 func run(cmd *cobra.Command, argv []string) error {
+	err := arguments.CheckRetryFlags(cmd.Flags(), &args.retry, http.MethodDelete)
+	if err != nil {
+		return err
+	}
+
 	path, err := urls.Expand(argv)
 	if err != nil {
 		return fmt.Errorf("could not create URI: %w", err)
 	}
 
+	// Deleting a cluster can't be undone, so ask for confirmation first. Other objects only
+	// need confirmation in the production environment:
+	if clusterID, ok := clusterFromPath(path); ok {
+		err = arguments.ConfirmDeletion(cmd.Flags(), "cluster", clusterID)
+	} else {
+		err = arguments.ConfirmProduction(cmd.Flags(), fmt.Sprintf("delete '%s'", path))
+	}
+	if err != nil {
+		return err
+	}
+
 	// Create the client for the OCM API:
-	connection, err := ocm.NewConnection().Build()
+	connection, err := arguments.ApplyRetryFlags(ocm.NewConnection(), args.retry).Build()
 	if err != nil {
 		return fmt.Errorf("failed to create OCM connection: %w", err)
 	}
@@ -113,11 +139,17 @@ func run(cmd *cobra.Command, argv []string) error {
 		fmt.Fprintf(os.Stderr, "Can't parse path '%s': %v\n", path, err)
 		os.Exit(1)
 	}
-	arguments.ApplyParameterFlag(request, args.parameter)
-	arguments.ApplyHeaderFlag(request, args.header)
+	err = arguments.ApplyParameterFlag(request, args.parameter)
+	if err != nil {
+		return err
+	}
+	err = arguments.ApplyHeaderFlag(request, args.header)
+	if err != nil {
+		return err
+	}
 
 	// Send the request:
-	response, err := request.Send()
+	response, err := arguments.SendRequestWithRetry(request, args.verboseTiming, args.retry)
 	if err != nil {
 		return fmt.Errorf("can't send request: %w", err)
 	}
@@ -158,3 +190,13 @@ func run(cmd *cobra.Command, argv []string) error {
 
 	return nil
 }
+
+// clusterFromPath returns the identifier of the cluster if the given path points to a cluster.
+func clusterFromPath(path string) (clusterID string, ok bool) {
+	path, _, _ = strings.Cut(path, "?")
+	clusterID, ok = strings.CutPrefix(strings.TrimSuffix(path, "/"), clustersPath)
+	if !ok || clusterID == "" || strings.Contains(clusterID, "/") {
+		return "", false
+	}
+	return clusterID, true
+}
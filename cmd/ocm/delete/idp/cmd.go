@@ -19,12 +19,14 @@ import (
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
 	c "github.com/openshift-online/ocm-cli/pkg/cluster"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
 )
 
 var args struct {
 	clusterKey string
+	confirm    bool
 }
 
 var Cmd = &cobra.Command{
@@ -49,6 +51,7 @@ func init() {
 	)
 	//nolint:gosec
 	Cmd.MarkFlagRequired("cluster")
+	arguments.AddConfirmFlag(flags, &args.confirm)
 }
 
 func run(cmd *cobra.Command, argv []string) error {
@@ -76,6 +79,14 @@ func run(cmd *cobra.Command, argv []string) error {
 			clusterKey,
 		)
 	}
+	err := arguments.ConfirmProduction(cmd.Flags(), fmt.Sprintf(
+		"delete identity provider '%s' of cluster '%s'",
+		idpName, clusterKey,
+	))
+	if err != nil {
+		return err
+	}
+
 	// Create the client for the OCM API:
 	connection, err := ocm.NewConnection().Build()
 	if err != nil {
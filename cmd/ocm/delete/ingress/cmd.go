@@ -20,6 +20,7 @@ import (
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
 	c "github.com/openshift-online/ocm-cli/pkg/cluster"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
 )
@@ -27,6 +28,7 @@ import (
 var ingressKeyRE = regexp.MustCompile(`^[a-z0-9]{4,5}$`)
 var args struct {
 	clusterKey string
+	confirm    bool
 }
 
 var Cmd = &cobra.Command{
@@ -53,6 +55,7 @@ func init() {
 	)
 	//nolint:gosec
 	Cmd.MarkFlagRequired("cluster")
+	arguments.AddConfirmFlag(flags, &args.confirm)
 }
 
 func run(cmd *cobra.Command, argv []string) error {
@@ -83,6 +86,14 @@ func run(cmd *cobra.Command, argv []string) error {
 			clusterKey,
 		)
 	}
+	err := arguments.ConfirmProduction(cmd.Flags(), fmt.Sprintf(
+		"delete ingress '%s' of cluster '%s'",
+		ingressID, clusterKey,
+	))
+	if err != nil {
+		return err
+	}
+
 	// Create the client for the OCM API:
 	connection, err := ocm.NewConnection().Build()
 	if err != nil {
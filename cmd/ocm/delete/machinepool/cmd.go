@@ -18,12 +18,14 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
 	c "github.com/openshift-online/ocm-cli/pkg/cluster"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
 )
 
 var args struct {
 	clusterKey string
+	confirm    bool
 }
 
 var Cmd = &cobra.Command{
@@ -48,6 +50,7 @@ func init() {
 	)
 	//nolint:gosec
 	Cmd.MarkFlagRequired("cluster")
+	arguments.AddConfirmFlag(flags, &args.confirm)
 }
 
 func run(cmd *cobra.Command, argv []string) error {
@@ -72,6 +75,14 @@ func run(cmd *cobra.Command, argv []string) error {
 			clusterKey,
 		)
 	}
+	err := arguments.ConfirmProduction(cmd.Flags(), fmt.Sprintf(
+		"delete machine pool '%s' of cluster '%s'",
+		machinePoolID, clusterKey,
+	))
+	if err != nil {
+		return err
+	}
+
 	// Create the client for the OCM API:
 	connection, err := ocm.NewConnection().Build()
 	if err != nil {
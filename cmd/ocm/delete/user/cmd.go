@@ -18,12 +18,14 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
 	c "github.com/openshift-online/ocm-cli/pkg/cluster"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
 )
 
 var args struct {
 	clusterKey string
+	confirm    bool
 	group      string
 }
 
@@ -49,6 +51,7 @@ func init() {
 	)
 	//nolint:gosec
 	Cmd.MarkFlagRequired("cluster")
+	arguments.AddConfirmFlag(flags, &args.confirm)
 
 	flags.StringVar(
 		&args.group,
@@ -79,6 +82,14 @@ func run(cmd *cobra.Command, argv []string) error {
 			clusterKey,
 		)
 	}
+	err := arguments.ConfirmProduction(cmd.Flags(), fmt.Sprintf(
+		"delete user '%s' of cluster '%s'",
+		username, clusterKey,
+	))
+	if err != nil {
+		return err
+	}
+
 	// Create the client for the OCM API:
 	connection, err := ocm.NewConnection().Build()
 	if err != nil {
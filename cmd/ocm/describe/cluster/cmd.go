@@ -30,8 +30,11 @@ import (
 )
 
 var args struct {
-	json   bool
-	output bool
+	json             bool
+	output           bool
+	showNodesSummary bool
+	showProxy        bool
+	timestamps       bool
 }
 
 var Cmd = &cobra.Command{
@@ -56,6 +59,27 @@ func init() {
 		false,
 		"Output the entire JSON structure",
 	)
+	flags.BoolVar(
+		&args.showNodesSummary,
+		"show-nodes-summary",
+		false,
+		"Show only a summary of the nodes of the cluster: the number of control plane, infra "+
+			"and compute nodes, and the instance type of the compute nodes.",
+	)
+	flags.BoolVar(
+		&args.showProxy,
+		"show-proxy",
+		false,
+		"Show only the cluster-wide proxy settings of the cluster: the HTTP, HTTPS and no "+
+			"proxy values, and whether an additional trust bundle is configured.",
+	)
+	flags.BoolVar(
+		&args.timestamps,
+		"timestamps",
+		false,
+		"Show only the creation time, the expiration time if set, and the last update time of "+
+			"the cluster, both as dates and relative to now.",
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) error {
@@ -83,6 +107,11 @@ func run(cmd *cobra.Command, argv []string) error {
 		os.Exit(1)
 	}
 
+	err := checkExclusiveFlags(cmd)
+	if err != nil {
+		return err
+	}
+
 	// Create the client for the OCM API:
 	connection, err := ocm.NewConnection().Build()
 	if err != nil {
@@ -129,6 +158,15 @@ func run(cmd *cobra.Command, argv []string) error {
 			return fmt.Errorf("Can't print body: %v", err)
 		}
 
+	} else if args.showNodesSummary {
+		c.PrintNodesSummary(cluster)
+	} else if args.showProxy {
+		c.PrintProxySummary(cluster)
+	} else if args.timestamps {
+		err = c.PrintTimestamps(connection, cluster)
+		if err != nil {
+			return err
+		}
 	} else {
 		err = c.PrintClusterDescription(connection, cluster)
 		if err != nil {
@@ -138,3 +176,22 @@ func run(cmd *cobra.Command, argv []string) error {
 
 	return nil
 }
+
+// exclusiveFlags are the flags that select what is displayed, so only one of them can be used.
+var exclusiveFlags = []string{"json", "show-nodes-summary", "show-proxy", "timestamps"}
+
+// checkExclusiveFlags checks that at most one of the flags that select what is displayed has been
+// used.
+func checkExclusiveFlags(cmd *cobra.Command) error {
+	var used []string
+	for _, name := range exclusiveFlags {
+		value, _ := cmd.Flags().GetBool(name)
+		if value {
+			used = append(used, name)
+		}
+	}
+	if len(used) > 1 {
+		return fmt.Errorf("Flags --%s and --%s can't be used at the same time", used[0], used[1])
+	}
+	return nil
+}
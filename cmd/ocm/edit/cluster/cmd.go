@@ -22,9 +22,11 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
 	c "github.com/openshift-online/ocm-cli/pkg/cluster"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
 	"github.com/openshift-online/ocm-cli/pkg/utils"
+	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 )
 
@@ -39,6 +41,8 @@ var args struct {
 	channelGroup string
 
 	clusterWideProxy c.ClusterWideProxy
+
+	confirm bool
 }
 
 var Cmd = &cobra.Command{
@@ -119,7 +123,8 @@ func init() {
 		"additional-trust-bundle-file",
 		"",
 		"A file contains a PEM-encoded X.509 certificate bundle that will be "+
-			"added to the nodes' trusted certificate store.")
+			"added to the nodes' trusted certificate store. Pass an empty value to "+
+			"remove the current bundle.")
 
 	flags.BoolVar(
 		&args.enableDeleteProtection,
@@ -127,6 +132,7 @@ func init() {
 		false,
 		"Enable cluster delete protection against accidental cluster deletion.",
 	)
+	arguments.AddConfirmFlag(flags, &args.confirm)
 }
 
 func isGCPNetworkEmpty(network *cmv1.GCPNetwork) bool {
@@ -160,13 +166,17 @@ func run(cmd *cobra.Command, argv []string) error {
 		)
 	}
 
-	// Create the client for the OCM API:
-	connection, err := ocm.NewConnection().Build()
+	err := arguments.ConfirmProduction(cmd.Flags(), fmt.Sprintf("edit cluster '%s'", clusterKey))
 	if err != nil {
-		return fmt.Errorf("Failed to create OCM connection: %v", err)
+		return err
 	}
-	defer connection.Close()
 
+	return ocm.WithConnection(func(connection *sdk.Connection) error {
+		return editCluster(cmd, clusterKey, connection)
+	})
+}
+
+func editCluster(cmd *cobra.Command, clusterKey string, connection *sdk.Connection) error {
 	// Get the client for the cluster management api
 	clusterCollection := connection.ClustersMgmt().V1().Clusters()
 
@@ -188,6 +198,10 @@ func run(cmd *cobra.Command, argv []string) error {
 
 	var channelGroup string
 	if cmd.Flags().Changed("channel-group") {
+		err = checkChannelGroup(connection.ClustersMgmt().V1(), cluster, args.channelGroup)
+		if err != nil {
+			return err
+		}
 		channelGroup = args.channelGroup
 	}
 
@@ -295,3 +309,25 @@ func run(cmd *cobra.Command, argv []string) error {
 	return nil
 
 }
+
+// checkChannelGroup verifies that the current version of the cluster is available in the given
+// channel group.
+func checkChannelGroup(client *cmv1.Client, cluster *cmv1.Cluster, channelGroup string) error {
+	rawVersion := cluster.Version().RawID()
+	if rawVersion == "" {
+		rawVersion = cluster.OpenshiftVersion()
+	}
+	channelGroups, err := c.GetChannelGroups(client, rawVersion)
+	if err != nil {
+		return fmt.Errorf("Failed to get the channel groups for version '%s': %v", rawVersion, err)
+	}
+	for _, value := range channelGroups {
+		if value == channelGroup {
+			return nil
+		}
+	}
+	return fmt.Errorf(
+		"Channel group '%s' isn't available for version '%s' of cluster '%s', valid values are: %s",
+		channelGroup, rawVersion, cluster.Name(), strings.Join(channelGroups, ", "),
+	)
+}
@@ -18,6 +18,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
 	c "github.com/openshift-online/ocm-cli/pkg/cluster"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
 	"github.com/openshift-online/ocm-cli/pkg/utils"
@@ -44,6 +45,7 @@ var expectedParameters = []string{
 
 var args struct {
 	clusterKey    string
+	isDefault     bool
 	private       bool
 	routeSelector string
 	lbType        string
@@ -55,9 +57,12 @@ var args struct {
 	clusterRoutesTlsSecretRef string
 
 	componentRoutes string
+
+	confirm bool
 }
 
 const (
+	defaultFlag                   = "default"
 	privateFlag                   = "private"
 	labelMatchFlag                = "label-match"
 	lbTypeFlag                    = "lb-type"
@@ -76,14 +81,16 @@ const (
 )
 
 var Cmd = &cobra.Command{
-	Use:     "ingress --cluster={NAME|ID|EXTERNAL_ID} [flags] INGRESS_ID",
+	Use:     "ingress --cluster={NAME|ID|EXTERNAL_ID} [flags] {INGRESS_ID|--default}",
 	Aliases: []string{"route", "routes", "ingresses"},
 	Short:   "Edit a cluster Ingress",
 	Long:    "Edit an Ingress endpoint to determine access to the cluster.",
 	Example: `  #  Update the router selectors for the additional ingress with ID 'a1b2'
   ocm edit ingress --label-match=foo=bar --cluster=mycluster a1b2
-  #  Update the default ingress using the sub-domain identifier
-  ocm edit ingress --private=false --cluster=mycluster apps"`,
+  #  Update the default ingress
+  ocm edit ingress --private=false --cluster=mycluster --default
+  #  Update the default ingress using the legacy sub-domain identifier
+  ocm edit ingress --private=false --cluster=mycluster apps`,
 	RunE: run,
 }
 
@@ -100,6 +107,14 @@ func init() {
 	//nolint:gosec
 	Cmd.MarkFlagRequired("cluster")
 
+	flags.BoolVar(
+		&args.isDefault,
+		defaultFlag,
+		false,
+		"Edit the default ingress of the cluster, instead of the one given by its identifier. "+
+			"Replaces the legacy 'apps' identifier.",
+	)
+
 	flags.BoolVar(
 		&args.private,
 		"private",
@@ -173,22 +188,34 @@ func init() {
 		"",
 		//nolint:lll
 		"Component routes settings. Available keys [oauth, console, downloads]. For each key a pair of hostname and tlsSecretRef is expected to be supplied. "+
-			"Format should be a comma separate list 'oauth: hostname=example-hostname;tlsSecretRef=example-secret-ref,downloads:...",
+			"Format should be a comma separate list 'oauth: hostname=example-hostname;tlsSecretRef=example-secret-ref,downloads:...'. "+
+			"An empty value removes all the component routes, and if the flag isn't used the component routes are left unchanged.",
 	)
+	arguments.AddConfirmFlag(flags, &args.confirm)
 }
 
 func run(cmd *cobra.Command, argv []string) error {
 
-	if len(argv) != 1 {
+	var ingressID string
+	switch {
+	case len(argv) == 1:
+		ingressID = argv[0]
+		if !ingressKeyRE.MatchString(ingressID) {
+			return fmt.Errorf(
+				"Ingress  identifier '%s' isn't valid: it must contain only letters or digits",
+				ingressID,
+			)
+		}
+	case len(argv) == 0 && args.isDefault:
+	default:
 		return fmt.Errorf(
-			"Expected exactly one command line parameter containing the id of the ingress")
+			"Expected exactly one command line parameter containing the id of the ingress, " +
+				"or the --default flag")
 	}
-
-	ingressID := argv[0]
-	if !ingressKeyRE.MatchString(ingressID) {
+	if args.isDefault && ingressID == legacyAdditionalIngressID {
 		return fmt.Errorf(
-			"Ingress  identifier '%s' isn't valid: it must contain only letters or digits",
-			ingressID,
+			"Flag --%s can't be used with '%s', which selects the additional ingress",
+			defaultFlag, legacyAdditionalIngressID,
 		)
 	}
 
@@ -203,6 +230,18 @@ func run(cmd *cobra.Command, argv []string) error {
 		)
 	}
 
+	description := fmt.Sprintf("ingress '%s'", ingressID)
+	if ingressID == "" {
+		description = "the default ingress"
+	}
+	err := arguments.ConfirmProduction(cmd.Flags(), fmt.Sprintf(
+		"edit %s of cluster '%s'",
+		description, clusterKey,
+	))
+	if err != nil {
+		return err
+	}
+
 	// Create the client for the OCM API:
 	connection, err := ocm.NewConnection().Build()
 	if err != nil {
@@ -227,20 +266,15 @@ func run(cmd *cobra.Command, argv []string) error {
 		return fmt.Errorf("Failed to get ingresses for cluster '%s': %v", clusterKey, err)
 	}
 
-	var ingress *cmv1.Ingress
-	for _, item := range ingresses {
-		if ingressID == "apps" && item.Default() {
-			ingress = item
-		}
-		if ingressID == "apps2" && !item.Default() {
-			ingress = item
-		}
-		if item.ID() == ingressID {
-			ingress = item
-		}
-	}
+	ingress := selectIngress(ingresses, ingressID, args.isDefault)
 	if ingress == nil {
-		return fmt.Errorf("Failed to get ingress '%s' for cluster '%s'", ingressID, clusterKey)
+		return fmt.Errorf("Failed to get %s for cluster '%s'", description, clusterKey)
+	}
+	if args.isDefault && !ingress.Default() {
+		return fmt.Errorf(
+			"Ingress '%s' isn't the default ingress of cluster '%s', it can't be used with --%s",
+			ingressID, clusterKey, defaultFlag,
+		)
 	}
 
 	ingressBuilder := cmv1.NewIngress().ID(ingress.ID())
@@ -340,9 +374,39 @@ func run(cmd *cobra.Command, argv []string) error {
 	return nil
 }
 
+// Legacy identifiers that select the default and the additional ingress of a cluster.
+const (
+	legacyDefaultIngressID    = "apps"
+	legacyAdditionalIngressID = "apps2"
+)
+
+// selectIngress returns the ingress with the given identifier. When the identifier is empty and
+// isDefault is true it returns the default ingress. The legacy 'apps' and 'apps2' identifiers
+// select the default and the additional ingress. It returns nil if no ingress matches.
+func selectIngress(ingresses []*cmv1.Ingress, ingressID string, isDefault bool) *cmv1.Ingress {
+	var result *cmv1.Ingress
+	for _, item := range ingresses {
+		if (ingressID == legacyDefaultIngressID || ingressID == "" && isDefault) && item.Default() {
+			result = item
+		}
+		if ingressID == legacyAdditionalIngressID && !item.Default() {
+			result = item
+		}
+		if item.ID() == ingressID {
+			result = item
+		}
+	}
+	return result
+}
+
+// parseComponentRoutes parses the value of the component routes flag. An empty value means that the
+// component routes should be removed, so it returns an empty, but not nil, set.
 func parseComponentRoutes(input string) (map[string]*cmv1.ComponentRouteBuilder, error) {
 	result := map[string]*cmv1.ComponentRouteBuilder{}
 	input = strings.TrimSpace(input)
+	if input == "" {
+		return result, nil
+	}
 	components := strings.Split(input, ",")
 	if len(components) != len(expectedComponentRoutes) {
 		return nil, fmt.Errorf(
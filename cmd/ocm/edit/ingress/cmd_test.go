@@ -5,6 +5,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 )
 
 var _ = Describe("Parse component routes", func() {
@@ -23,6 +24,13 @@ var _ = Describe("Parse component routes", func() {
 			Expect(componentRoute.TlsSecretRef()).To(Equal(expectedTlsRef))
 		}
 	})
+
+	It("Returns an empty set of component routes for an empty input string", func() {
+		componentRouteBuilder, err := parseComponentRoutes("  ")
+		Expect(err).To(BeNil())
+		Expect(componentRouteBuilder).ToNot(BeNil())
+		Expect(componentRouteBuilder).To(BeEmpty())
+	})
 	Context("Fails to parse input string for component routes", func() {
 		It("fails due to invalid component route", func() {
 			_, err := parseComponentRoutes(
@@ -76,3 +84,34 @@ var _ = Describe("Parse component routes", func() {
 		})
 	})
 })
+
+var _ = Describe("Select ingress", func() {
+	var ingresses []*cmv1.Ingress
+
+	BeforeEach(func() {
+		defaultIngress, err := cmv1.NewIngress().ID("a1b2").Default(true).Build()
+		Expect(err).To(BeNil())
+		additionalIngress, err := cmv1.NewIngress().ID("c3d4").Default(false).Build()
+		Expect(err).To(BeNil())
+		ingresses = []*cmv1.Ingress{defaultIngress, additionalIngress}
+	})
+
+	DescribeTable(
+		"Selects the expected ingress",
+		func(ingressID string, isDefault bool, expected string) {
+			ingress := selectIngress(ingresses, ingressID, isDefault)
+			if expected == "" {
+				Expect(ingress).To(BeNil())
+				return
+			}
+			Expect(ingress).ToNot(BeNil())
+			Expect(ingress.ID()).To(Equal(expected))
+		},
+		Entry("Default flag without identifier", "", true, "a1b2"),
+		Entry("Legacy default identifier", "apps", false, "a1b2"),
+		Entry("Legacy additional identifier", "apps2", false, "c3d4"),
+		Entry("Explicit identifier", "c3d4", false, "c3d4"),
+		Entry("Explicit identifier with default flag", "c3d4", true, "c3d4"),
+		Entry("Unknown identifier", "e5f6", false, ""),
+	)
+})
@@ -30,6 +30,7 @@ var args struct {
 	autoscaling c.Autoscaling
 	labels      string
 	taints      string
+	confirm     bool
 }
 
 var Cmd = &cobra.Command{
@@ -40,7 +41,9 @@ var Cmd = &cobra.Command{
 	Example: `  #  Update the number of replicas for machine pool with ID 'a1b2'
   ocm edit machinepool --replicas=3 --cluster=mycluster a1b2
   # Enable autoscaling and Set 3-5 replicas on machine pool 'mp1' on cluster 'mycluster'
-  ocm edit machinepool --enable-autoscaling --min-replicas=3 max-replicas=5 --cluster=mycluster mp1`,
+  ocm edit machinepool --enable-autoscaling --min-replicas=3 max-replicas=5 --cluster=mycluster mp1
+  # Remove all the labels and taints from machine pool 'mp1' on cluster 'mycluster'
+  ocm edit machinepool --labels="" --taints="" --cluster=mycluster mp1`,
 	RunE: run,
 }
 
@@ -71,7 +74,8 @@ func init() {
 		"labels",
 		"",
 		"Labels for machine pool. Format should be a comma-separated list of 'key=value'. "+
-			"This list will overwrite any modifications made to Node labels on an ongoing basis.",
+			"This list will overwrite any modifications made to Node labels on an ongoing basis. "+
+			"An empty value removes all the labels, and if the flag isn't used the labels are left unchanged.",
 	)
 
 	flags.StringVar(
@@ -79,8 +83,10 @@ func init() {
 		"taints",
 		"",
 		"Taints for machine pool. Format should be a comma-separated list of 'key=value:scheduleType'. "+
-			"This list will overwrite any modifications made to Node taints on an ongoing basis.",
+			"This list will overwrite any modifications made to Node taints on an ongoing basis. "+
+			"An empty value removes all the taints, and if the flag isn't used the taints are left unchanged.",
 	)
+	arguments.AddConfirmFlag(flags, &args.confirm)
 }
 
 func run(cmd *cobra.Command, argv []string) error {
@@ -103,6 +109,14 @@ func run(cmd *cobra.Command, argv []string) error {
 		)
 	}
 
+	err := arguments.ConfirmProduction(cmd.Flags(), fmt.Sprintf(
+		"edit machine pool '%s' of cluster '%s'",
+		machinePoolID, clusterKey,
+	))
+	if err != nil {
+		return err
+	}
+
 	// Create the client for the OCM API:
 	connection, err := ocm.NewConnection().Build()
 	if err != nil {
@@ -129,20 +143,16 @@ func run(cmd *cobra.Command, argv []string) error {
 		}
 	}
 
-	taintBuilders := []*cmv1.TaintBuilder{}
-
-	if args.taints != "" {
-		for _, taint := range strings.Split(args.taints, ",") {
-			if !strings.Contains(taint, "=") || !strings.Contains(taint, ":") {
-				return fmt.Errorf("Expected key=value:scheduleType format for taints")
-			}
-			tokens := strings.FieldsFunc(taint, arguments.Split)
-			taintBuilders = append(taintBuilders, cmv1.NewTaint().Key(tokens[0]).Value(tokens[1]).Effect(tokens[2]))
-		}
+	taintBuilders, err := arguments.ParseTaints(args.taints)
+	if err != nil {
+		return err
 	}
 
 	machinePoolBuilder := cmv1.NewMachinePool().ID(machinePoolID)
 
+	// Labels and taints are only sent when the flags are used, so that they are left unchanged
+	// otherwise. Note that when the flags are used with an empty value the empty map and list
+	// are sent, and that removes all the existing labels and taints.
 	if cmd.Flags().Changed("labels") {
 		machinePoolBuilder = machinePoolBuilder.Labels(labels)
 	}
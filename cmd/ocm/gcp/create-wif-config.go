@@ -26,6 +26,9 @@ var (
 		TargetDir:        "",
 		OpenshiftVersion: "",
 	}
+
+	// customJwks is the content of the file given with the '--jwks-file' flag.
+	customJwks string
 )
 
 const (
@@ -46,7 +49,11 @@ wif-config objects represent a set of GCP resources that are needed in a
 deployment of WIF OSD-GCP clusters. These resources include service accounts,
 custom roles, role bindings, identity and federated pools. Running this command
 in auto-mode will generate these resources on the user's cloud, and create a
-wif-config resource within OCM to represent those resources.`,
+wif-config resource within OCM to represent those resources.
+
+The JWKS configured in the workload identity provider is generated by OCM. A
+custom one can be supplied with the '--jwks-file' flag; GCP will then only
+accept tokens signed by one of the keys of that set.`,
 		PreRunE: validationForCreateWorkloadIdentityConfigurationCmd,
 		RunE:    createWorkloadIdentityConfigurationCmd,
 	}
@@ -82,6 +89,12 @@ wif-config resource within OCM to represent those resources.`,
 		"",
 		versionFlagDescription,
 	)
+	createWifConfigCmd.PersistentFlags().StringVar(
+		&CreateWifConfigOpts.JwksFile,
+		"jwks-file",
+		"",
+		jwksFileFlagDescription,
+	)
 
 	return createWifConfigCmd
 }
@@ -106,6 +119,13 @@ func validationForCreateWorkloadIdentityConfigurationCmd(cmd *cobra.Command, arg
 	if err != nil {
 		return err
 	}
+
+	if CreateWifConfigOpts.JwksFile != "" {
+		customJwks, err = loadJwksFile(CreateWifConfigOpts.JwksFile)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -184,6 +204,12 @@ func createWorkloadIdentityConfigurationCmd(cmd *cobra.Command, argv []string) e
 	if err != nil {
 		return errors.Wrapf(err, "failed to create wif-config")
 	}
+	if customJwks != "" {
+		wifConfig, err = withJwks(wifConfig, customJwks)
+		if err != nil {
+			return errors.Wrapf(err, "failed to set custom JWKS")
+		}
+	}
 
 	if CreateWifConfigOpts.Mode == ModeManual {
 		log.Printf("Writing script files to %s", CreateWifConfigOpts.TargetDir)
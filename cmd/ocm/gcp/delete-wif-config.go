@@ -2,11 +2,13 @@ package gcp
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"strings"
 
 	"log"
 
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
 	"github.com/openshift-online/ocm-cli/pkg/gcp"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -60,6 +62,14 @@ GCP resources represented by the wif-config.
 		"",
 		targetDirFlagDescription,
 	)
+	deleteWifConfigCmd.Flags().BoolVar(
+		&DeleteWifConfigOpts.Force,
+		"force",
+		false,
+		"Continue deleting the remaining resources when the deletion of one of them fails, "+
+			"and report all the failures at the end.",
+	)
+	arguments.AddConfirmFlag(deleteWifConfigCmd.Flags(), &DeleteWifConfigOpts.Confirm)
 
 	return deleteWifConfigCmd
 }
@@ -115,43 +125,85 @@ func deleteWorkloadIdentityConfigurationCmd(cmd *cobra.Command, argv []string) e
 		return nil
 	}
 
-	gcpClient, err := gcp.NewGcpClient(context.Background())
+	err = arguments.ConfirmDeletion(cmd.Flags(), "wif-config", wifConfig.DisplayName())
 	if err != nil {
 		return err
 	}
 
-	if err := deleteServiceAccounts(ctx, gcpClient, wifConfig, true); err != nil {
+	gcpClient, err := gcp.NewGcpClient(context.Background())
+	if err != nil {
 		return err
 	}
 
+	return deleteWifConfigResources(ctx, gcpClient, connection.ClustersMgmt().V1(), wifConfig,
+		DeleteWifConfigOpts.Force)
+}
+
+// deleteWifConfigResources deletes the GCP resources of the wif config and then the wif config
+// itself. When forced, failures don't stop the deletion, they are collected and reported at the end.
+func deleteWifConfigResources(ctx context.Context, gcpClient gcp.GcpClient, client *cmv1.Client,
+	wifConfig *cmv1.WifConfig, force bool) error {
+	var failures []error
+
+	if err := deleteServiceAccounts(ctx, gcpClient, wifConfig, true, force); err != nil {
+		if !force {
+			return err
+		}
+		failures = append(failures, err)
+	}
+
 	if err := deleteWorkloadIdentityPool(ctx, gcpClient, wifConfig, true); err != nil {
-		return err
+		if !force {
+			return err
+		}
+		failures = append(failures, err)
 	}
 
-	_, err = connection.ClustersMgmt().V1().GCP().WifConfigs().
+	_, err := client.GCP().WifConfigs().
 		WifConfig(wifConfig.ID()).
 		Delete().
 		Send()
 	if err != nil {
-		return errors.Wrapf(err, "failed to delete wif config %q", wifConfig.ID())
+		err = errors.Wrapf(err, "failed to delete wif config %q", wifConfig.ID())
+		if !force {
+			return err
+		}
+		failures = append(failures, err)
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to delete some of the resources of wif config %q: %w",
+			wifConfig.ID(), stderrors.Join(failures...))
 	}
 	return nil
 }
 
+// deleteServiceAccounts deletes the service accounts of the wif config. Unless force is set it
+// stops at the first failure, otherwise it tries to delete all of them and returns the failures
+// combined in a single error.
 func deleteServiceAccounts(ctx context.Context, gcpClient gcp.GcpClient,
-	wifConfig *cmv1.WifConfig, allowMissing bool) error {
+	wifConfig *cmv1.WifConfig, allowMissing bool, force bool) error {
 	log.Println("Deleting service accounts...")
 	projectId := wifConfig.Gcp().ProjectId()
 
+	var failures []error
 	for _, serviceAccount := range wifConfig.Gcp().ServiceAccounts() {
 		serviceAccountID := serviceAccount.ServiceAccountId()
 		log.Println("Deleting service account", serviceAccountID)
 		err := gcpClient.DeleteServiceAccount(ctx, serviceAccountID, projectId, allowMissing)
 		if err != nil {
-			return errors.Wrapf(err, "Failed to delete service account %q", serviceAccountID)
+			err = errors.Wrapf(err, "Failed to delete service account %q", serviceAccountID)
+			if !force {
+				return err
+			}
+			log.Println(err)
+			failures = append(failures, err)
 		}
 	}
 
+	if len(failures) > 0 {
+		return fmt.Errorf("Failed to delete some of the service accounts: %w", stderrors.Join(failures...))
+	}
 	return nil
 }
 
@@ -0,0 +1,135 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+	iamv1 "google.golang.org/api/iam/v1"
+
+	"github.com/openshift-online/ocm-cli/pkg/gcp"
+)
+
+// fakeGcpClient records the deleted resources, and fails to delete the ones that are in the
+// failures map.
+type fakeGcpClient struct {
+	gcp.GcpClient
+	failures map[string]error
+	deleted  []string
+}
+
+func (c *fakeGcpClient) DeleteServiceAccount(ctx context.Context, saName string, project string,
+	allowMissing bool) error {
+	return c.delete(saName)
+}
+
+func (c *fakeGcpClient) DeleteWorkloadIdentityPool(ctx context.Context,
+	resource string) (*iamv1.Operation, error) {
+	return nil, c.delete(resource)
+}
+
+func (c *fakeGcpClient) delete(name string) error {
+	if err, ok := c.failures[name]; ok {
+		return err
+	}
+	c.deleted = append(c.deleted, name)
+	return nil
+}
+
+var _ = Describe("Delete wif-config", func() {
+	const poolResource = "projects/my-project/locations/global/workloadIdentityPools/my-pool"
+
+	var server *Server
+	var connection *sdk.Connection
+	var gcpClient *fakeGcpClient
+	var wifConfig *cmv1.WifConfig
+
+	BeforeEach(func() {
+		server = MakeTCPServer()
+		var err error
+		connection, err = sdk.NewConnectionBuilder().
+			URL(server.URL()).
+			Tokens(MakeTokenString("Bearer", 15*time.Minute)).
+			RetryLimit(0).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() {
+			connection.Close()
+			server.Close()
+		})
+
+		gcpClient = &fakeGcpClient{failures: map[string]error{}}
+		wifConfig, err = cmv1.NewWifConfig().
+			ID("123").
+			Gcp(cmv1.NewWifGcp().
+				ProjectId("my-project").
+				ServiceAccounts(
+					cmv1.NewWifServiceAccount().ServiceAccountId("sa-1"),
+					cmv1.NewWifServiceAccount().ServiceAccountId("sa-2"),
+				).
+				WorkloadIdentityPool(cmv1.NewWifPool().PoolId("my-pool"))).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	respondToDelete := func(status int) {
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodDelete, "/api/clusters_mgmt/v1/gcp/wif_configs/123"),
+				RespondWithJSON(status, `{}`),
+			),
+		)
+	}
+
+	It("Deletes the resources and the wif-config", func() {
+		respondToDelete(http.StatusNoContent)
+		err := deleteWifConfigResources(context.Background(), gcpClient,
+			connection.ClustersMgmt().V1(), wifConfig, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gcpClient.deleted).To(Equal([]string{"sa-1", "sa-2", poolResource}))
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+	})
+
+	It("Stops at the first failure without --force", func() {
+		gcpClient.failures["sa-1"] = fmt.Errorf("permission denied")
+		err := deleteWifConfigResources(context.Background(), gcpClient,
+			connection.ClustersMgmt().V1(), wifConfig, false)
+		Expect(err).To(MatchError(`Failed to delete service account "sa-1": permission denied`))
+		Expect(gcpClient.deleted).To(BeEmpty())
+		Expect(server.ReceivedRequests()).To(BeEmpty())
+	})
+
+	It("Deletes the remaining resources with --force and reports all the failures", func() {
+		gcpClient.failures["sa-1"] = fmt.Errorf("permission denied")
+		gcpClient.failures[poolResource] = fmt.Errorf("pool is busy")
+		respondToDelete(http.StatusNoContent)
+		err := deleteWifConfigResources(context.Background(), gcpClient,
+			connection.ClustersMgmt().V1(), wifConfig, true)
+		Expect(err).To(MatchError(
+			`failed to delete some of the resources of wif config "123": ` +
+				`Failed to delete some of the service accounts: ` +
+				`Failed to delete service account "sa-1": permission denied` + "\n" +
+				`Failed to delete workload identity pool "my-pool": pool is busy`,
+		))
+		Expect(gcpClient.deleted).To(Equal([]string{"sa-2"}))
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+	})
+
+	It("Reports the failure to delete the wif-config with --force", func() {
+		respondToDelete(http.StatusConflict)
+		err := deleteWifConfigResources(context.Background(), gcpClient,
+			connection.ClustersMgmt().V1(), wifConfig, true)
+		Expect(err).To(MatchError(ContainSubstring(
+			`failed to delete some of the resources of wif config "123": ` +
+				`failed to delete wif config "123"`,
+		)))
+		Expect(gcpClient.deleted).To(Equal([]string{"sa-1", "sa-2", poolResource}))
+	})
+})
@@ -11,3 +11,12 @@ manual:         Commands necessary to modify GCP resources will be output
 	targetDirFlagDescription = `Directory to place generated files (defaults to current directory)`
 	versionFlagDescription   = `Version of OpenShift to configure the WIF resources for`
 )
+
+const jwksFileFlagDescription = `Path to a file containing the JSON Web Key Set (JWKS) to configure
+in the workload identity provider, instead of the one generated by OCM.
+GCP will only accept tokens signed by one of the keys of this set, so it
+must contain the public keys used to sign the service account tokens of
+the clusters that use the wif-config. The set is only applied to the GCP
+resources; running 'ocm gcp update wif-config' will restore the JWKS
+generated by OCM.
+`
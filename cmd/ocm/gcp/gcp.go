@@ -5,7 +5,10 @@ import (
 )
 
 type options struct {
+	Confirm                  bool
+	Force                    bool
 	Interactive              bool
+	JwksFile                 string
 	Mode                     string
 	Name                     string
 	OpenshiftVersion         string
@@ -63,7 +63,10 @@ func getWorkloadIdentityConfigurationCmd(cmd *cobra.Command, argv []string) erro
 	defer connection.Close()
 
 	request := connection.Get().Path(path)
-	arguments.ApplyParameterFlag(request, GetWorkloadIdentityConfigurationOpts.parameter)
+	err = arguments.ApplyParameterFlag(request, GetWorkloadIdentityConfigurationOpts.parameter)
+	if err != nil {
+		return err
+	}
 
 	resp, err := request.Send()
 	if err != nil {
@@ -1,6 +1,7 @@
 package gcp
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -95,3 +96,62 @@ func versionToTemplateID(version string) string {
 	// Otherwise, return the version as is
 	return version
 }
+
+// loadJwksFile reads the JSON Web Key Set stored in the given file and checks that it is a valid
+// set of public keys, returning its content.
+func loadJwksFile(path string) (string, error) {
+	// #nosec G304
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read JWKS file '%s'", path)
+	}
+	err = validateJwks(data)
+	if err != nil {
+		return "", errors.Wrapf(err, "JWKS file '%s' isn't valid", path)
+	}
+	return string(data), nil
+}
+
+// validateJwks checks that the given data is a JSON Web Key Set containing at least one key, and
+// that none of the keys contains private key material.
+func validateJwks(data []byte) error {
+	var jwks struct {
+		Keys []map[string]interface{} `json:"keys"`
+	}
+	err := json.Unmarshal(data, &jwks)
+	if err != nil {
+		return err
+	}
+	if len(jwks.Keys) == 0 {
+		return fmt.Errorf("it doesn't contain any key")
+	}
+	for i, key := range jwks.Keys {
+		kty, _ := key["kty"].(string)
+		if kty == "" {
+			return fmt.Errorf("key %d doesn't have a 'kty' parameter", i)
+		}
+		if _, ok := key["d"]; ok {
+			return fmt.Errorf("key %d contains private key material", i)
+		}
+	}
+	return nil
+}
+
+// withJwks returns a copy of the given wif-config where the JWKS of the identity provider has
+// been replaced with the given one.
+func withJwks(wifConfig *cmv1.WifConfig, jwks string) (*cmv1.WifConfig, error) {
+	pool := wifConfig.Gcp().WorkloadIdentityPool()
+	return cmv1.NewWifConfig().
+		Copy(wifConfig).
+		Gcp(cmv1.NewWifGcp().
+			Copy(wifConfig.Gcp()).
+			WorkloadIdentityPool(cmv1.NewWifPool().
+				Copy(pool).
+				IdentityProvider(cmv1.NewWifIdentityProvider().
+					Copy(pool.IdentityProvider()).
+					Jwks(jwks),
+				),
+			),
+		).
+		Build()
+}
@@ -0,0 +1,50 @@
+package gcp
+
+import (
+	"testing"
+)
+
+func TestValidateJwks(t *testing.T) {
+	tests := []struct {
+		name  string
+		jwks  string
+		valid bool
+	}{
+		{
+			name:  "Public RSA key",
+			jwks:  `{"keys":[{"kty":"RSA","kid":"mykey","n":"AQAB","e":"AQAB"}]}`,
+			valid: true,
+		},
+		{
+			name:  "Not JSON",
+			jwks:  `keys`,
+			valid: false,
+		},
+		{
+			name:  "No keys",
+			jwks:  `{"keys":[]}`,
+			valid: false,
+		},
+		{
+			name:  "Key without type",
+			jwks:  `{"keys":[{"kid":"mykey"}]}`,
+			valid: false,
+		},
+		{
+			name:  "Private key",
+			jwks:  `{"keys":[{"kty":"RSA","n":"AQAB","e":"AQAB","d":"AQAB"}]}`,
+			valid: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateJwks([]byte(test.jwks))
+			if test.valid && err != nil {
+				t.Errorf("expected JWKS to be valid, got error: %v", err)
+			}
+			if !test.valid && err == nil {
+				t.Errorf("expected JWKS to be invalid")
+			}
+		})
+	}
+}
@@ -121,5 +121,5 @@ func listWorkloadIdentityConfigurationCmd(cmd *cobra.Command, argv []string) err
 		}
 		index++
 	}
-	return nil
+	return table.Flush()
 }
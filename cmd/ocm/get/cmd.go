@@ -18,6 +18,8 @@ package get
 
 import (
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -30,9 +32,16 @@ import (
 )
 
 var args struct {
-	parameter []string
-	header    []string
-	single    bool
+	parameter     []string
+	header        []string
+	outFile       string
+	single        bool
+	compact       bool
+	flatten       bool
+	raw           bool
+	jq            string
+	verboseTiming bool
+	retry         arguments.RetryOptions
 }
 
 var Cmd = &cobra.Command{
@@ -47,15 +56,83 @@ func init() {
 	fs := Cmd.Flags()
 	arguments.AddParameterFlag(fs, &args.parameter)
 	arguments.AddHeaderFlag(fs, &args.header)
+	arguments.AddVerboseTimingFlag(fs, &args.verboseTiming)
+	arguments.AddRetryFlags(fs, &args.retry)
+	arguments.AddOutputFileFlag(fs, &args.outFile)
 	fs.BoolVar(
 		&args.single,
 		"single",
 		false,
 		"Return the output as a single line.",
 	)
+	fs.BoolVar(
+		&args.compact,
+		"compact",
+		false,
+		"Return the response body as minified JSON in a single line, without colors. Useful "+
+			"to append responses to log files.",
+	)
+	fs.BoolVar(
+		&args.flatten,
+		"flatten",
+		false,
+		"Return each value of the response body in a separate 'path = value' line, for "+
+			"example 'nodes.compute = 3'. Useful to grep large responses. The paths can be "+
+			"used with the --jq flag.",
+	)
+	fs.BoolVar(
+		&args.raw,
+		"raw",
+		false,
+		"Return the response body exactly as it was received, without indenting it.",
+	)
+	fs.StringVar(
+		&args.jq,
+		"jq",
+		"",
+		"Return only the value in the given path of the response body, for example "+
+			"'.items[0].id'. Strings, numbers and booleans are returned as text, objects "+
+			"and arrays as JSON.",
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) error {
+	if args.raw && args.single {
+		return fmt.Errorf("Flags --raw and --single can't be used at the same time")
+	}
+	if args.raw && args.jq != "" {
+		return fmt.Errorf("Flags --raw and --jq can't be used at the same time")
+	}
+	if args.compact && args.raw {
+		return fmt.Errorf("Flags --compact and --raw can't be used at the same time")
+	}
+	if args.compact && args.single {
+		return fmt.Errorf("Flags --compact and --single can't be used at the same time")
+	}
+	if args.flatten {
+		others := []struct {
+			name string
+			used bool
+		}{
+			{name: "raw", used: args.raw},
+			{name: "single", used: args.single},
+			{name: "compact", used: args.compact},
+			{name: "jq", used: args.jq != ""},
+		}
+		for _, other := range others {
+			if other.used {
+				return fmt.Errorf(
+					"Flags --flatten and --%s can't be used at the same time",
+					other.name,
+				)
+			}
+		}
+	}
+	err := arguments.CheckRetryFlags(cmd.Flags(), &args.retry, http.MethodGet)
+	if err != nil {
+		return err
+	}
+
 	path, err := urls.Expand(argv)
 	if err != nil {
 		return fmt.Errorf("Could not create URI: %v", err)
@@ -71,7 +148,7 @@ func run(cmd *cobra.Command, argv []string) error {
 	}
 
 	// Create the client for the OCM API:
-	connection, err := ocm.NewConnection().Build()
+	connection, err := arguments.ApplyRetryFlags(ocm.NewConnection(), args.retry).Build()
 	if err != nil {
 		return err
 	}
@@ -84,33 +161,54 @@ func run(cmd *cobra.Command, argv []string) error {
 		fmt.Fprintf(os.Stderr, "Can't parse path '%s': %v\n", path, err)
 		os.Exit(1)
 	}
-	arguments.ApplyParameterFlag(request, args.parameter)
-	arguments.ApplyHeaderFlag(request, args.header)
+	err = arguments.ApplyParameterFlag(request, args.parameter)
+	if err != nil {
+		return err
+	}
+	err = arguments.ApplyHeaderFlag(request, args.header)
+	if err != nil {
+		return err
+	}
 
 	// Send the request:
-	response, err := request.Send()
+	response, err := arguments.SendRequestWithRetry(request, args.verboseTiming, args.retry)
 	if err != nil {
 		return fmt.Errorf("Can't send request: %v", err)
 	}
 	status := response.Status()
 	body := response.Bytes()
-	if status < 400 {
-		if args.single {
-			err = dump.Single(os.Stdout, body)
-		} else {
-			err = dump.Pretty(os.Stdout, body)
+
+	// Write the body of successful responses to the output file, if requested:
+	stdout := os.Stdout
+	if status < 400 && args.outFile != "" {
+		stdout, err = dump.CreateFile(args.outFile)
+		if err != nil {
+			return fmt.Errorf("Can't create output file: %v", err)
 		}
-	} else {
-		if args.single {
-			err = dump.Single(os.Stderr, body)
-		} else {
-			err = dump.Pretty(os.Stderr, body)
+	}
+
+	if status < 400 && args.jq != "" {
+		err = dump.Dig(stdout, body, args.jq, args.single || args.compact)
+		if err != nil {
+			return err
 		}
+	} else if status < 400 {
+		err = dumpBody(stdout, body)
+	} else {
+		err = dumpBody(os.Stderr, body)
 	}
 	if err != nil {
 		return fmt.Errorf("Can't print body: %v", err)
 	}
 
+	// Check that the output file has been written completely:
+	if stdout != os.Stdout {
+		err = stdout.Close()
+		if err != nil {
+			return fmt.Errorf("Can't close output file: %v", err)
+		}
+	}
+
 	// Save the configuration:
 	cfg.AccessToken, cfg.RefreshToken, err = connection.Tokens()
 	if err != nil {
@@ -128,3 +226,20 @@ func run(cmd *cobra.Command, argv []string) error {
 
 	return nil
 }
+
+// dumpBody writes the response body to the given stream in the format selected with the command
+// line flags.
+func dumpBody(stream io.Writer, body []byte) error {
+	switch {
+	case args.raw:
+		return dump.Raw(stream, body)
+	case args.single:
+		return dump.Single(stream, body)
+	case args.compact:
+		return dump.Compact(stream, body)
+	case args.flatten:
+		return dump.Flatten(stream, body)
+	default:
+		return dump.Pretty(stream, body)
+	}
+}
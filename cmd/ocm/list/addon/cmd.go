@@ -17,11 +17,16 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"time"
 
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
 	c "github.com/openshift-online/ocm-cli/pkg/cluster"
 	"github.com/openshift-online/ocm-cli/pkg/config"
+	"github.com/openshift-online/ocm-cli/pkg/dump"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
 	"github.com/openshift-online/ocm-cli/pkg/output"
+	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 )
@@ -29,6 +34,8 @@ import (
 var args struct {
 	clusterKey string
 	columns    string
+	watch      time.Duration
+	count      bool
 }
 
 var Cmd = &cobra.Command{
@@ -37,7 +44,9 @@ var Cmd = &cobra.Command{
 	Short:   "List add-on installations",
 	Long:    "List add-ons installed on a cluster.",
 	Example: `  # List all add-on installations on a cluster named "mycluster"
-  ocm list addons --cluster=mycluster`,
+  ocm list addons --cluster=mycluster
+  # Follow the installation of the add-ons, listing them again every 10 seconds
+  ocm list addons --cluster=mycluster --watch=10s`,
 	Args: cobra.NoArgs,
 	RunE: run,
 }
@@ -57,6 +66,8 @@ func init() {
 		"id, name, state",
 		"Comma separated list of columns to display.",
 	)
+	arguments.AddWatchFlag(fs, &args.watch)
+	arguments.AddCountFlag(fs, &args.count)
 
 	//nolint:gosec
 	Cmd.MarkFlagRequired("cluster")
@@ -66,6 +77,20 @@ func run(cmd *cobra.Command, argv []string) error {
 	// Create a context:
 	ctx := context.Background()
 
+	// Check the output format:
+	format, err := output.SelectedFormat()
+	if err != nil {
+		return err
+	}
+	err = arguments.CheckWatchFlag(args.watch, format)
+	if err != nil {
+		return err
+	}
+	err = arguments.CheckCountFlag(args.count, format)
+	if err != nil {
+		return err
+	}
+
 	// Load the configuration:
 	cfg, err := config.Load()
 	if err != nil {
@@ -79,26 +104,21 @@ func run(cmd *cobra.Command, argv []string) error {
 	}
 	defer connection.Close()
 
-	// Create the output printer:
+	// Create the output printer. The pager isn't used when watching, as it would wait for the
+	// user to quit it before each refresh:
+	pager := cfg.Pager
+	if args.watch != 0 {
+		pager = ""
+	}
 	printer, err := output.NewPrinter().
 		Writer(os.Stdout).
-		Pager(cfg.Pager).
+		Pager(pager).
 		Build(ctx)
 	if err != nil {
 		return err
 	}
 	defer printer.Close()
 
-	// Create the output table:
-	table, err := printer.NewTable().
-		Name("addons").
-		Columns(args.columns).
-		Build(ctx)
-	if err != nil {
-		return err
-	}
-	defer table.Close()
-
 	// Check that the cluster key (name, identifier or external identifier) given by the user
 	// is reasonably safe so that there is no risk of SQL injection:
 	clusterKey := args.clusterKey
@@ -119,16 +139,49 @@ func run(cmd *cobra.Command, argv []string) error {
 		return fmt.Errorf("Cluster '%s' is not yet ready", clusterKey)
 	}
 
+	// When watching, stop refreshing when the user presses Ctrl+C:
+	if args.watch != 0 {
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+	}
+
+	return printer.Watch(ctx, args.watch, func() error {
+		return listAddOns(ctx, printer, connection, cluster, format)
+	})
+}
+
+// listAddOns fetches the add-ons of the cluster and writes them to the printer, using a new table
+// each time it is called, so that the widths of the columns are learned from the current data.
+func listAddOns(ctx context.Context, printer *output.Printer, connection *sdk.Connection,
+	cluster *cmv1.Cluster, format output.Format) error {
+	clusterKey := args.clusterKey
 	clusterAddOns, err := c.GetClusterAddOns(connection, cluster.ID())
 	if err != nil {
 		return fmt.Errorf("Failed to get add-ons for cluster '%s': %v", clusterKey, err)
 	}
 
+	// Write the add-ons as they are if a structured output format has been requested:
+	if format.IsStructured() {
+		return dump.List(printer, format, clusterAddOns, dump.Items[*c.AddOnItem])
+	}
+
 	if len(clusterAddOns) == 0 {
-		fmt.Printf("There are no add-ons installed on cluster '%s'", clusterKey)
+		fmt.Fprintf(printer, "There are no add-ons installed on cluster '%s'", clusterKey)
 		return nil
 	}
 
+	// Create the output table:
+	table, err := printer.NewTable().
+		Name("addons").
+		Columns(args.columns).
+		Wide(format.IsWide()).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer table.Close()
+
 	// Write the column headers:
 	err = table.WriteHeaders()
 	if err != nil {
@@ -146,5 +199,12 @@ func run(cmd *cobra.Command, argv []string) error {
 		return err
 	}
 
-	return nil
+	if args.count {
+		err = table.TotalFooter(len(clusterAddOns))
+		if err != nil {
+			return err
+		}
+	}
+
+	return table.Flush()
 }
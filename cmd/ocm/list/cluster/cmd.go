@@ -20,24 +20,33 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"time"
 
 	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift-online/ocm-cli/pkg/arguments"
+	c "github.com/openshift-online/ocm-cli/pkg/cluster"
 	"github.com/openshift-online/ocm-cli/pkg/config"
+	"github.com/openshift-online/ocm-cli/pkg/dump"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
 	"github.com/openshift-online/ocm-cli/pkg/output"
 )
 
 var args struct {
-	parameter []string
-	header    []string
-	managed   bool
-	noHeaders bool
-	columns   string
-	padding   int
+	parameter   []string
+	header      []string
+	where       []string
+	managed     bool
+	mine        bool
+	noHeaders   bool
+	columns     string
+	columnsFrom string
+	padding     int
+	watch       time.Duration
+	count       bool
 }
 
 // Cmd Constant:
@@ -46,20 +55,37 @@ var Cmd = &cobra.Command{
 	Aliases: []string{"cluster"},
 	Short:   "List clusters",
 	Long:    "List clusters, optionally filtering by substring of ID or Name",
-	Args:    cobra.RangeArgs(0, 1),
-	RunE:    run,
+	Example: `  # List the AWS clusters that are ready
+  ocm list clusters --where cloud_provider.id=aws --where state=ready
+  # List the clusters using a raw search query
+  ocm list clusters --parameter search="name like 'my-%'"
+  # List the clusters created by the current user
+  ocm list clusters --mine
+  # List the clusters displaying the fields shown by the describe command
+  ocm list clusters --columns-from describe
+  # List the clusters again every 30 seconds, till interrupted
+  ocm list clusters --watch=30s`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: run,
 }
 
 func init() {
 	fs := Cmd.Flags()
 	arguments.AddParameterFlag(fs, &args.parameter)
 	arguments.AddHeaderFlag(fs, &args.header)
+	arguments.AddWhereFlag(fs, &args.where)
 	fs.BoolVar(
 		&args.managed,
 		"managed",
 		false,
 		"Filter managed/unmanaged clusters",
 	)
+	fs.BoolVar(
+		&args.mine,
+		"mine",
+		false,
+		"Show only the clusters created by the current user.",
+	)
 	_ = fs.Bool(
 		"step",
 		true,
@@ -79,18 +105,63 @@ func init() {
 		"id, name, api.url, openshift_version, product.id, hypershift.enabled, cloud_provider.id, region.id, state",
 		"Specify which columns to display separated by commas, path is based on Cluster struct",
 	)
+	fs.StringVar(
+		&args.columnsFrom,
+		"columns-from",
+		"",
+		fmt.Sprintf(
+			"Display the same fields than another command instead of the given columns. "+
+				"The only supported value is '%s'.",
+			columnsFromDescribe,
+		),
+	)
 	fs.IntVar(
 		&args.padding,
 		"padding",
 		-1,
 		"Change all column sizes.",
 	)
+	arguments.AddWatchFlag(fs, &args.watch)
+	arguments.AddCountFlag(fs, &args.count)
 }
 
+// columnsFromDescribe is the value of the `--columns-from` flag that selects the fields displayed
+// by the describe command.
+const columnsFromDescribe = "describe"
+
 func run(cmd *cobra.Command, argv []string) error {
 	// Create a context:
 	ctx := context.Background()
 
+	// Check the source of the columns:
+	columns := args.columns
+	if args.columnsFrom != "" {
+		if cmd.Flags().Changed("columns") {
+			return fmt.Errorf("Flags --columns and --columns-from can't be used at the same time")
+		}
+		if args.columnsFrom != columnsFromDescribe {
+			return fmt.Errorf(
+				"Invalid --columns-from '%s', the only supported value is '%s'",
+				args.columnsFrom, columnsFromDescribe,
+			)
+		}
+		columns = c.DescribeColumns()
+	}
+
+	// Check the output format:
+	format, err := output.SelectedFormat()
+	if err != nil {
+		return err
+	}
+	err = arguments.CheckWatchFlag(args.watch, format)
+	if err != nil {
+		return err
+	}
+	err = arguments.CheckCountFlag(args.count, format)
+	if err != nil {
+		return err
+	}
+
 	// Load the configuration:
 	cfg, err := config.Load()
 	if err != nil {
@@ -104,26 +175,21 @@ func run(cmd *cobra.Command, argv []string) error {
 	}
 	defer connection.Close()
 
-	// Create the output printer:
+	// Create the output printer. The pager isn't used when watching, as it would wait for the
+	// user to quit it before each refresh:
+	pager := cfg.Pager
+	if args.watch != 0 {
+		pager = ""
+	}
 	printer, err := output.NewPrinter().
 		Writer(os.Stdout).
-		Pager(cfg.Pager).
+		Pager(pager).
 		Build(ctx)
 	if err != nil {
 		return err
 	}
 	defer printer.Close()
 
-	// Create the output table:
-	table, err := printer.NewTable().
-		Name("clusters").
-		Columns(args.columns).
-		Build(ctx)
-	if err != nil {
-		return err
-	}
-	defer table.Close()
-
 	// This will contain the terms used to construct the search query:
 	var searchTerms []string
 
@@ -145,6 +211,23 @@ func run(cmd *cobra.Command, argv []string) error {
 		searchTerms = append(searchTerms, term)
 	}
 
+	// Add the search term for the `--mine` flag:
+	if args.mine {
+		response, err := connection.AccountsMgmt().V1().CurrentAccount().Get().Send()
+		if err != nil {
+			return fmt.Errorf("Can't retrieve current account: %v", err)
+		}
+		term := fmt.Sprintf("creator.id = '%s'", response.Body().ID())
+		searchTerms = append(searchTerms, term)
+	}
+
+	// Add the search terms for the `--where` flag:
+	whereTerms, err := arguments.ParseWhereFlag(args.where)
+	if err != nil {
+		return err
+	}
+	searchTerms = append(searchTerms, whereTerms...)
+
 	// If the `search` parameter has been specified with the `--parameter` flag then we have to
 	// remove it and add the values to the list of search terms, otherwise we will be sending
 	// multiple `search` query parameters and the server will ignore all but one of them. Note
@@ -173,16 +256,54 @@ func run(cmd *cobra.Command, argv []string) error {
 	// Join all the search terms using the `and` connective:
 	searchQuery := strings.Join(searchTerms, " and ")
 
+	// Create the request. Note that this request can be created outside of the loop and used
+	// for all the iterations just changing the values of the `size` and `page` parameters.
+	request := connection.ClustersMgmt().V1().Clusters().List().Search(searchQuery)
+	err = arguments.ApplyParameterFlag(request, args.parameter)
+	if err != nil {
+		return err
+	}
+	err = arguments.ApplyHeaderFlag(request, args.header)
+	if err != nil {
+		return err
+	}
+
+	// When watching, stop refreshing when the user presses Ctrl+C:
+	if args.watch != 0 {
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+	}
+
+	return printer.Watch(ctx, args.watch, func() error {
+		return listClusters(ctx, printer, request, format, columns)
+	})
+}
+
+// listClusters fetches all the pages of clusters and writes them to the printer, using a new table
+// each time it is called, so that the widths of the columns are learned from the current data.
+func listClusters(ctx context.Context, printer *output.Printer, request *v1.ClustersListRequest,
+	format output.Format, columns string) error {
+	// Create the output table:
+	table, err := printer.NewTable().
+		Name("clusters").
+		Columns(columns).
+		Wide(format.IsWide()).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer table.Close()
+
 	// Unless noHeaders set, print header row:
-	if !args.noHeaders {
+	if !args.noHeaders && !format.IsStructured() {
 		table.WriteHeaders()
 	}
 
-	// Create the request. Note that this request can be created outside of the loop and used
-	// for all the iterations just changing the values of the `size` and `page` parameters.
-	request := connection.ClustersMgmt().V1().Clusters().List().Search(searchQuery)
-	arguments.ApplyParameterFlag(request, args.parameter)
-	arguments.ApplyHeaderFlag(request, args.header)
+	// When a structured output format has been requested the items are collected and written
+	// at the end, as all of them are part of the same document, unless the output is streamed:
+	var clusters []*v1.Cluster
+	count := 0
 
 	// Send the request till we receive a page with less items than requested:
 	size := 100
@@ -198,11 +319,26 @@ func run(cmd *cobra.Command, argv []string) error {
 
 		// Display the items of the fetched page:
 		response.Items().Each(func(cluster *v1.Cluster) bool {
+			if format.IsStructured() {
+				clusters = append(clusters, cluster)
+				return true
+			}
+			count++
 			err = table.WriteObject(cluster)
 			return err == nil
 		})
 		if err != nil {
-			break
+			return err
+		}
+
+		// When the output is streamed the items of the page are written right away, so that
+		// they don't need to be kept in memory till the last page is fetched:
+		if format.IsStreamed() {
+			err = dump.List(printer, format, clusters, v1.MarshalClusterList)
+			if err != nil {
+				return err
+			}
+			clusters = nil
 		}
 
 		// If the number of fetched items is less than requested, then this was the last
@@ -213,5 +349,16 @@ func run(cmd *cobra.Command, argv []string) error {
 		index++
 	}
 
-	return nil
+	if format.IsStructured() {
+		return dump.List(printer, format, clusters, v1.MarshalClusterList)
+	}
+
+	if args.count {
+		err = table.TotalFooter(count)
+		if err != nil {
+			return err
+		}
+	}
+
+	return table.Flush()
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/openshift-online/ocm-cli/cmd/ocm/list/idp"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/list/ingress"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/list/machinepool"
+	"github.com/openshift-online/ocm-cli/cmd/ocm/list/machinetype"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/list/org"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/list/quota"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/list/region"
@@ -26,6 +27,7 @@ import (
 	"github.com/openshift-online/ocm-cli/cmd/ocm/list/upgradepolicy"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/list/user"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/list/version"
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
 	"github.com/spf13/cobra"
 )
 
@@ -36,12 +38,17 @@ var Cmd = &cobra.Command{
 }
 
 func init() {
+	// The output format is shared by all the list commands, each of them writes its
+	// results as a table or as the list of objects returned by the API:
+	arguments.AddOutputFlag(Cmd.PersistentFlags())
+
 	Cmd.AddCommand(addon.Cmd)
 	Cmd.AddCommand(cluster.Cmd)
 	Cmd.AddCommand(idp.Cmd)
 	Cmd.AddCommand(ingress.Cmd)
 	Cmd.AddCommand(org.Cmd)
 	Cmd.AddCommand(machinepool.Cmd)
+	Cmd.AddCommand(machinetype.Cmd)
 	Cmd.AddCommand(quota.Cmd)
 	Cmd.AddCommand(region.Cmd)
 	Cmd.AddCommand(upgradepolicy.Cmd)
@@ -18,8 +18,10 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
 	c "github.com/openshift-online/ocm-cli/pkg/cluster"
 	"github.com/openshift-online/ocm-cli/pkg/config"
+	"github.com/openshift-online/ocm-cli/pkg/dump"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
 	"github.com/openshift-online/ocm-cli/pkg/output"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -30,6 +32,7 @@ import (
 var args struct {
 	clusterKey string
 	columns    string
+	count      bool
 }
 
 var Cmd = &cobra.Command{
@@ -58,6 +61,7 @@ func init() {
 		"name, type, auth_url",
 		"Comma separated list of columns to display.",
 	)
+	arguments.AddCountFlag(fs, &args.count)
 
 	//nolint:gosec
 	Cmd.MarkFlagRequired("cluster")
@@ -67,6 +71,16 @@ func run(cmd *cobra.Command, argv []string) error {
 	// Create a context:
 	ctx := context.Background()
 
+	// Check the output format:
+	format, err := output.SelectedFormat()
+	if err != nil {
+		return err
+	}
+	err = arguments.CheckCountFlag(args.count, format)
+	if err != nil {
+		return err
+	}
+
 	// Load the configuration:
 	cfg, err := config.Load()
 	if err != nil {
@@ -118,10 +132,17 @@ func run(cmd *cobra.Command, argv []string) error {
 		return fmt.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
 	}
 
+	// Write the identity providers as they are if a structured output format has been
+	// requested:
+	if format.IsStructured() {
+		return dump.List(printer, format, idps, cmv1.MarshalIdentityProviderList)
+	}
+
 	// Create the output table:
 	table, err := printer.NewTable().
 		Name("idps").
 		Columns(args.columns).
+		Wide(format.IsWide()).
 		Value("type", getType).
 		Value("auth_url", func(idp *cmv1.IdentityProvider) string {
 			return getAuthURL(cluster, idp.Name())
@@ -149,7 +170,14 @@ func run(cmd *cobra.Command, argv []string) error {
 		return err
 	}
 
-	return nil
+	if args.count {
+		err = table.TotalFooter(len(idps))
+		if err != nil {
+			return err
+		}
+	}
+
+	return table.Flush()
 }
 
 func getType(idp *cmv1.IdentityProvider) string {
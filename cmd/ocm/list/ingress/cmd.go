@@ -24,6 +24,7 @@ import (
 
 	c "github.com/openshift-online/ocm-cli/pkg/cluster"
 	"github.com/openshift-online/ocm-cli/pkg/config"
+	"github.com/openshift-online/ocm-cli/pkg/dump"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
 	"github.com/openshift-online/ocm-cli/pkg/output"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -65,6 +66,12 @@ func run(cmd *cobra.Command, argv []string) error {
 	// Create a context:
 	ctx := context.Background()
 
+	// Check the output format:
+	format, err := output.SelectedFormat()
+	if err != nil {
+		return err
+	}
+
 	// Load the configuration:
 	cfg, err := config.Load()
 	if err != nil {
@@ -116,6 +123,11 @@ func run(cmd *cobra.Command, argv []string) error {
 		return fmt.Errorf("Failed to get ingresses for cluster '%s': %v", clusterKey, err)
 	}
 
+	// Write the ingresses as they are if a structured output format has been requested:
+	if format.IsStructured() {
+		return dump.List(printer, format, ingresses, cmv1.MarshalIngressList)
+	}
+
 	// Write the endpoints:
 	endpointsTable, err := printer.NewTable().
 		Name("endpoints").
@@ -17,13 +17,19 @@ limitations under the License.
 package machinepool
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
 	c "github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/dump"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	"github.com/openshift-online/ocm-cli/pkg/output"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 
 	"github.com/spf13/cobra"
@@ -31,6 +37,7 @@ import (
 
 var args struct {
 	clusterKey string
+	watch      time.Duration
 }
 
 var Cmd = &cobra.Command{
@@ -39,7 +46,10 @@ var Cmd = &cobra.Command{
 	Short:   "List cluster machine pools",
 	Long:    "List machine pools for a cluster.",
 	Example: `  # List all machine pools on a cluster named "mycluster"
-  ocm list machine-pools --cluster=mycluster`,
+  ocm list machine-pools --cluster=mycluster
+
+  # List the machine pools again every 30 seconds, till interrupted
+  ocm list machine-pools --cluster=mycluster --watch=30s`,
 	Args: cobra.NoArgs,
 	RunE: run,
 }
@@ -54,11 +64,24 @@ func init() {
 		"",
 		"Name or ID or external_id of the cluster to list the machine pools of (required).",
 	)
+	arguments.AddWatchFlag(flags, &args.watch)
 	//nolint:gosec
 	Cmd.MarkFlagRequired("cluster")
 }
 
 func run(cmd *cobra.Command, argv []string) error {
+	// Create a context:
+	ctx := context.Background()
+
+	// Check the output format:
+	format, err := output.SelectedFormat()
+	if err != nil {
+		return err
+	}
+	err = arguments.CheckWatchFlag(args.watch, format)
+	if err != nil {
+		return err
+	}
 
 	// Check that the cluster key (name, identifier or external identifier) given by the user
 	// is reasonably safe so that there is no risk of SQL injection:
@@ -90,13 +113,42 @@ func run(cmd *cobra.Command, argv []string) error {
 		return fmt.Errorf("Cluster '%s' is not yet ready", clusterKey)
 	}
 
-	machinePools, err := c.GetMachinePools(clusterCollection, cluster.ID())
+	// Create the output printer:
+	printer, err := output.NewPrinter().
+		Writer(os.Stdout).
+		Build(ctx)
 	if err != nil {
 		return err
 	}
+	defer printer.Close()
+
+	// When watching, stop refreshing when the user presses Ctrl+C:
+	if args.watch != 0 {
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+	}
+
+	return printer.Watch(ctx, args.watch, func() error {
+		return listMachinePools(printer, clusterCollection, cluster.ID(), format)
+	})
+}
+
+// listMachinePools fetches the machine pools of the cluster and writes them to the printer.
+func listMachinePools(printer *output.Printer, clusterCollection *cmv1.ClustersClient,
+	clusterID string, format output.Format) error {
+	machinePools, err := c.GetMachinePools(clusterCollection, clusterID)
+	if err != nil {
+		return err
+	}
+
+	// Write the machine pools as they are if a structured output format has been requested:
+	if format.IsStructured() {
+		return dump.List(printer, format, machinePools, cmv1.MarshalMachinePoolList)
+	}
 
 	// Create the writer that will be used to print the tabulated results:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	writer := tabwriter.NewWriter(printer, 0, 0, 2, ' ', 0)
 
 	fmt.Fprintf(writer, "ID\tAUTOSCALING\tREPLICAS\tINSTANCE TYPE\tLABELS\t\tTAINTS\t\tAVAILABILITY ZONES\tSG IDs\n")
 
@@ -112,9 +164,7 @@ func run(cmd *cobra.Command, argv []string) error {
 			printAdditionalSecurityGroups(machinePool.AWS().AdditionalSecurityGroupIds()),
 		)
 	}
-	writer.Flush()
-
-	return nil
+	return writer.Flush()
 }
 
 func printAutoscaling(autoscaling *cmv1.MachinePoolAutoscaling) string {
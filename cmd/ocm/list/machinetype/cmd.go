@@ -0,0 +1,136 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinetype
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/openshift-online/ocm-cli/pkg/dump"
+	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	"github.com/openshift-online/ocm-cli/pkg/output"
+	"github.com/openshift-online/ocm-cli/pkg/provider"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+)
+
+var args struct {
+	provider string
+	ccs      bool
+	category string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "machine-types --provider=CLOUD_PROVIDER",
+	Aliases: []string{"machine-type", "machinetypes", "machinetype"},
+	Short:   "List machine types",
+	Long:    "List the machine types of a cloud provider that can be used for compute nodes.",
+	Example: `  # List the memory optimized machine types available on AWS for CCS clusters
+  ocm list machine-types --provider=aws --ccs --category=memory_optimized`,
+	Args: cobra.NoArgs,
+	RunE: run,
+}
+
+func init() {
+	fs := Cmd.Flags()
+	fs.StringVar(
+		&args.provider,
+		"provider",
+		"",
+		"Lists the machine types of the specific cloud provider",
+	)
+	//nolint:gosec
+	Cmd.MarkFlagRequired("provider")
+	fs.BoolVar(
+		&args.ccs,
+		"ccs",
+		false,
+		"Include the machine types that are only available to CCS clusters",
+	)
+	fs.StringVar(
+		&args.category,
+		"category",
+		"",
+		fmt.Sprintf("Lists only the machine types of this category, one of: %s",
+			strings.Join(provider.MachineTypeCategories, ", ")),
+	)
+	Cmd.RegisterFlagCompletionFunc("category", categoryCompletion)
+}
+
+func run(cmd *cobra.Command, argv []string) error {
+	// Check the output format:
+	format, err := output.SelectedFormat()
+	if err != nil {
+		return err
+	}
+
+	err = provider.ValidateMachineTypeCategory(args.category)
+	if err != nil {
+		return err
+	}
+
+	connection, err := ocm.NewConnection().Build()
+	if err != nil {
+		return fmt.Errorf("Failed to create OCM connection: %v", err)
+	}
+	defer connection.Close()
+
+	machineTypes, err := provider.GetMachineTypes(connection.ClustersMgmt().V1(), args.provider, args.ccs,
+		args.category)
+	if err != nil {
+		return err
+	}
+
+	// Write the machine types as they are if a structured output format has been requested:
+	if format.IsStructured() {
+		return dump.List(os.Stdout, format, machineTypes, cmv1.MarshalMachineTypeList)
+	}
+
+	// Create the writer that will be used to print the tabulated results:
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(writer, "ID\tCATEGORY\tCPU\tMEMORY\tCCS ONLY\n")
+	for _, machineType := range machineTypes {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%v\n",
+			machineType.ID(),
+			machineType.Category(),
+			printValue(machineType.CPU()),
+			printValue(machineType.Memory()),
+			machineType.CCSOnly(),
+		)
+	}
+
+	return writer.Flush()
+}
+
+func categoryCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return provider.MachineTypeCategories, cobra.ShellCompDirectiveDefault
+}
+
+// printValue formats the CPU or memory of a machine type. Memory is returned by the API in bytes,
+// so it is converted to GiB.
+func printValue(value *cmv1.Value) string {
+	if value == nil {
+		return ""
+	}
+	if value.Unit() == "B" {
+		return fmt.Sprintf("%g GiB", value.Value()/(1<<30))
+	}
+	return fmt.Sprintf("%g %s", value.Value(), value.Unit())
+}
@@ -26,14 +26,17 @@ import (
 
 	"github.com/openshift-online/ocm-cli/pkg/arguments"
 	"github.com/openshift-online/ocm-cli/pkg/config"
+	"github.com/openshift-online/ocm-cli/pkg/dump"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
 	"github.com/openshift-online/ocm-cli/pkg/output"
 )
 
 var args struct {
 	parameter []string
+	where     []string
 	header    []string
 	columns   string
+	count     bool
 }
 
 var Cmd = &cobra.Command{
@@ -49,18 +52,36 @@ func init() {
 	fs := Cmd.Flags()
 	arguments.AddParameterFlag(fs, &args.parameter)
 	arguments.AddHeaderFlag(fs, &args.header)
+	arguments.AddWhereFlag(fs, &args.where)
 	fs.StringVar(
 		&args.columns,
 		"columns",
 		"id, name",
 		"Comma separated list of columns to display.",
 	)
+	arguments.AddCountFlag(fs, &args.count)
 }
 
 func run(cmd *cobra.Command, argv []string) error {
 	// Create a context:
 	ctx := context.Background()
 
+	// Check the output format:
+	format, err := output.SelectedFormat()
+	if err != nil {
+		return err
+	}
+	err = arguments.CheckCountFlag(args.count, format)
+	if err != nil {
+		return err
+	}
+
+	// Add the search terms for the `--where` flag:
+	parameters, err := arguments.MergeWhereFlag(args.parameter, args.where)
+	if err != nil {
+		return err
+	}
+
 	// Load the configuration:
 	cfg, err := config.Load()
 	if err != nil {
@@ -88,6 +109,7 @@ func run(cmd *cobra.Command, argv []string) error {
 	table, err := printer.NewTable().
 		Name("orgs").
 		Columns(args.columns).
+		Wide(format.IsWide()).
 		Build(ctx)
 	if err != nil {
 		return err
@@ -95,16 +117,29 @@ func run(cmd *cobra.Command, argv []string) error {
 	defer table.Close()
 
 	// Write the header row:
-	err = table.WriteHeaders()
-	if err != nil {
-		return err
+	if !format.IsStructured() {
+		err = table.WriteHeaders()
+		if err != nil {
+			return err
+		}
 	}
 
 	// Create the request. Note that this request can be created outside of the loop and used
 	// for all iterations just changing the values of the `size` and `page` parameters.
 	request := connection.AccountsMgmt().V1().Organizations().List()
-	arguments.ApplyParameterFlag(request, args.parameter)
-	arguments.ApplyHeaderFlag(request, args.header)
+	err = arguments.ApplyParameterFlag(request, parameters)
+	if err != nil {
+		return err
+	}
+	err = arguments.ApplyHeaderFlag(request, args.header)
+	if err != nil {
+		return err
+	}
+
+	// When a structured output format has been requested the items are collected and written
+	// at the end, as all of them are part of the same document, unless the output is streamed:
+	var orgs []*amv1.Organization
+	count := 0
 
 	// Send the request till we receive a page with less items than requested:
 	size := 100
@@ -120,11 +155,26 @@ func run(cmd *cobra.Command, argv []string) error {
 
 		// Display the items of the fetched page:
 		response.Items().Each(func(org *amv1.Organization) bool {
+			if format.IsStructured() {
+				orgs = append(orgs, org)
+				return true
+			}
+			count++
 			err = table.WriteObject(org)
 			return err == nil
 		})
 		if err != nil {
-			break
+			return err
+		}
+
+		// When the output is streamed the items of the page are written right away, so that
+		// they don't need to be kept in memory till the last page is fetched:
+		if format.IsStreamed() {
+			err = dump.List(printer, format, orgs, amv1.MarshalOrganizationList)
+			if err != nil {
+				return err
+			}
+			orgs = nil
 		}
 
 		// If the number of fetched items is less than requested, then this was the last
@@ -135,5 +185,16 @@ func run(cmd *cobra.Command, argv []string) error {
 		index++
 	}
 
-	return nil
+	if format.IsStructured() {
+		return dump.List(printer, format, orgs, amv1.MarshalOrganizationList)
+	}
+
+	if args.count {
+		err = table.TotalFooter(count)
+		if err != nil {
+			return err
+		}
+	}
+
+	return table.Flush()
 }
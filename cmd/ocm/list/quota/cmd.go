@@ -23,22 +23,32 @@ import (
 
 	"github.com/spf13/cobra"
 
+	acc_util "github.com/openshift-online/ocm-cli/pkg/account"
 	"github.com/openshift-online/ocm-cli/pkg/dump"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	"github.com/openshift-online/ocm-cli/pkg/output"
+	sdk "github.com/openshift-online/ocm-sdk-go"
 	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 )
 
 var args struct {
-	json bool
-	org  string
+	json         bool
+	org          string
+	resourceType string
 }
 
 var Cmd = &cobra.Command{
-	Use:   "quota",
-	Short: "Retrieve cluster quota information.",
-	Long:  "Retrieve cluster quota information of a specific organization.",
-	Args:  cobra.NoArgs,
-	RunE:  run,
+	Use:     "quota",
+	Aliases: []string{"quotas"},
+	Short:   "Retrieve cluster quota information.",
+	Long:    "Retrieve cluster quota information of a specific organization.",
+	Example: `  # List the quota of the organization of the current user
+  ocm list quota
+
+  # List only the quota that applies to add-ons, in JSON format
+  ocm list quota --resource-type add-on --output json`,
+	Args: cobra.NoArgs,
+	RunE: run,
 }
 
 func init() {
@@ -50,78 +60,94 @@ func init() {
 		false,
 		"Returns a list of resource quota objects in JSON.",
 	)
+	//nolint:gosec
+	flags.MarkDeprecated("json", "use '--output json' instead")
 	flags.StringVar(
 		&args.org,
 		"org",
 		"",
 		"Specify which organization to query information from. Default to local users organization.",
 	)
+	flags.StringVar(
+		&args.resourceType,
+		"resource-type",
+		"",
+		"Only list the quota that applies to resources of the given type, for example "+
+			"'cluster', 'add-on' or 'compute.node'.",
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) error {
+	// Check the output format:
+	format, err := output.SelectedFormat()
+	if err != nil {
+		return err
+	}
+	if args.json && args.resourceType != "" {
+		return fmt.Errorf(
+			"Flag --resource-type can't be used with --json, use '--output json' instead",
+		)
+	}
+
 	connection, err := ocm.NewConnection().Build()
 	if err != nil {
 		return fmt.Errorf("Failed to create OCM connection: %v", err)
 	}
 	defer connection.Close()
-	orgID := args.org
-
-	if args.org == "" {
-		// Get organization of current user:
-		userConn, err := connection.AccountsMgmt().V1().CurrentAccount().Get().
-			Send()
-		if err != nil {
-			return fmt.Errorf("Can't retrieve current user information: %v", err)
-		}
-		userOrg, _ := userConn.Body().GetOrganization()
-		orgID = userOrg.ID()
+
+	// The deprecated '--json' flag writes the resource quota document, as it always did, so that
+	// existing scripts that parse it keep working:
+	if args.json {
+		return dumpResourceQuota(connection, args.org)
 	}
 
-	orgCollection := connection.AccountsMgmt().V1().Organizations().Organization(orgID)
+	quotaCosts, err := acc_util.GetQuotaCosts(connection, args.org)
 	if err != nil {
-		return fmt.Errorf("Can't retrieve organization information: %v", err)
+		return err
+	}
+	if args.resourceType != "" {
+		quotaCosts = acc_util.FilterQuotaCostsByResourceType(quotaCosts, args.resourceType)
+	}
+
+	// Write the quota costs as they are if a structured output format has been requested:
+	if format.IsStructured() {
+		return dump.List(os.Stdout, format, quotaCosts, amv1.MarshalQuotaCostList)
 	}
 
-	quotaClient := orgCollection.QuotaCost()
-
-	if !args.json {
-		quotasListResponse, err := quotaClient.List().
-			Parameter("fetchRelatedResources", true).
-			Send()
-		if err != nil {
-			return fmt.Errorf("Failed to retrieve quota: %v", err)
-		}
-		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintf(
-			writer,
-			"CONSUMED\t\tALLOWED\t\tQUOTA ID\n")
-
-		quotasListResponse.Items().Each(func(quota *amv1.QuotaCost) bool {
-			fmt.Fprintf(writer, "%d\t\t%d\t\t%s\n", quota.Consumed(), quota.Allowed(), quota.QuotaID())
-			return true
-		})
-
-		err = writer.Flush()
-		if err != nil {
-			return nil
-		}
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(
+		writer,
+		"CONSUMED\t\tALLOWED\t\tQUOTA ID\n")
+
+	for _, quota := range quotaCosts {
+		fmt.Fprintf(writer, "%d\t\t%d\t\t%s\n", quota.Consumed(), quota.Allowed(), quota.QuotaID())
+	}
 
+	err = writer.Flush()
+	if err != nil {
 		return nil
 	}
 
-	// TODO: Do this without hard-code; could not find any marshall method
-	jsonDisplay, err := connection.Get().Path(
+	return nil
+}
+
+// dumpResourceQuota writes the resource quota of the given organization, or of the organization
+// of the current account, as it is returned by the API.
+func dumpResourceQuota(connection *sdk.Connection, orgID string) error {
+	orgID, err := acc_util.GetOrganizationID(connection, orgID)
+	if err != nil {
+		return err
+	}
+	response, err := connection.Get().Path(
 		fmt.Sprintf("/api/accounts_mgmt/v1/organizations/%s/resource_quota", orgID)).
 		Parameter("fetchRelatedResources", true).
 		Send()
 	if err != nil {
 		return fmt.Errorf("Failed to get resource quota: %v", err)
 	}
-	jsonDisplay.Bytes()
-	err = dump.Pretty(os.Stdout, jsonDisplay.Bytes())
+	err = dump.Pretty(os.Stdout, response.Bytes())
 	if err != nil {
 		return fmt.Errorf("Failed to display quota JSON: %v", err)
 	}
-
 	return nil
 }
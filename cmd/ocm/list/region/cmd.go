@@ -19,8 +19,11 @@ import (
 	"text/tabwriter"
 
 	"github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/dump"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	"github.com/openshift-online/ocm-cli/pkg/output"
 	"github.com/openshift-online/ocm-cli/pkg/provider"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 )
 
@@ -38,6 +41,8 @@ var Cmd = &cobra.Command{
 	Long: "List regions of a cloud provider.\n\n" +
 		"In --ccs mode, fetch regions that would be available to *your* cloud account\n" +
 		"(currently only supported with --provider=aws).",
+	Example: `  # List the regions of AWS with their capabilities, for use in scripts
+  ocm list regions --provider=aws --output json`,
 	RunE: run,
 }
 
@@ -75,6 +80,12 @@ func init() {
 }
 
 func run(cmd *cobra.Command, argv []string) error {
+	// Check the output format:
+	format, err := output.SelectedFormat()
+	if err != nil {
+		return err
+	}
+
 	ccs := cluster.CCS{}
 	if args.provider == "aws" && args.ccs {
 		if args.awsAccessKeyID == "" {
@@ -102,25 +113,36 @@ func run(cmd *cobra.Command, argv []string) error {
 		return err
 	}
 
+	//We display only the enabled region for both ccs and non ccs regions
+	var enabledRegions []*cmv1.CloudRegion
+	for _, region := range regions {
+		if region.Enabled() {
+			enabledRegions = append(enabledRegions, region)
+		}
+	}
+
+	// Write the regions with their capabilities if a structured output format has been
+	// requested:
+	if format.IsStructured() {
+		enabledRegions, err = withCapabilities(enabledRegions)
+		if err != nil {
+			return err
+		}
+		return dump.List(os.Stdout, format, enabledRegions, cmv1.MarshalCloudRegionList)
+	}
+
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.TabIndent)
 
-	//We display only the enabled region for both ccs and non ccs regions
 	if args.provider == "aws" && args.ccs {
 		fmt.Fprintf(writer, "ID\t\tSUPPORTS MULTI-AZ\n")
-		for _, region := range regions {
-			if !region.Enabled() {
-				continue
-			}
+		for _, region := range enabledRegions {
 			fmt.Fprintf(writer, "%s\t\t%v\n",
 				region.ID(), region.SupportsMultiAZ())
 		}
 	} else {
 		fmt.Fprintf(writer, "ID\t\tON RED HAT INFRA\t\tCCS ONLY\t\tSUPPORTS MULTI-AZ\n")
-		for _, region := range regions {
-			if !region.Enabled() {
-				continue
-			}
+		for _, region := range enabledRegions {
 			fmt.Fprintf(writer, "%s\t\t%v\t\t%v\t\t%v\n",
 				region.ID(), !region.CCSOnly(), region.CCSOnly(), region.SupportsMultiAZ())
 		}
@@ -130,3 +152,24 @@ func run(cmd *cobra.Command, argv []string) error {
 	err = writer.Flush()
 	return err
 }
+
+// withCapabilities returns copies of the given regions where the capability fields are explicitly
+// set, so that they are included in the structured output even when the server omitted them
+// because they have the default value.
+func withCapabilities(regions []*cmv1.CloudRegion) ([]*cmv1.CloudRegion, error) {
+	result := make([]*cmv1.CloudRegion, len(regions))
+	for i, region := range regions {
+		var err error
+		result[i], err = cmv1.NewCloudRegion().
+			Copy(region).
+			CCSOnly(region.CCSOnly()).
+			Enabled(region.Enabled()).
+			SupportsMultiAZ(region.SupportsMultiAZ()).
+			KMSLocationID(region.KMSLocationID()).
+			Build()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to build region '%s': %v", region.ID(), err)
+		}
+	}
+	return result, nil
+}
@@ -3,18 +3,23 @@ package rhRegion
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
 
 	sdk "github.com/openshift-online/ocm-sdk-go"
 
 	"github.com/openshift-online/ocm-cli/pkg/config"
+	"github.com/openshift-online/ocm-cli/pkg/dump"
+	"github.com/openshift-online/ocm-cli/pkg/output"
+	"github.com/openshift-online/ocm-cli/pkg/properties"
 	"github.com/openshift-online/ocm-cli/pkg/urls"
 	"github.com/spf13/cobra"
 )
 
 var args struct {
 	discoveryURL string
+	refresh      bool
 }
 
 var Cmd = &cobra.Command{
@@ -37,9 +42,30 @@ func init() {
 			"file or "+sdk.DefaultURL+" as a last resort. The value should be a complete URL "+
 			"or a valid URL alias: "+strings.Join(urls.ValidOCMUrlAliases(), ", "),
 	)
+	flags.BoolVar(
+		&args.refresh,
+		"refresh",
+		false,
+		"Fetch the regions again instead of using the result cached by previous commands. "+
+			"The cache can be disabled setting the "+properties.DisableRegionCacheEnvKey+
+			" environment variable to 'true'.",
+	)
+}
+
+// rhRegionItem is the representation of a region used by the structured output formats.
+type rhRegionItem struct {
+	Name string   `json:"name"`
+	URL  string   `json:"url"`
+	AWS  []string `json:"aws,omitempty"`
+	GCP  []string `json:"gcp,omitempty"`
 }
 
 func run(cmd *cobra.Command, argv []string) error {
+	// Check the output format:
+	format, err := output.SelectedFormat()
+	if err != nil {
+		return err
+	}
 
 	cfg, _ := config.Load()
 
@@ -48,16 +74,38 @@ func run(cmd *cobra.Command, argv []string) error {
 		return err
 	}
 
-	fmt.Fprintf(os.Stdout, "Discovery URL: %s\n\n", gatewayURL)
-	regions, err := sdk.GetRhRegions(gatewayURL)
+	regions, err := urls.GetRhRegions(gatewayURL, args.refresh)
 	if err != nil {
 		return fmt.Errorf("Failed to get OCM regions: %w", err)
 	}
 
+	regionNames := make([]string, 0, len(regions))
+	for regionName := range regions {
+		regionNames = append(regionNames, regionName)
+	}
+	sort.Strings(regionNames)
+
+	// Write the regions as a list if a structured output format has been requested:
+	if format.IsStructured() {
+		items := make([]*rhRegionItem, len(regionNames))
+		for i, regionName := range regionNames {
+			region := regions[regionName]
+			items[i] = &rhRegionItem{
+				Name: regionName,
+				URL:  region.URL,
+				AWS:  region.AWS,
+				GCP:  region.GCP,
+			}
+		}
+		return dump.List(os.Stdout, format, items, dump.Items[*rhRegionItem])
+	}
+
+	fmt.Fprintf(os.Stdout, "Discovery URL: %s\n\n", gatewayURL)
+
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.TabIndent)
 	fmt.Fprintf(writer, "RH Region\t\tGateway URL\n")
-	for regionName, region := range regions {
-		fmt.Fprintf(writer, "%s\t\t%v\n", regionName, region.URL)
+	for _, regionName := range regionNames {
+		fmt.Fprintf(writer, "%s\t\t%v\n", regionName, regions[regionName].URL)
 	}
 
 	err = writer.Flush()
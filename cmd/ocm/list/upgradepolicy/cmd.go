@@ -17,12 +17,18 @@ limitations under the License.
 package upgradepolicy
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"text/tabwriter"
+	"time"
 
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
 	c "github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/dump"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	"github.com/openshift-online/ocm-cli/pkg/output"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 
 	"github.com/spf13/cobra"
@@ -30,15 +36,26 @@ import (
 
 var args struct {
 	clusterKey string
+	watch      time.Duration
 }
 
 var Cmd = &cobra.Command{
 	Use:     "upgradepolicies --cluster={NAME|ID|EXTERNAL_ID}",
 	Aliases: []string{"upgrade-policy", "upgrade-policies", "upgradepolicy"},
 	Short:   "List cluster upgrade policies",
-	Long:    "List upgrade policies for a cluster.",
+	Long: "List upgrade policies for a cluster, including when they run next, the version " +
+		"they upgrade to and their state.\n\n" +
+		"The state of each policy is retrieved with an additional request, so the table " +
+		"output sends one request per policy. The structured output formats don't include " +
+		"the state and don't send these requests.",
 	Example: `  # List all upgrade policies on a cluster named "mycluster"
-  ocm list upgradepolicies --cluster=mycluster`,
+  ocm list upgradepolicies --cluster=mycluster
+
+  # List the upgrade policies in JSON format
+  ocm list upgrade-policies --cluster=mycluster --output json
+
+  # List the upgrade policies again every 30 seconds, till interrupted
+  ocm list upgrade-policies --cluster=mycluster --watch=30s`,
 	Args: cobra.NoArgs,
 	RunE: run,
 }
@@ -53,11 +70,24 @@ func init() {
 		"",
 		"Name or ID or external_id of the cluster to list the upgrade policies of (required).",
 	)
+	arguments.AddWatchFlag(flags, &args.watch)
 	//nolint:gosec
 	Cmd.MarkFlagRequired("cluster")
 }
 
 func run(cmd *cobra.Command, argv []string) error {
+	// Create a context:
+	ctx := context.Background()
+
+	// Check the output format:
+	format, err := output.SelectedFormat()
+	if err != nil {
+		return err
+	}
+	err = arguments.CheckWatchFlag(args.watch, format)
+	if err != nil {
+		return err
+	}
 
 	// Check that the cluster key (name, identifier or external identifier) given by the user
 	// is reasonably safe so that there is no risk of SQL injection:
@@ -89,25 +119,61 @@ func run(cmd *cobra.Command, argv []string) error {
 		return fmt.Errorf("Cluster '%s' is not yet ready", clusterKey)
 	}
 
-	upgradePolicies, err := c.GetUpgradePolicies(clusterCollection, cluster.ID())
+	// Create the output printer:
+	printer, err := output.NewPrinter().
+		Writer(os.Stdout).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer printer.Close()
+
+	// When watching, stop refreshing when the user presses Ctrl+C:
+	if args.watch != 0 {
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+	}
+
+	return printer.Watch(ctx, args.watch, func() error {
+		return listUpgradePolicies(printer, clusterCollection, cluster.ID(), format)
+	})
+}
+
+// listUpgradePolicies fetches the upgrade policies of the cluster and writes them to the printer.
+func listUpgradePolicies(printer *output.Printer, clusterCollection *cmv1.ClustersClient,
+	clusterID string, format output.Format) error {
+	upgradePolicies, err := c.GetUpgradePolicies(clusterCollection, clusterID)
 	if err != nil {
 		return err
 	}
 
+	// Write the upgrade policies as they are if a structured output format has been requested:
+	if format.IsStructured() {
+		return dump.List(printer, format, upgradePolicies, cmv1.MarshalUpgradePolicyList)
+	}
+
 	// Create the writer that will be used to print the tabulated results:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	writer := tabwriter.NewWriter(printer, 0, 0, 2, ' ', 0)
 
-	fmt.Fprintf(writer, "ID\tSCHEDULE TYPE\t\t\tUPGRADE VERSION\t\tNEXT RUN\n")
+	fmt.Fprintf(writer, "ID\tSCHEDULE TYPE\tNEXT RUN\tVERSION\tSTATE\n")
 	for _, upgradePolicy := range upgradePolicies {
-		fmt.Fprintf(writer, "%s\t%s\t\t\t%s\t\t%v\n",
+		// The state isn't part of the policy and the API only returns it for one policy at a
+		// time. Policies that have no state are still listed:
+		policyState, err := c.GetUpgradePolicyState(clusterCollection, clusterID, upgradePolicy.ID())
+		if err != nil {
+			return err
+		}
+		state := "N/A"
+		if policyState != nil {
+			state = string(policyState.Value())
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%v\t%s\t%s\n",
 			upgradePolicy.ID(),
 			upgradePolicy.ScheduleType(),
+			upgradePolicy.NextRun(),
 			upgradePolicy.Version(),
-			upgradePolicy.NextRun())
+			state)
 	}
-
-	//nolint:gosec
-	writer.Flush()
-
-	return nil
+	return writer.Flush()
 }
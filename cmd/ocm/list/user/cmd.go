@@ -24,7 +24,9 @@ import (
 	"github.com/spf13/cobra"
 
 	c "github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/dump"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	"github.com/openshift-online/ocm-cli/pkg/output"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 )
 
@@ -56,6 +58,12 @@ func init() {
 }
 
 func run(cmd *cobra.Command, argv []string) error {
+	// Check the output format:
+	format, err := output.SelectedFormat()
+	if err != nil {
+		return err
+	}
+
 	// Check that the cluster key (name, identifier or external identifier) given by the user
 	// is reasonably safe so that there is no risk of SQL injection:
 	clusterKey := args.clusterKey
@@ -89,6 +97,12 @@ func run(cmd *cobra.Command, argv []string) error {
 		return fmt.Errorf("Failed to get users for cluster '%s': %v", clusterKey, err)
 	}
 
+	// Write the groups, including their users, as they are if a structured output format has
+	// been requested:
+	if format.IsStructured() {
+		return dump.List(os.Stdout, format, groups, cmv1.MarshalGroupList)
+	}
+
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	fmt.Fprintf(writer, "GROUP\t\tUSER\n")
@@ -18,9 +18,12 @@ package version
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/dump"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	"github.com/openshift-online/ocm-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
@@ -66,6 +69,12 @@ func init() {
 }
 
 func run(cmd *cobra.Command, argv []string) error {
+	// Check the output format:
+	format, err := output.SelectedFormat()
+	if err != nil {
+		return err
+	}
+
 	// Create the client for the OCM API:
 	connection, err := ocm.NewConnection().Build()
 	if err != nil {
@@ -80,11 +89,16 @@ func run(cmd *cobra.Command, argv []string) error {
 	}
 
 	if args.defaultVersion {
-		fmt.Println(defaultVersion)
-	} else {
-		for _, v := range versions {
-			fmt.Println(v)
-		}
+		versions = []string{defaultVersion}
+	}
+
+	// Write the versions as a list if a structured output format has been requested:
+	if format.IsStructured() {
+		return dump.List(os.Stdout, format, versions, dump.Items[string])
+	}
+
+	for _, v := range versions {
+		fmt.Println(v)
 	}
 
 	return nil
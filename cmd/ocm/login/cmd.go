@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -43,6 +44,7 @@ var args struct {
 	clientID      string
 	clientSecret  string
 	scopes        []string
+	addScopes     []string
 	url           string
 	token         string
 	user          string
@@ -97,6 +99,13 @@ func init() {
 		"OpenID scope. If this option is used it will replace completely the default "+
 			"scopes. Can be repeated multiple times to specify multiple scopes.",
 	)
+	flags.StringSliceVar(
+		&args.addScopes,
+		"add-scope",
+		nil,
+		"OpenID scope to add to the default scopes. Can be repeated multiple times to add "+
+			"multiple scopes. Can't be used together with --scope.",
+	)
 	flags.StringVar(
 		&args.url,
 		"url",
@@ -174,6 +183,10 @@ func run(cmd *cobra.Command, argv []string) error {
 
 	var err error
 
+	if cmd.Flags().Changed("scope") && cmd.Flags().Changed("add-scope") {
+		return fmt.Errorf("Flags --scope and --add-scope can't be used at the same time")
+	}
+
 	// Fail fast if OCM_KEYRING is provided and invalid
 	if keyring, ok := config.IsKeyringManaged(); ok {
 		err := securestore.ValidateBackend(keyring)
@@ -218,6 +231,7 @@ func run(cmd *cobra.Command, argv []string) error {
 	havePassword := args.user != "" && args.password != ""
 	haveClientCreds := args.clientID != "" && args.clientSecret != ""
 	haveToken := args.token != ""
+	clientCredsOnly := haveClientCreds && !havePassword && !haveToken
 	if !havePassword && !haveClientCreds && !haveToken {
 		// Allow bare `ocm login` to suggest the token page without noise of full help.
 		fmt.Fprintf(
@@ -251,6 +265,16 @@ func run(cmd *cobra.Command, argv []string) error {
 		cfg = new(config.Config)
 	}
 
+	// When only the client credentials of a service account are given the tokens have to be
+	// requested with the client credentials grant, so discard the tokens and the user name and
+	// password of previous logins to make sure that they aren't used instead:
+	if clientCredsOnly {
+		cfg.AccessToken = ""
+		cfg.RefreshToken = ""
+		cfg.User = ""
+		cfg.Password = ""
+	}
+
 	if haveToken {
 		// Encrypted tokens are assumed to be refresh tokens:
 		if config.IsEncryptedToken(args.token) {
@@ -300,7 +324,7 @@ func run(cmd *cobra.Command, argv []string) error {
 	// service discovery for the environment --url is a part of, but the gatewayURL (and
 	// ultimately the cfg.URL) is then updated to the URL of the matching --rh-region:
 	//   1. resolve the gatewayURL as above
-	//   2. fetch a well-known file from sdk.GetRhRegion
+	//   2. fetch a well-known file from urls.GetRhRegion, or reuse the cached copy
 	//   3. update the gatewayURL to the region URL matching args.rhRegion
 	//
 	// So `--url=https://api.stage.openshift.com --rh-region=singapore` might result in
@@ -308,7 +332,7 @@ func run(cmd *cobra.Command, argv []string) error {
 	//
 	// See ocm-sdk-go/rh_region.go for full details on how service discovery works.
 	if args.rhRegion != "" {
-		regValue, err := sdk.GetRhRegion(gatewayURL, args.rhRegion)
+		regValue, err := urls.GetRhRegion(gatewayURL, args.rhRegion)
 		if err != nil {
 			return fmt.Errorf("Can't find region: %w", err)
 		}
@@ -324,6 +348,9 @@ func run(cmd *cobra.Command, argv []string) error {
 	cfg.ClientID = clientID
 	cfg.ClientSecret = args.clientSecret
 	cfg.Scopes = args.scopes
+	if len(args.addScopes) > 0 {
+		cfg.Scopes = addScopes(sdk.DefaultScopes, args.addScopes)
+	}
 	cfg.URL = gatewayURL
 	cfg.User = args.user
 	cfg.Password = args.password
@@ -353,6 +380,10 @@ func run(cmd *cobra.Command, argv []string) error {
 		return fmt.Errorf("can't save config: %v", err)
 	}
 
+	if clientCredsOnly {
+		fmt.Printf("Login successful with the client credentials of '%s'\n", clientID)
+	}
+
 	if args.useAuthCode || args.useDeviceCode {
 		ssoURL, err := url.Parse(cfg.TokenURL)
 		if err != nil {
@@ -367,3 +398,16 @@ func run(cmd *cobra.Command, argv []string) error {
 
 	return nil
 }
+
+// addScopes returns a new slice containing the given scopes followed by the added ones that
+// aren't already present.
+func addScopes(scopes []string, added []string) []string {
+	result := make([]string, 0, len(scopes)+len(added))
+	result = append(result, scopes...)
+	for _, scope := range added {
+		if !slices.Contains(result, scope) {
+			result = append(result, scope)
+		}
+	}
+	return result
+}
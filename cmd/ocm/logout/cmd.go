@@ -25,6 +25,10 @@ import (
 	"github.com/openshift-online/ocm-sdk-go/authentication/securestore"
 )
 
+var args struct {
+	keepURL bool
+}
+
 var Cmd = &cobra.Command{
 	Use:   "logout",
 	Short: "Log out",
@@ -33,9 +37,20 @@ var Cmd = &cobra.Command{
 	RunE:  run,
 }
 
+func init() {
+	flags := Cmd.Flags()
+	flags.BoolVar(
+		&args.keepURL,
+		"keep-url",
+		false,
+		"Remove only the credentials, keeping the API and token URLs, the client identifier "+
+			"and the scopes, so that the next login uses the same environment.",
+	)
+}
+
 func run(cmd *cobra.Command, argv []string) error {
 
-	if keyring, ok := config.IsKeyringManaged(); ok {
+	if keyring, ok := config.IsKeyringManaged(); ok && !args.keepURL {
 		err := securestore.RemoveConfigFromKeyring(keyring)
 		if err != nil {
 			return fmt.Errorf("can't remove configuration from keyring: %w", err)
@@ -48,9 +63,16 @@ func run(cmd *cobra.Command, argv []string) error {
 	if err != nil {
 		return fmt.Errorf("can't load configuration file: %w", err)
 	}
+	if cfg == nil {
+		return nil
+	}
 
-	// Remove all the login related settings from the configuration file:
-	cfg.Disarm()
+	// Remove the login related settings from the configuration file:
+	if args.keepURL {
+		cfg.DisarmCredentials()
+	} else {
+		cfg.Disarm()
+	}
 
 	// Save the configuration file:
 	err = config.Save(cfg)
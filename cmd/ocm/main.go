@@ -47,6 +47,7 @@ import (
 	"github.com/openshift-online/ocm-cli/cmd/ocm/pop"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/post"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/push"
+	"github.com/openshift-online/ocm-cli/cmd/ocm/request"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/resume"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/success"
 	"github.com/openshift-online/ocm-cli/cmd/ocm/token"
@@ -81,6 +82,9 @@ func init() {
 	// Add the command line flags:
 	fs := root.PersistentFlags()
 	arguments.AddDebugFlag(fs)
+	arguments.AddOCMProxyFlag(fs)
+	arguments.AddCAFileFlag(fs)
+	arguments.AddConfigFlag(fs)
 
 	// Register the subcommands:
 	root.AddCommand(account.Cmd)
@@ -102,6 +106,7 @@ func init() {
 	root.AddCommand(post.Cmd)
 	root.AddCommand(pop.Cmd)
 	root.AddCommand(push.Cmd)
+	root.AddCommand(request.Cmd)
 	root.AddCommand(resume.Cmd)
 	root.AddCommand(success.Cmd)
 	root.AddCommand(token.Cmd)
@@ -22,7 +22,6 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
-	"sort"
 	"strings"
 
 	"github.com/openshift-online/ocm-cli/pkg/config"
@@ -41,6 +40,7 @@ var Cmd = &cobra.Command{
 var args struct {
 	columns  string
 	nameOnly bool
+	paths    bool
 }
 
 func init() {
@@ -57,6 +57,14 @@ func init() {
 		"name, path",
 		"Comma separated list of columns to display.",
 	)
+	fs.BoolVar(
+		&args.paths,
+		"paths",
+		false,
+		"Show the complete path of the executable of each plugin, and whether it is the one "+
+			"that will be used or it is shadowed by another plugin with the same name that "+
+			"appears before in the PATH. This is equivalent to '--columns name,file,status'.",
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) error {
@@ -68,6 +76,9 @@ func run(cmd *cobra.Command, argv []string) error {
 	if args.nameOnly {
 		args.columns = "name"
 	}
+	if args.paths {
+		args.columns = "name, file, status"
+	}
 
 	// Load the configuration:
 	cfg, err := config.Load()
@@ -118,17 +129,33 @@ func run(cmd *cobra.Command, argv []string) error {
 		return err
 	}
 
-	return nil
+	return table.Flush()
 }
 
 // Plugin contains the description fo a Plugin.
 type Plugin struct {
-	Name string
-	Path string
+	Name   string
+	Path   string
+	File   string
+	Status string
 }
 
+const (
+	// pluginActive is the status of the plugin that will be used for a name.
+	pluginActive = "active"
+
+	// pluginShadowed is the status of the plugins that won't be used because there is another
+	// plugin with the same name before in the PATH.
+	pluginShadowed = "shadowed"
+
+	// pluginNotExecutable is the status of the plugins that will be ignored because they aren't
+	// executable.
+	pluginNotExecutable = "not executable"
+)
+
 // findPlugins scans the directories listed in the `PATH` environment variable looking for
-// files that are plugins.
+// files that are plugins. The plugins are returned in the order of the `PATH`, so the first
+// executable plugin for each name is the one that will be used, and the rest are shadowed.
 func findPlugins() (result []Plugin, err error) {
 	defaultPath := filepath.SplitList(os.Getenv("PATH"))
 	newPath := uniquePath(defaultPath)
@@ -149,6 +176,20 @@ func findPlugins() (result []Plugin, err error) {
 		}
 		result = append(result, list...)
 	}
+
+	// Mark the plugins that are shadowed by others with the same name:
+	active := map[string]bool{}
+	for i, plugin := range result {
+		if plugin.Status == pluginNotExecutable {
+			continue
+		}
+		if active[plugin.Name] {
+			result[i].Status = pluginShadowed
+			continue
+		}
+		active[plugin.Name] = true
+		result[i].Status = pluginActive
+	}
 	return
 }
 
@@ -181,30 +222,32 @@ func listPlugins(dir string) (result []Plugin, err error) {
 		plugin := Plugin{
 			Name: name,
 			Path: dir,
+			File: path,
+		}
+		if !exec {
+			plugin.Status = pluginNotExecutable
 		}
 		result = append(result, plugin)
 	}
 	return
 }
 
-// uniquePath remove the duplicate items from the PATH
+// uniquePath remove the duplicate items from the PATH, preserving the order.
 func uniquePath(path []string) []string {
-	keys := make(map[string]int)
+	keys := make(map[string]bool)
 	uniPath := make([]string, 0)
 
 	for _, p := range path {
 		if p == "" {
 			p = "."
 		}
-		keys[p] = 1
-	}
-
-	for element := range keys {
-		uniPath = append(uniPath, element)
+		if keys[p] {
+			continue
+		}
+		keys[p] = true
+		uniPath = append(uniPath, p)
 	}
 
-	sort.Strings(uniPath)
-
 	return uniPath
 }
 
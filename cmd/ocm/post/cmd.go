@@ -18,6 +18,7 @@ package post
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -30,9 +31,13 @@ import (
 )
 
 var args struct {
-	parameter []string
-	header    []string
-	body      string
+	parameter     []string
+	header        []string
+	outFile       string
+	body          string
+	dryRun        bool
+	verboseTiming bool
+	retry         arguments.RetryOptions
 }
 
 var Cmd = &cobra.Command{
@@ -47,17 +52,26 @@ func init() {
 	fs := Cmd.Flags()
 	arguments.AddParameterFlag(fs, &args.parameter)
 	arguments.AddHeaderFlag(fs, &args.header)
+	arguments.AddVerboseTimingFlag(fs, &args.verboseTiming)
+	arguments.AddRetryFlags(fs, &args.retry)
+	arguments.AddOutputFileFlag(fs, &args.outFile)
 	arguments.AddBodyFlag(fs, &args.body)
+	arguments.AddDryRunFlag(fs, &args.dryRun)
 }
 
 func run(cmd *cobra.Command, argv []string) error {
+	err := arguments.CheckRetryFlags(cmd.Flags(), &args.retry, http.MethodPost)
+	if err != nil {
+		return err
+	}
+
 	path, err := urls.Expand(argv)
 	if err != nil {
 		return fmt.Errorf("Could not create URI: %v", err)
 	}
 
 	// Create the client for the OCM API:
-	connection, err := ocm.NewConnection().Build()
+	connection, err := arguments.ApplyRetryFlags(ocm.NewConnection(), args.retry).Build()
 	if err != nil {
 		return fmt.Errorf("Failed to create OCM connection: %v", err)
 	}
@@ -70,22 +84,39 @@ func run(cmd *cobra.Command, argv []string) error {
 		fmt.Fprintf(os.Stderr, "Can't parse path '%s': %v\n", path, err)
 		os.Exit(1)
 	}
-	arguments.ApplyParameterFlag(request, args.parameter)
-	arguments.ApplyHeaderFlag(request, args.header)
+	err = arguments.ApplyParameterFlag(request, args.parameter)
+	if err != nil {
+		return err
+	}
+	err = arguments.ApplyHeaderFlag(request, args.header)
+	if err != nil {
+		return err
+	}
+	arguments.ApplyDryRunFlag(request, args.dryRun)
 	err = arguments.ApplyBodyFlag(request, args.body)
 	if err != nil {
 		return fmt.Errorf("Can't read body: %v", err)
 	}
 
 	// Send the request:
-	response, err := request.Send()
+	response, err := arguments.SendRequestWithRetry(request, args.verboseTiming, args.retry)
 	if err != nil {
 		return fmt.Errorf("Can't send request: %v", err)
 	}
 	status := response.Status()
 	body := response.Bytes()
+
+	// Write the body of successful responses to the output file, if requested:
+	stdout := os.Stdout
+	if status < 400 && args.outFile != "" {
+		stdout, err = dump.CreateFile(args.outFile)
+		if err != nil {
+			return fmt.Errorf("Can't create output file: %v", err)
+		}
+	}
+
 	if status < 400 {
-		err = dump.Pretty(os.Stdout, body)
+		err = dump.Pretty(stdout, body)
 	} else {
 		err = dump.Pretty(os.Stderr, body)
 	}
@@ -93,6 +124,14 @@ func run(cmd *cobra.Command, argv []string) error {
 		return fmt.Errorf("Can't print body: %v", err)
 	}
 
+	// Check that the output file has been written completely:
+	if stdout != os.Stdout {
+		err = stdout.Close()
+		if err != nil {
+			return fmt.Errorf("Can't close output file: %v", err)
+		}
+	}
+
 	// Load the configuration file:
 	cfg, err := config.Load()
 	if err != nil {
@@ -0,0 +1,210 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package request
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
+	"github.com/openshift-online/ocm-cli/pkg/config"
+	"github.com/openshift-online/ocm-cli/pkg/dump"
+	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	"github.com/openshift-online/ocm-cli/pkg/urls"
+)
+
+var args struct {
+	method        string
+	parameter     []string
+	header        []string
+	outFile       string
+	body          string
+	dryRun        bool
+	verboseTiming bool
+	retry         arguments.RetryOptions
+}
+
+var Cmd = &cobra.Command{
+	Use:   "request PATH",
+	Short: "Send a request with any HTTP method",
+	Long: "Send a request with the HTTP method given with the '--method' flag to the given path. " +
+		"The request body is only sent for methods that accept it, or when the '--body' flag " +
+		"is used.",
+	Example: `  # Replace an object
+  ocm request --method PUT --body object.json /api/my_service/v1/my_objects/123`,
+	RunE:      run,
+	ValidArgs: urls.Resources(),
+}
+
+func init() {
+	fs := Cmd.Flags()
+	fs.StringVar(
+		&args.method,
+		"method",
+		http.MethodGet,
+		fmt.Sprintf("HTTP method of the request, one of: %s.", strings.Join(methodNames(), ", ")),
+	)
+	arguments.AddParameterFlag(fs, &args.parameter)
+	arguments.AddHeaderFlag(fs, &args.header)
+	arguments.AddVerboseTimingFlag(fs, &args.verboseTiming)
+	arguments.AddRetryFlags(fs, &args.retry)
+	arguments.AddOutputFileFlag(fs, &args.outFile)
+	arguments.AddBodyFlag(fs, &args.body)
+	arguments.AddDryRunFlag(fs, &args.dryRun)
+}
+
+// methods contains the functions used to create the requests for the supported methods, and a
+// flag indicating if the request body is read from the standard input when the '--body' flag
+// isn't used. Only the methods supported by the request builder of the SDK are included.
+var methods = map[string]struct {
+	create func(*sdk.Connection) *sdk.Request
+	body   bool
+}{
+	http.MethodGet:    {create: (*sdk.Connection).Get},
+	http.MethodPost:   {create: (*sdk.Connection).Post, body: true},
+	http.MethodPut:    {create: (*sdk.Connection).Put, body: true},
+	http.MethodPatch:  {create: (*sdk.Connection).Patch, body: true},
+	http.MethodDelete: {create: (*sdk.Connection).Delete},
+}
+
+func methodNames() []string {
+	names := make([]string, 0, len(methods))
+	for name := range methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func run(cmd *cobra.Command, argv []string) error {
+	method := strings.ToUpper(args.method)
+	spec, ok := methods[method]
+	if !ok && (method == http.MethodHead || method == http.MethodOptions) {
+		return fmt.Errorf(
+			"Method '%s' isn't supported because the request builder of the SDK has no "+
+				"request type for it, valid values are: %s",
+			method, strings.Join(methodNames(), ", "),
+		)
+	}
+	if !ok {
+		return fmt.Errorf(
+			"Unsupported method '%s', valid values are: %s",
+			args.method, strings.Join(methodNames(), ", "),
+		)
+	}
+	err := arguments.CheckRetryFlags(cmd.Flags(), &args.retry, method)
+	if err != nil {
+		return err
+	}
+
+	path, err := urls.Expand(argv)
+	if err != nil {
+		return fmt.Errorf("Could not create URI: %v", err)
+	}
+
+	// Create the client for the OCM API:
+	connection, err := arguments.ApplyRetryFlags(ocm.NewConnection(), args.retry).Build()
+	if err != nil {
+		return fmt.Errorf("Failed to create OCM connection: %v", err)
+	}
+	defer connection.Close()
+
+	// Create and populate the request:
+	request := spec.create(connection)
+	err = arguments.ApplyPathArg(request, path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't parse path '%s': %v\n", path, err)
+		os.Exit(1)
+	}
+	err = arguments.ApplyParameterFlag(request, args.parameter)
+	if err != nil {
+		return err
+	}
+	err = arguments.ApplyHeaderFlag(request, args.header)
+	if err != nil {
+		return err
+	}
+	arguments.ApplyDryRunFlag(request, args.dryRun)
+	if spec.body || args.body != "" {
+		err = arguments.ApplyBodyFlag(request, args.body)
+		if err != nil {
+			return fmt.Errorf("Can't read body: %v", err)
+		}
+	}
+
+	// Send the request:
+	response, err := arguments.SendRequestWithRetry(request, args.verboseTiming, args.retry)
+	if err != nil {
+		return fmt.Errorf("Can't send request: %v", err)
+	}
+	status := response.Status()
+	body := response.Bytes()
+
+	// Write the body of successful responses to the output file, if requested:
+	stdout := os.Stdout
+	if status < 400 && args.outFile != "" {
+		stdout, err = dump.CreateFile(args.outFile)
+		if err != nil {
+			return fmt.Errorf("Can't create output file: %v", err)
+		}
+	}
+
+	if status < 400 {
+		err = dump.Pretty(stdout, body)
+	} else {
+		err = dump.Pretty(os.Stderr, body)
+	}
+	if err != nil {
+		return fmt.Errorf("Can't print body: %v", err)
+	}
+
+	// Check that the output file has been written completely:
+	if stdout != os.Stdout {
+		err = stdout.Close()
+		if err != nil {
+			return fmt.Errorf("Can't close output file: %v", err)
+		}
+	}
+
+	// Load the configuration file:
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("Can't load config file: %v", err)
+	}
+	// Save the configuration:
+	cfg.AccessToken, cfg.RefreshToken, err = connection.Tokens()
+	if err != nil {
+		return fmt.Errorf("Can't get tokens: %v", err)
+	}
+	err = config.Save(cfg)
+	if err != nil {
+		return fmt.Errorf("Can't save config file: %v", err)
+	}
+
+	// Bye:
+	if status >= 400 {
+		os.Exit(1)
+	}
+
+	return nil
+}
@@ -1,15 +1,22 @@
 package cluster
 
 import (
+	"context"
 	"fmt"
 	"os"
 
+	"github.com/openshift-online/ocm-cli/pkg/arguments"
 	c "github.com/openshift-online/ocm-cli/pkg/cluster"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 
 	"github.com/spf13/cobra"
 )
 
+var args struct {
+	wait ocm.WaitOptions
+}
+
 var Cmd = &cobra.Command{
 	Use:   "cluster {NAME|ID|EXTERNAL_ID}",
 	Short: "Resume a cluster from hibernation",
@@ -17,6 +24,15 @@ var Cmd = &cobra.Command{
 	RunE:  run,
 }
 
+func init() {
+	flags := Cmd.Flags()
+	arguments.AddWaitFlags(
+		flags,
+		&args.wait,
+		"Wait till the cluster is ready again, printing the state transitions.",
+	)
+}
+
 func run(cmd *cobra.Command, argv []string) error {
 	// Check that there is exactly one cluster name, identifir or external identifier in the
 	// command line arguments:
@@ -40,6 +56,11 @@ func run(cmd *cobra.Command, argv []string) error {
 		)
 	}
 
+	err := arguments.CheckWaitFlags(cmd.Flags(), args.wait)
+	if err != nil {
+		return err
+	}
+
 	// Create the client for the OCM API:
 	connection, err := ocm.NewConnection().Build()
 	if err != nil {
@@ -55,9 +76,54 @@ func run(cmd *cobra.Command, argv []string) error {
 	if err != nil {
 		return fmt.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
 	}
+
+	// Only hibernating clusters can be resumed:
+	if cluster.State() != cmv1.ClusterStateHibernating {
+		return fmt.Errorf(
+			"Cluster '%s' can't be resumed because it isn't hibernating, its current state is '%s'",
+			clusterKey, cluster.State(),
+		)
+	}
+
 	_, err = clusterCollection.Cluster(cluster.ID()).Resume().Send()
 	if err != nil {
 		return err
 	}
+
+	if !args.wait.Enabled {
+		return nil
+	}
+	return waitForReady(clusterCollection.Cluster(cluster.ID()), clusterKey, cluster.State())
+}
+
+// waitForReady polls the cluster till it is ready, printing the state transitions. It fails if the
+// cluster moves to the error state or if it isn't ready before the timeout.
+func waitForReady(client *cmv1.ClusterClient, clusterKey string, state cmv1.ClusterState) error {
+	fmt.Printf("Waiting for cluster '%s' to be ready, current state is '%s'\n", clusterKey, state)
+	err := ocm.NewPoller().
+		Options(args.wait).
+		Run(context.Background(), func(ctx context.Context) (bool, error) {
+			response, err := client.Get().SendContext(ctx)
+			if err != nil {
+				// Keep waiting if the server can't answer right now, the next check will
+				// probably succeed:
+				if ocm.IsTransientStatus(response.Status()) {
+					return false, nil
+				}
+				return false, err
+			}
+			current := response.Body().State()
+			if current != state {
+				fmt.Printf("Cluster '%s' state changed from '%s' to '%s'\n", clusterKey, state, current)
+				state = current
+			}
+			return state == cmv1.ClusterStateReady || state == cmv1.ClusterStateError, nil
+		})
+	if err != nil {
+		return fmt.Errorf("Failed to wait for cluster '%s' to be ready: %v", clusterKey, err)
+	}
+	if state == cmv1.ClusterStateError {
+		return fmt.Errorf("Cluster '%s' failed to resume, its current state is '%s'", clusterKey, state)
+	}
 	return nil
 }
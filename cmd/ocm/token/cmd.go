@@ -20,6 +20,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	jwt "github.com/golang-jwt/jwt/v4"
@@ -36,14 +37,29 @@ var args struct {
 	signature bool
 	refresh   bool
 	generate  bool
+	tokenType string
+}
+
+// Types of tokens accepted by the '--type' flag.
+const (
+	accessTokenType  = "access"
+	refreshTokenType = "refresh"
+)
+
+var tokenTypes = []string{
+	accessTokenType,
+	refreshTokenType,
 }
 
 var Cmd = &cobra.Command{
 	Use:   "token",
 	Short: "Generates a token",
 	Long:  "Uses the stored credentials to generate a token.",
-	Args:  cobra.NoArgs,
-	RunE:  run,
+	Example: `  # Print the refresh token, for example to store it in a CI secret and use it later
+  # with 'ocm login --token'
+  ocm token --type refresh`,
+	Args: cobra.NoArgs,
+	RunE: run,
 }
 
 func init() {
@@ -72,6 +88,16 @@ func init() {
 		false,
 		"Print the refresh token instead of the access token.",
 	)
+	flags.StringVar(
+		&args.tokenType,
+		"type",
+		accessTokenType,
+		fmt.Sprintf(
+			"Type of token to print, one of: %s. The refresh token is long lived, so it can "+
+				"be stored, for example in a CI secret, and used later to log in.",
+			strings.Join(tokenTypes, ", "),
+		),
+	)
 	flags.BoolVar(
 		&args.generate,
 		"generate",
@@ -100,6 +126,21 @@ func run(cmd *cobra.Command, argv []string) error {
 		return fmt.Errorf("Options '--payload', '--header', '--signature', and '--generate' are mutually exclusive")
 	}
 
+	// The '--refresh' flag is a shorthand for '--type refresh':
+	switch args.tokenType {
+	case accessTokenType:
+		if args.refresh && cmd.Flags().Changed("type") {
+			return fmt.Errorf("Flag --refresh can't be used with --type=%s", accessTokenType)
+		}
+	case refreshTokenType:
+		args.refresh = true
+	default:
+		return fmt.Errorf(
+			"Invalid --type '%s', valid values are: %s",
+			args.tokenType, strings.Join(tokenTypes, ", "),
+		)
+	}
+
 	// Create the client for the OCM API:
 	connection, err := ocm.NewConnection().Build()
 	if err != nil {
@@ -127,6 +168,11 @@ func run(cmd *cobra.Command, argv []string) error {
 	// Select the token according to the options:
 	selectedToken := accessToken
 	if args.refresh {
+		if refreshToken == "" {
+			return fmt.Errorf(
+				"There is no refresh token, the credentials used to log in don't provide one",
+			)
+		}
 		selectedToken = refreshToken
 	}
 
@@ -167,6 +213,13 @@ func run(cmd *cobra.Command, argv []string) error {
 			return fmt.Errorf("Can't dump signature: %v", err)
 		}
 	} else {
+		if args.tokenType == refreshTokenType {
+			fmt.Fprintf(
+				os.Stderr,
+				"Warning: the refresh token gives long lived access to your account, keep it "+
+					"in a secure place like a CI secret and don't share it\n",
+			)
+		}
 		fmt.Fprintf(os.Stdout, "%s\n", selectedToken)
 	}
 
@@ -32,7 +32,8 @@ import (
 )
 
 var args struct {
-	useSubnets bool
+	useSubnets   bool
+	portForwards []string
 }
 
 var Cmd = &cobra.Command{
@@ -41,10 +42,11 @@ var Cmd = &cobra.Command{
 	Long: "Use sshuttle to create a ssh tunnel to a cluster by ID or Name or" +
 		"cluster name search string according to the api: " +
 		"https://api.openshift.com/#/clusters/get_api_clusters_mgmt_v1_clusters",
-	Example: " ocm tunnel <cluster_id>\n ocm tunnel %test%",
-	RunE:    run,
-	Hidden:  true,
-	Args:    cobra.ArbitraryArgs,
+	Example: " ocm tunnel <cluster_id>\n ocm tunnel %test%\n" +
+		" ocm tunnel --port-forward 8443:6443 --port-forward 9090:prometheus.local:9090 <cluster_id>",
+	RunE:   run,
+	Hidden: true,
+	Args:   cobra.ArbitraryArgs,
 }
 
 func init() {
@@ -57,6 +59,15 @@ func init() {
 		"If specified, tunnel the entire subnets of MachineCIDR, ServiceCIDR and PodCIDR. "+
 			"Otherwise, only tunnel to the IPs of console and API Servers. ",
 	)
+	flags.StringArrayVar(
+		&args.portForwards,
+		"port-forward",
+		nil,
+		"Forward a local port to an endpoint on the cluster side of the tunnel, using ssh instead "+
+			"of sshuttle. The syntax is 'LOCAL_PORT:REMOTE_PORT' or "+
+			"'LOCAL_PORT:REMOTE_HOST:REMOTE_PORT', when the remote host isn't given the "+
+			"API server of the cluster is used. Can be repeated to forward multiple ports.",
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) error {
@@ -81,9 +92,33 @@ func run(cmd *cobra.Command, argv []string) error {
 		)
 	}
 
-	path, err := exec.LookPath("sshuttle")
+	// Check the port forwards before doing anything else, so that syntax errors and local ports
+	// that are already in use are reported early:
+	var portForwards []*portForward
+	if len(args.portForwards) > 0 {
+		if args.useSubnets {
+			return fmt.Errorf("flags --subnets and --port-forward can't be used at the same time")
+		}
+		var err error
+		portForwards, err = parsePortForwards(args.portForwards)
+		if err != nil {
+			return err
+		}
+		for _, portForward := range portForwards {
+			err = checkLocalPort(portForward)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	tool := "sshuttle"
+	if len(portForwards) > 0 {
+		tool = "ssh"
+	}
+	path, err := exec.LookPath(tool)
 	if err != nil {
-		return fmt.Errorf("to run this, you need install the sshuttle tool first")
+		return fmt.Errorf("to run this, you need install the %s tool first", tool)
 	}
 
 	// Create the client for the OCM API:
@@ -105,6 +140,10 @@ func run(cmd *cobra.Command, argv []string) error {
 		return err
 	}
 
+	if len(portForwards) > 0 {
+		return runPortForwards(path, sshURL, cluster, portForwards, argv[1:])
+	}
+
 	sshuttleArgs := []string{
 		"--remote", sshURL,
 	}
@@ -145,6 +184,49 @@ func run(cmd *cobra.Command, argv []string) error {
 	return nil
 }
 
+// runPortForwards uses ssh to forward the given local ports to the cluster side of the tunnel.
+func runPortForwards(path string, sshURL string, cluster *clustersmgmtv1.Cluster,
+	portForwards []*portForward, extraArgs []string) error {
+	// The mappings that don't specify a remote host go to the API server:
+	apiURL, err := url.Parse(cluster.API().URL())
+	if err != nil {
+		return fmt.Errorf("can't parse api server URL: %s", err)
+	}
+	// Ask ssh to exit if any of the ports can't be forwarded, so that the mappings reported
+	// below are all established:
+	sshArgs := []string{"-N", "-o", "ExitOnForwardFailure=yes"}
+	for _, portForward := range portForwards {
+		if portForward.RemoteHost == "" {
+			portForward.RemoteHost = apiURL.Hostname()
+		}
+		sshArgs = append(sshArgs, "-L", portForward.String())
+	}
+	sshArgs = append(sshArgs, extraArgs...)
+	sshArgs = append(sshArgs, sshURL)
+
+	// Output ssh command execution string for review
+	fmt.Printf("\n# %s %s\n\n", path, strings.Join(sshArgs, " "))
+
+	for _, portForward := range portForwards {
+		fmt.Printf(
+			"Forwarding %s:%d to %s:%d\n",
+			localHost, portForward.LocalPort, portForward.RemoteHost, portForward.RemotePort,
+		)
+	}
+
+	// #nosec G204
+	sshCmd := exec.Command(path, sshArgs...)
+	sshCmd.Stderr = os.Stderr
+	sshCmd.Stdin = os.Stdin
+	sshCmd.Stdout = os.Stdout
+	err = sshCmd.Run()
+	if err != nil {
+		return fmt.Errorf("failed to forward ports to cluster: %s", err)
+	}
+
+	return nil
+}
+
 func generateSSHURI(cluster *clustersmgmtv1.Cluster) (string, error) {
 	r := regexp.MustCompile(`(?mi)^https:\/\/api\.(.*):6443`)
 	apiURL := cluster.API().URL()
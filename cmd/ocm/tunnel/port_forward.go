@@ -0,0 +1,121 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// portForward is a mapping from a local port to an endpoint reachable from the cluster side of
+// the tunnel.
+type portForward struct {
+	LocalPort  int
+	RemoteHost string
+	RemotePort int
+}
+
+// String returns the representation of the mapping used by the '-L' option of ssh.
+func (f *portForward) String() string {
+	return fmt.Sprintf("%s:%d:%s:%d", localHost, f.LocalPort, f.RemoteHost, f.RemotePort)
+}
+
+// localHost is the address where the local side of the port forwards listens.
+const localHost = "127.0.0.1"
+
+// parsePortForward parses a mapping with the syntax 'LOCAL_PORT:REMOTE_HOST:REMOTE_PORT' or
+// 'LOCAL_PORT:REMOTE_PORT'. When the remote host isn't given it is left empty, and the caller is
+// expected to replace it with the host of the API server of the cluster.
+func parsePortForward(value string) (*portForward, error) {
+	parts := strings.Split(value, ":")
+	var local, host, remote string
+	switch len(parts) {
+	case 2:
+		local, remote = parts[0], parts[1]
+	case 3:
+		local, host, remote = parts[0], parts[1], parts[2]
+		if host == "" {
+			return nil, fmt.Errorf("remote host of port forward '%s' is empty", value)
+		}
+	default:
+		return nil, fmt.Errorf(
+			"port forward '%s' isn't valid, expected 'LOCAL_PORT:REMOTE_PORT' or "+
+				"'LOCAL_PORT:REMOTE_HOST:REMOTE_PORT'",
+			value,
+		)
+	}
+	localPort, err := parsePort(local)
+	if err != nil {
+		return nil, fmt.Errorf("local port of port forward '%s' isn't valid: %v", value, err)
+	}
+	remotePort, err := parsePort(remote)
+	if err != nil {
+		return nil, fmt.Errorf("remote port of port forward '%s' isn't valid: %v", value, err)
+	}
+	return &portForward{
+		LocalPort:  localPort,
+		RemoteHost: host,
+		RemotePort: remotePort,
+	}, nil
+}
+
+// parsePortForwards parses all the given mappings and checks that the same local port isn't used
+// twice.
+func parsePortForwards(values []string) ([]*portForward, error) {
+	result := make([]*portForward, 0, len(values))
+	used := map[int]string{}
+	for _, value := range values {
+		forward, err := parsePortForward(value)
+		if err != nil {
+			return nil, err
+		}
+		previous, ok := used[forward.LocalPort]
+		if ok {
+			return nil, fmt.Errorf(
+				"local port %d is used by port forwards '%s' and '%s'",
+				forward.LocalPort, previous, value,
+			)
+		}
+		used[forward.LocalPort] = value
+		result = append(result, forward)
+	}
+	return result, nil
+}
+
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' isn't a number", value)
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("%d isn't between 1 and 65535", port)
+	}
+	return port, nil
+}
+
+// checkLocalPort checks that the local port of the given mapping isn't already in use, trying to
+// listen on it.
+func checkLocalPort(forward *portForward) error {
+	address := net.JoinHostPort(localHost, strconv.Itoa(forward.LocalPort))
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("local port %d is already in use: %v", forward.LocalPort, err)
+	}
+	return listener.Close()
+}
@@ -0,0 +1,55 @@
+package tunnel
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Port forwards", func() {
+	It("Parses a mapping to the API server", func() {
+		forward, err := parsePortForward("8443:6443")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(forward.LocalPort).To(Equal(8443))
+		Expect(forward.RemoteHost).To(BeEmpty())
+		Expect(forward.RemotePort).To(Equal(6443))
+	})
+
+	It("Parses a mapping with a remote host", func() {
+		forward, err := parsePortForward("9090:prometheus.local:9091")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(forward.String()).To(Equal("127.0.0.1:9090:prometheus.local:9091"))
+	})
+
+	DescribeTable(
+		"Rejects invalid mappings",
+		func(value string, message string) {
+			_, err := parsePortForward(value)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(message))
+		},
+		Entry("Single port", "8443", "isn't valid, expected"),
+		Entry("Too many parts", "1:host:2:3", "isn't valid, expected"),
+		Entry("Local port not a number", "abc:6443", "local port of port forward 'abc:6443' isn't valid"),
+		Entry("Remote port out of range", "8443:70000", "70000 isn't between 1 and 65535"),
+		Entry("Empty remote host", "8443::6443", "remote host of port forward '8443::6443' is empty"),
+	)
+
+	It("Rejects mappings that use the same local port", func() {
+		_, err := parsePortForwards([]string{"8443:6443", "9090:9090", "8443:443"})
+		Expect(err).To(MatchError(
+			"local port 8443 is used by port forwards '8443:6443' and '8443:443'",
+		))
+	})
+
+	It("Rejects local ports that are already in use", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		defer listener.Close()
+		port := listener.Addr().(*net.TCPAddr).Port
+		err = checkLocalPort(&portForward{LocalPort: port})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("is already in use"))
+	})
+})
@@ -18,22 +18,59 @@ package whoami
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 
+	sdk "github.com/openshift-online/ocm-sdk-go"
 	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	"github.com/spf13/cobra"
+	"gitlab.com/c0b/go-ordered-json"
 
+	acc_util "github.com/openshift-online/ocm-cli/pkg/account"
 	"github.com/openshift-online/ocm-cli/pkg/dump"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
 )
 
+var args struct {
+	showRoles   bool
+	impersonate string
+}
+
 var Cmd = &cobra.Command{
 	Use:   "whoami",
 	Short: "Prints user information",
-	Long:  "Prints user information.",
-	Args:  cobra.NoArgs,
-	RunE:  run,
+	Long: "Prints user information.\n\n" +
+		"Use the '--impersonate' option to print the account that requests would act as when " +
+		"impersonating another user. Impersonation requires elevated privileges that are " +
+		"granted only to support staff, and the OCM API rejects the request when the caller " +
+		"doesn't have them.",
+	Example: `  # Print the current account
+  ocm whoami
+
+  # Print the account that would be used when impersonating 'my-user'
+  ocm whoami --impersonate my-user`,
+	Args: cobra.NoArgs,
+	RunE: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.BoolVar(
+		&args.showRoles,
+		"show-roles",
+		false,
+		"Add to the output a 'roles' field containing the roles granted to the account by its "+
+			"role bindings.",
+	)
+	flags.StringVar(
+		&args.impersonate,
+		"impersonate",
+		"",
+		"Print the account resolved when impersonating the given user, to check the identity "+
+			"before acting as it. Requires elevated privileges, which are enforced by the server.",
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) error {
@@ -46,9 +83,18 @@ func run(cmd *cobra.Command, argv []string) error {
 	defer connection.Close()
 
 	// Send the request:
-	response, err := connection.AccountsMgmt().V1().CurrentAccount().Get().
-		Send()
+	request := connection.AccountsMgmt().V1().CurrentAccount().Get()
+	if args.impersonate != "" {
+		request.Impersonate(args.impersonate)
+	}
+	response, err := request.Send()
 	if err != nil {
+		if args.impersonate != "" && response.Status() == http.StatusForbidden {
+			return fmt.Errorf(
+				"Can't impersonate user '%s', elevated privileges are required: %v",
+				args.impersonate, err,
+			)
+		}
 		return fmt.Errorf("Can't send request: %v", err)
 	}
 
@@ -61,10 +107,18 @@ func run(cmd *cobra.Command, argv []string) error {
 		return fmt.Errorf("Failed to marshal account into JSON encoder: %v", err)
 	}
 
+	body := buf.Bytes()
+	if args.showRoles {
+		body, err = addRoles(connection, response.Body(), body)
+		if err != nil {
+			return err
+		}
+	}
+
 	if response.Status() < 400 {
-		err = dump.Pretty(os.Stdout, buf.Bytes())
+		err = dump.Pretty(os.Stdout, body)
 	} else {
-		err = dump.Pretty(os.Stderr, buf.Bytes())
+		err = dump.Pretty(os.Stderr, body)
 	}
 	if err != nil {
 		return fmt.Errorf("Can't print body: %v", err)
@@ -72,3 +126,23 @@ func run(cmd *cobra.Command, argv []string) error {
 
 	return nil
 }
+
+// addRoles adds to the JSON representation of the account a 'roles' field containing the
+// identifiers of the roles granted by the role bindings of the account.
+func addRoles(connection *sdk.Connection, account *amsv1.Account, body []byte) ([]byte, error) {
+	roles, err := acc_util.GetRolesFromUsers([]*amsv1.Account{account}, connection)
+	if err != nil {
+		return nil, err
+	}
+	accountRoles := roles[account]
+	if accountRoles == nil {
+		accountRoles = []string{}
+	}
+	data := ordered.NewOrderedMap()
+	err = json.Unmarshal(body, data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse account: %v", err)
+	}
+	data.Set("roles", accountRoles)
+	return json.Marshal(data)
+}
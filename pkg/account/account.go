@@ -19,6 +19,7 @@ package account
 import (
 	"bytes"
 	"fmt"
+	"strings"
 
 	"github.com/openshift-online/ocm-sdk-go"
 	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
@@ -89,6 +90,102 @@ func GetRolesFromUsers(accounts []*amv1.Account,
 	return
 }
 
+// GetQuotaCosts returns the quota costs of the given organization, including the resources that
+// they apply to. If the organization identifier is empty it uses the organization of the current
+// account.
+func GetQuotaCosts(conn *sdk.Connection, orgID string) ([]*amv1.QuotaCost, error) {
+	orgID, err := GetOrganizationID(conn, orgID)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.AccountsMgmt().V1().Organizations().Organization(orgID).QuotaCost().
+		List().
+		Parameter("fetchRelatedResources", true).
+		Send()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve quota: %v", err)
+	}
+	return response.Items().Slice(), nil
+}
+
+// FilterQuotaCostsByResourceType returns the quota costs that have at least one related resource
+// of the given type, for example 'cluster', 'add-on' or 'compute.node'.
+func FilterQuotaCostsByResourceType(quotaCosts []*amv1.QuotaCost,
+	resourceType string) []*amv1.QuotaCost {
+	var result []*amv1.QuotaCost
+	for _, quotaCost := range quotaCosts {
+		for _, relatedResource := range quotaCost.RelatedResources() {
+			if relatedResource.ResourceType() == resourceType {
+				result = append(result, quotaCost)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// GetOrganizationID returns the given organization identifier, or the identifier of the
+// organization of the current account if it is empty.
+func GetOrganizationID(conn *sdk.Connection, orgID string) (string, error) {
+	if orgID != "" {
+		return orgID, nil
+	}
+	response, err := conn.AccountsMgmt().V1().CurrentAccount().Get().Send()
+	if err != nil {
+		return "", fmt.Errorf("Can't retrieve current user information: %v", err)
+	}
+	return response.Body().Organization().ID(), nil
+}
+
+// GetAccountID returns the given account identifier, or the identifier of the current account if
+// it is empty.
+func GetAccountID(conn *sdk.Connection, accountID string) (string, error) {
+	if accountID != "" {
+		return accountID, nil
+	}
+	response, err := conn.AccountsMgmt().V1().CurrentAccount().Get().Send()
+	if err != nil {
+		return "", fmt.Errorf("Can't retrieve current user information: %v", err)
+	}
+	return response.Body().ID(), nil
+}
+
+// GetAccountLabels returns all the labels of the given account.
+func GetAccountLabels(conn *sdk.Connection, accountID string) ([]*amv1.Label, error) {
+	var labels []*amv1.Label
+	request := conn.AccountsMgmt().V1().Accounts().Account(accountID).Labels().List()
+	size := 100
+	page := 1
+	for {
+		response, err := request.Size(size).Page(page).Send()
+		if err != nil {
+			return nil, fmt.Errorf("Can't retrieve labels of account '%s': %v", accountID, err)
+		}
+		labels = append(labels, response.Items().Slice()...)
+		if response.Size() < size {
+			break
+		}
+		page++
+	}
+	return labels, nil
+}
+
+// ParseLabel parses a label with the syntax 'KEY=VALUE'. The value may be empty, but the key may
+// not.
+func ParseLabel(text string) (key, value string, err error) {
+	equals := strings.Index(text, "=")
+	if equals == -1 {
+		err = fmt.Errorf("Label '%s' isn't valid, expected 'KEY=VALUE'", text)
+		return
+	}
+	key = strings.TrimSpace(text[:equals])
+	value = text[equals+1:]
+	if key == "" {
+		err = fmt.Errorf("Label '%s' isn't valid, the key is empty", text)
+	}
+	return
+}
+
 // stringInList returns a bool signifying whether
 // a string is in a string array.
 func stringInList(strArr []string, key string) bool {
@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions to remember the answers given to the interactive prompts, so that
+// they can be used as defaults when the command is run again.
+
+package arguments
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/pflag"
+)
+
+// answers contains the answers saved by the current and previous runs of the command, indexed by
+// flag name. It is nil unless ResumeAnswers has been called.
+var answers map[string]string
+
+// answersFile is the state file where the answers are saved.
+var answersFile string
+
+// ResumeAnswers makes the interactive prompts save the answers to a state file, and loads the
+// answers saved by previous runs of the same command, so that the prompts use them as defaults.
+// The name identifies the command. The file is in the cache directory of the user and it is
+// specific to the shell session, so that answers aren't shared by different terminals.
+func ResumeAnswers(name string) error {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return fmt.Errorf("Can't find cache directory for answers file: %v", err)
+	}
+	answersDir := filepath.Join(cacheDir, "ocm")
+	err = os.MkdirAll(answersDir, 0700)
+	if err != nil {
+		return fmt.Errorf("Can't create directory '%s' for answers file: %v", answersDir, err)
+	}
+	answersFile = filepath.Join(answersDir, fmt.Sprintf("%s-answers-%d.json", name, os.Getppid()))
+	answers = map[string]string{}
+	info, err := os.Stat(answersFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Can't check answers file '%s': %v", answersFile, err)
+	}
+	// Answers written by other users can't be trusted:
+	err = checkOwner(info)
+	if err != nil {
+		return fmt.Errorf("Can't use answers file '%s': %v", answersFile, err)
+	}
+	// #nosec G304
+	data, err := os.ReadFile(answersFile)
+	if err != nil {
+		return fmt.Errorf("Can't read answers file '%s': %v", answersFile, err)
+	}
+	err = json.Unmarshal(data, &answers)
+	if err != nil || answers == nil {
+		// A damaged file shouldn't prevent starting over:
+		answers = map[string]string{}
+	}
+	return nil
+}
+
+// ClearAnswers removes the state file containing the saved answers. It does nothing unless
+// ResumeAnswers has been called.
+func ClearAnswers() error {
+	if answers == nil {
+		return nil
+	}
+	answers = map[string]string{}
+	err := os.Remove(answersFile)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Can't remove answers file '%s': %v", answersFile, err)
+	}
+	return nil
+}
+
+// SavedAnswer returns the answer saved for the given flag, if any.
+func SavedAnswer(flagName string) (value string, ok bool) {
+	value, ok = answers[flagName]
+	return
+}
+
+// SaveAnswer saves the answer given for the given flag in the state file. It does nothing unless
+// ResumeAnswers has been called.
+func SaveAnswer(flagName string, value string) error {
+	if answers == nil {
+		return nil
+	}
+	answers[flagName] = value
+	data, err := json.MarshalIndent(answers, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Can't marshal answers: %v", err)
+	}
+	err = os.WriteFile(answersFile, data, 0600)
+	if err != nil {
+		return fmt.Errorf("Can't write answers file '%s': %v", answersFile, err)
+	}
+	return nil
+}
+
+// applySavedAnswer sets the value of the flag to the answer saved for it, if any, without marking
+// the flag as changed, so that the prompt uses it as default. Returns true if it was applied.
+func applySavedAnswer(flag *pflag.Flag) bool {
+	value, ok := SavedAnswer(flag.Name)
+	if !ok {
+		return false
+	}
+	return flag.Value.Set(value) == nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package arguments
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+)
+
+var _ = Describe("Saved answers", func() {
+	BeforeEach(func() {
+		home := GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", home)
+		GinkgoT().Setenv("XDG_CACHE_HOME", home)
+		GinkgoT().Setenv("LocalAppData", home)
+		DeferCleanup(func() {
+			answers = nil
+			answersFile = ""
+		})
+	})
+
+	It("Doesn't save answers unless resuming has been enabled", func() {
+		Expect(SaveAnswer("region", "us-east-1")).To(Succeed())
+		_, ok := SavedAnswer("region")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("Loads the answers saved by a previous run", func() {
+		Expect(ResumeAnswers("test")).To(Succeed())
+		Expect(SaveAnswer("region", "us-east-1")).To(Succeed())
+		Expect(answersFile).To(BeAnExistingFile())
+
+		// Simulate a new run of the command:
+		answers = nil
+		Expect(ResumeAnswers("test")).To(Succeed())
+		value, ok := SavedAnswer("region")
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("us-east-1"))
+	})
+
+	It("Applies the saved answer without marking the flag as changed", func() {
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.String("region", "", "")
+		Expect(ResumeAnswers("test")).To(Succeed())
+		Expect(SaveAnswer("region", "us-east-1")).To(Succeed())
+
+		flag := fs.Lookup("region")
+		Expect(applySavedAnswer(flag)).To(BeTrue())
+		Expect(flag.Value.String()).To(Equal("us-east-1"))
+		Expect(flag.Changed).To(BeFalse())
+	})
+
+	It("Removes the state file when cleared", func() {
+		Expect(ResumeAnswers("test")).To(Succeed())
+		Expect(SaveAnswer("region", "us-east-1")).To(Succeed())
+		Expect(ClearAnswers()).To(Succeed())
+		Expect(answersFile).ToNot(BeAnExistingFile())
+		_, ok := SavedAnswer("region")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("Saves the answers in a private file in the cache directory", func() {
+		if runtime.GOOS == "windows" {
+			Skip("File permissions aren't supported in Windows")
+		}
+		Expect(ResumeAnswers("test")).To(Succeed())
+		Expect(SaveAnswer("region", "us-east-1")).To(Succeed())
+		cacheDir, err := os.UserCacheDir()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filepath.Dir(answersFile)).To(Equal(filepath.Join(cacheDir, "ocm")))
+		dirInfo, err := os.Stat(filepath.Dir(answersFile))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dirInfo.Mode().Perm()).To(Equal(os.FileMode(0700)))
+		fileInfo, err := os.Stat(answersFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fileInfo.Mode().Perm()).To(Equal(os.FileMode(0600)))
+	})
+})
@@ -19,19 +19,30 @@ limitations under the License.
 package arguments
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"os"
 	"reflect"
 	"strings"
+	"time"
 
 	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
 
+	"github.com/openshift-online/ocm-cli/pkg/ca"
 	"github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/config"
 	"github.com/openshift-online/ocm-cli/pkg/debug"
+	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	conn "github.com/openshift-online/ocm-cli/pkg/ocm/connection-builder"
 	"github.com/openshift-online/ocm-cli/pkg/output"
+	"github.com/openshift-online/ocm-cli/pkg/proxy"
+	"github.com/openshift-online/ocm-cli/pkg/utils"
 )
 
 type FilePath string
@@ -53,11 +64,45 @@ func (f *FilePath) Type() string {
 	return "filepath"
 }
 
+// Version is a flag type for OpenShift versions. It accepts versions with or without the
+// `openshift-v` prefix, and always stores them without it, so that both forms can be used
+// interchangeably in the command line and in interactive mode.
+type Version string
+
+func (v *Version) String() string {
+	return string(*v)
+}
+
+func (v *Version) Set(value string) error {
+	*v = Version(cluster.DropOpenshiftVPrefix(strings.TrimSpace(value)))
+	return nil
+}
+
+// Type returns `string` so that the value can still be retrieved with `GetString`.
+func (v *Version) Type() string {
+	return "string"
+}
+
 // AddDebugFlag adds the '--debug' flag to the given set of command line flags.
 func AddDebugFlag(fs *pflag.FlagSet) {
 	debug.AddFlag(fs)
 }
 
+// AddOCMProxyFlag adds the '--ocm-proxy' flag to the given set of command line flags.
+func AddOCMProxyFlag(fs *pflag.FlagSet) {
+	proxy.AddFlag(fs)
+}
+
+// AddCAFileFlag adds the '--ca-file' flag to the given set of command line flags.
+func AddCAFileFlag(fs *pflag.FlagSet) {
+	ca.AddFlag(fs)
+}
+
+// AddConfigFlag adds the '--config' flag to the given set of command line flags.
+func AddConfigFlag(fs *pflag.FlagSet) {
+	config.AddFlag(fs)
+}
+
 // AddParameterFlag adds the '--parameter' flag to the given set of command line flags.
 func AddParameterFlag(fs *pflag.FlagSet, values *[]string) {
 	fs.StringArrayVarP(
@@ -69,7 +114,9 @@ func AddParameterFlag(fs *pflag.FlagSet, values *[]string) {
 			"parameter, followed by an optional equals sign and then the value "+
 			"of the parameter. Can be used multiple times to specify multiple "+
 			"parameters or multiple values for the same parameter. Example: "+
-			"--parameter search=\"username like 'myname%'\"",
+			"--parameter search=\"username like 'myname%'\". If the value starts with '@' "+
+			"the rest is the name of a file containing one parameter per line, in the same "+
+			"format, ignoring empty lines and lines starting with '#'.",
 	)
 }
 
@@ -82,7 +129,8 @@ func AddHeaderFlag(fs *pflag.FlagSet, values *[]string) {
 		"Headers to add to the request. The value must be the name of the header "+
 			"followed by an optional equals sign and then the value of the "+
 			"header. Can be used multiple times to specify multiple headers "+
-			"or multiple values for the same header.",
+			"or multiple values for the same header. If the value starts with '@' "+
+			"the rest is the name of a file containing one header per line.",
 	)
 }
 
@@ -92,11 +140,241 @@ func AddBodyFlag(fs *pflag.FlagSet, value *string) {
 		value,
 		"body",
 		"",
-		"Name of the file containing the request body. If this isn't given then "+
+		"Name of the file containing the request body, or 'http://' or 'https://' URL "+
+			"where the request body can be downloaded from. If this isn't given then "+
 			"the body will be taken from the standard input.",
 	)
 }
 
+// AddDryRunFlag adds the '--dry-run' flag to the given set of command line flags.
+func AddDryRunFlag(fs *pflag.FlagSet, value *bool) {
+	fs.BoolVar(
+		value,
+		"dry-run",
+		false,
+		"Add the 'dryRun=true' query parameter to the request, so that endpoints that "+
+			"support it validate the request without applying it.",
+	)
+}
+
+// AddVerboseTimingFlag adds the '--verbose-timing' flag to the given set of command line flags.
+func AddVerboseTimingFlag(fs *pflag.FlagSet, value *bool) {
+	fs.BoolVar(
+		value,
+		"verbose-timing",
+		false,
+		"Print the status and duration of the request to the standard error. This is lighter "+
+			"than --debug and doesn't alter the response body written to the standard output.",
+	)
+}
+
+// RetryOptions contains the values of the '--retry' and '--retry-on' command line flags.
+type RetryOptions struct {
+	// Attempts is the number of times that a failed request is retried.
+	Attempts int
+
+	// On contains the classes of errors that cause a retry.
+	On []string
+}
+
+// Classes of errors that can be given with the '--retry-on' flag:
+const (
+	RetryOn5xx        = "5xx"
+	RetryOn429        = "429"
+	RetryOnConnection = "connection"
+)
+
+// RetryClasses are the classes of errors that can be given with the '--retry-on' flag.
+var RetryClasses = []string{
+	RetryOn5xx,
+	RetryOn429,
+	RetryOnConnection,
+}
+
+// DefaultRetryAttempts is the number of retries used when the '--retry-on' flag is given without
+// the '--retry' flag.
+const DefaultRetryAttempts = 3
+
+const (
+	retryInitialDelay = time.Second
+	retryTimeout      = 10 * time.Minute
+)
+
+// AddRetryFlags adds the '--retry' and '--retry-on' flags to the given set of command line flags.
+func AddRetryFlags(fs *pflag.FlagSet, value *RetryOptions) {
+	fs.IntVar(
+		&value.Attempts,
+		"retry",
+		0,
+		"Number of times to retry the request, with exponential backoff, when it fails with "+
+			"one of the errors given with --retry-on. POST and PATCH requests are only "+
+			"retried when this flag is given explicitly. When requests are retried the "+
+			"automatic retries of 429 and 503 responses, and of 5xx responses to GET requests, "+
+			"are disabled, so this is the total number of retries.",
+	)
+	fs.StringSliceVar(
+		&value.On,
+		"retry-on",
+		[]string{RetryOn5xx, RetryOnConnection},
+		fmt.Sprintf(
+			"Comma-separated list of the errors that cause a retry, from: %s. If given "+
+				"without --retry idempotent requests are retried %d times.",
+			strings.Join(RetryClasses, ", "), DefaultRetryAttempts,
+		),
+	)
+}
+
+// CheckRetryFlags checks the values of the '--retry' and '--retry-on' flags for a request with
+// the given method. When '--retry-on' is given without '--retry' the number of attempts is set to
+// the default, unless the method isn't idempotent: POST and PATCH requests are only retried when
+// the number of attempts is given explicitly.
+func CheckRetryFlags(fs *pflag.FlagSet, value *RetryOptions, method string) error {
+	if value.Attempts < 0 {
+		return fmt.Errorf("--retry must be zero or positive, but it is %d", value.Attempts)
+	}
+	for _, class := range value.On {
+		if !isRetryClass(class) {
+			return fmt.Errorf(
+				"Invalid --retry-on class '%s', valid values are: %s",
+				class, strings.Join(RetryClasses, ", "),
+			)
+		}
+	}
+	idempotent := method != http.MethodPost && method != http.MethodPatch
+	if !fs.Changed("retry") && fs.Changed("retry-on") && idempotent {
+		value.Attempts = DefaultRetryAttempts
+	}
+	return nil
+}
+
+func isRetryClass(class string) bool {
+	for _, valid := range RetryClasses {
+		if class == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyRetryFlags configures the given connection builder so that, when the '--retry' flag
+// requests retries, failed requests are retried only by SendRequestWithRetry and not also by the
+// SDK.
+func ApplyRetryFlags(builder *conn.ConnectionBuilder, value RetryOptions) *conn.ConnectionBuilder {
+	if value.Attempts > 0 {
+		builder.WithRetryLimit(0)
+	}
+	return builder
+}
+
+// SendRequestWithRetry sends the given request like SendRequest, retrying it with exponential
+// backoff while it fails with one of the classes of errors of the retry options and there are
+// attempts left. The response or error of the last attempt is returned.
+func SendRequestWithRetry(request *sdk.Request, verboseTiming bool,
+	retry RetryOptions) (response *sdk.Response, err error) {
+	attempt := 0
+	retryable := fmt.Errorf("request can be retried")
+	// The only error that the retry function can return is the one that requests another
+	// attempt, and if the timeout is reached the result of the last attempt is used anyhow:
+	_ = utils.RetryWithBackoffandTimeout(func() error {
+		response, err = SendRequest(request, verboseTiming)
+		if attempt >= retry.Attempts || !shouldRetry(retry.On, response, err) {
+			return nil
+		}
+		attempt++
+		return retryable
+	}, retryInitialDelay, retryTimeout)
+	return response, err
+}
+
+// shouldRetry checks if the result of a request matches one of the given classes of errors.
+func shouldRetry(classes []string, response *sdk.Response, err error) bool {
+	for _, class := range classes {
+		switch {
+		case class == RetryOnConnection && err != nil:
+			return true
+		case class == RetryOn5xx && err == nil && response.Status() >= 500:
+			return true
+		case class == RetryOn429 && err == nil && response.Status() == http.StatusTooManyRequests:
+			return true
+		}
+	}
+	return false
+}
+
+// AddOutputFileFlag adds the '--output-file' flag to the given set of command line flags.
+func AddOutputFileFlag(fs *pflag.FlagSet, value *string) {
+	fs.StringVar(
+		value,
+		"output-file",
+		"",
+		"Name of the file where the response body will be written instead of the standard "+
+			"output. Parent directories are created if needed.",
+	)
+}
+
+// AddOutputFlag adds the '--output' flag, that selects the output format of list commands, to the
+// given set of command line flags.
+func AddOutputFlag(fs *pflag.FlagSet) {
+	output.AddFormatFlag(fs)
+}
+
+// AddWatchFlag adds the '--watch' flag, that refreshes the output of list commands periodically,
+// to the given set of command line flags. When the flag is used without a value the default
+// interval is used. It is only added to the commands that list objects whose state changes while
+// the user waits, like clusters, add-ons, machine pools and upgrade policies, and not to the ones
+// that list mostly static data, like regions, versions or organizations.
+func AddWatchFlag(fs *pflag.FlagSet, value *time.Duration) {
+	fs.DurationVar(
+		value,
+		"watch",
+		0,
+		fmt.Sprintf(
+			"Clear the screen and list again periodically, till interrupted with Ctrl+C. "+
+				"The interval can be given with '--watch=INTERVAL', for example '--watch=30s', "+
+				"and the default is %s.",
+			output.DefaultWatchInterval,
+		),
+	)
+	fs.Lookup("watch").NoOptDefVal = output.DefaultWatchInterval.String()
+}
+
+// CheckWatchFlag checks the value of the '--watch' flag. The interval can't be too short, and the
+// flag can only be used with tabular output formats.
+func CheckWatchFlag(value time.Duration, format output.Format) error {
+	if value == 0 {
+		return nil
+	}
+	if value < output.MinWatchInterval {
+		return fmt.Errorf(
+			"Invalid --watch: interval must be at least %s, but it is %s",
+			output.MinWatchInterval, value,
+		)
+	}
+	if format.IsStructured() {
+		return fmt.Errorf("Flag --watch can't be used with output format '%s'", format)
+	}
+	return nil
+}
+
+// AddCountFlag adds the '--count' flag, that adds a footer row with the number of listed items to
+// the output of list commands, to the given set of command line flags.
+func AddCountFlag(fs *pflag.FlagSet, value *bool) {
+	fs.BoolVar(
+		value,
+		"count",
+		false,
+		"Add a footer row with the total number of items listed.",
+	)
+}
+
+// CheckCountFlag checks that the '--count' flag is only used with tabular output formats.
+func CheckCountFlag(value bool, format output.Format) error {
+	if value && format.IsStructured() {
+		return fmt.Errorf("Flag --count can't be used with output format '%s'", format)
+	}
+	return nil
+}
+
 // AddCCSFlagsWithoutAccountID is sufficient for list regions command.
 func AddCCSFlagsWithoutAccountID(fs *pflag.FlagSet, value *cluster.CCS) {
 	fs.BoolVar(
@@ -170,6 +448,7 @@ func CheckIgnoredCCSFlags(ccs cluster.CCS, fs *pflag.FlagSet) error {
 // CheckIgnoredProviderFlags errors if provider-specific flags were used without the corresponding provider.
 func CheckIgnoredProviderFlags(fs *pflag.FlagSet, provider string) error {
 	gcpExclusiveFlags := []string{
+		"audit-log-forwarding",
 		"marketplace-gcp-terms",
 		"psc-subnet",
 		"secure-boot-for-shielded-vms",
@@ -186,6 +465,9 @@ func CheckIgnoredProviderFlags(fs *pflag.FlagSet, provider string) error {
 		"additional-infra-security-group-ids",
 		"additional-control-plane-security-group-ids",
 		"additional-trust-bundle-file",
+		"etcd-kms-key-arn",
+		"imds",
+		"private-link",
 		"subnet-ids",
 	}
 
@@ -341,7 +623,7 @@ func AddAutoscalingFlags(fs *pflag.FlagSet, value *cluster.Autoscaling) {
 		false,
 		"Enable autoscaling of compute nodes.",
 	)
-	SetQuestion(fs, "enable-autoscaing", "Enable autoscaling:")
+	SetQuestion(fs, "enable-autoscaling", "Enable autoscaling:")
 
 	fs.IntVar(
 		&value.MinReplicas,
@@ -361,10 +643,11 @@ func AddAutoscalingFlags(fs *pflag.FlagSet, value *cluster.Autoscaling) {
 }
 
 // CheckAutoscalingFlags errors if --min-replicas or --max-replicas
-// were used without --enable-autoscaling (and vice-versa with --compute-nodes)
+// were used without --enable-autoscaling (and vice-versa with --compute-nodes,
+// even when it is explicitly set to zero)
 // It also errors if --min-replicas or --max-replicas were not supplied
 // when --enable-autoscaling is used
-func CheckAutoscalingFlags(autoscaling cluster.Autoscaling, computeNodes int) error {
+func CheckAutoscalingFlags(fs *pflag.FlagSet, autoscaling cluster.Autoscaling, computeNodes int) error {
 	if !autoscaling.Enabled {
 		bad := []string{}
 		if autoscaling.MinReplicas != 0 {
@@ -380,8 +663,11 @@ func CheckAutoscalingFlags(autoscaling cluster.Autoscaling, computeNodes int) er
 				strings.Join(bad, ", "))
 		}
 	} else {
-		if computeNodes != 0 {
-			return fmt.Errorf("--compute-nodes is meaningless with --enable-autoscaling")
+		if computeNodes != 0 || fs.Changed("compute-nodes") {
+			return fmt.Errorf(
+				"--compute-nodes is not allowed with --enable-autoscaling, " +
+					"use --min-replicas and --max-replicas instead",
+			)
 		}
 
 		if autoscaling.MinReplicas == 0 {
@@ -396,6 +682,46 @@ func CheckAutoscalingFlags(autoscaling cluster.Autoscaling, computeNodes int) er
 	return nil
 }
 
+// AddWaitFlags adds the --wait, --wait-interval and --wait-timeout flags. The usage is the help
+// text of the --wait flag, describing what the command waits for.
+func AddWaitFlags(fs *pflag.FlagSet, value *ocm.WaitOptions, usage string) {
+	fs.BoolVar(
+		&value.Enabled,
+		"wait",
+		false,
+		usage,
+	)
+	fs.DurationVar(
+		&value.Interval,
+		"wait-interval",
+		ocm.DefaultWaitInterval,
+		"Time between checks when waiting, for example '10s'. Requires --wait.",
+	)
+	fs.DurationVar(
+		&value.Timeout,
+		"wait-timeout",
+		ocm.DefaultWaitTimeout,
+		"Maximum time to wait, for example '30m'. Requires --wait.",
+	)
+}
+
+// CheckWaitFlags errors if --wait-interval or --wait-timeout were used without --wait, or if
+// their values aren't positive.
+func CheckWaitFlags(fs *pflag.FlagSet, value ocm.WaitOptions) error {
+	for _, name := range []string{"wait-interval", "wait-timeout"} {
+		if fs.Changed(name) && !value.Enabled {
+			return fmt.Errorf("--%s flag is meaningless without --wait", name)
+		}
+	}
+	if value.Interval <= 0 {
+		return fmt.Errorf("--wait-interval must be positive, but it is %s", value.Interval)
+	}
+	if value.Timeout <= 0 {
+		return fmt.Errorf("--wait-timeout must be positive, but it is %s", value.Timeout)
+	}
+	return nil
+}
+
 func AddProviderFlag(fs *pflag.FlagSet, value *string) {
 	fs.StringVar(
 		value,
@@ -408,22 +734,28 @@ func AddProviderFlag(fs *pflag.FlagSet, value *string) {
 
 // ApplyParameterFlag applies the value of the '--parameter' command line flag to the given
 // request.
-func ApplyParameterFlag(request interface{}, values []string) {
-	applyNVFlag(request, "Parameter", values)
+func ApplyParameterFlag(request interface{}, values []string) error {
+	return applyNVFlag(request, "Parameter", values)
 }
 
 // ApplyHeaderFlag applies the value of the '--header' command line flag to the given request.
-func ApplyHeaderFlag(request interface{}, values []string) {
-	applyNVFlag(request, "Header", values)
+func ApplyHeaderFlag(request interface{}, values []string) error {
+	return applyNVFlag(request, "Header", values)
 }
 
 // applyNVFlag finds the method with the given name in a request and calls it to set a collection of
-// name value pairs.
-func applyNVFlag(request interface{}, method string, values []string) {
+// name value pairs. Values starting with '@' are the names of files containing the name value pairs.
+func applyNVFlag(request interface{}, method string, values []string) error {
 	// Find the method:
 	callable := reflect.ValueOf(request).MethodByName(method)
 	if !callable.IsValid() {
-		return
+		return nil
+	}
+
+	// Replace the file names with the name value pairs that they contain:
+	values, err := expandNVFiles(strings.ToLower(method), values)
+	if err != nil {
+		return err
 	}
 
 	// Split the values into name value pairs and call the method for each one:
@@ -436,13 +768,67 @@ func applyNVFlag(request interface{}, method string, values []string) {
 		}
 		callable.Call(args)
 	}
+	return nil
+}
+
+// expandNVFiles replaces the values that start with '@' with the lines of the file named by the
+// rest of the value. Empty lines and lines starting with '#' are ignored.
+func expandNVFiles(kind string, values []string) ([]string, error) {
+	var result []string
+	for _, value := range values {
+		if !strings.HasPrefix(value, "@") {
+			result = append(result, value)
+			continue
+		}
+		file := value[1:]
+		// #nosec G304
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("Can't read %ss file '%s': %v", kind, file, err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			result = append(result, line)
+		}
+	}
+	return result, nil
+}
+
+// SendRequest sends the given request. If the value of the '--verbose-timing' flag is true it also
+// writes the method, path, status and duration of the request to the standard error.
+func SendRequest(request *sdk.Request, verboseTiming bool) (*sdk.Response, error) {
+	start := time.Now()
+	response, err := request.Send()
+	if verboseTiming {
+		duration := time.Since(start).Round(time.Millisecond)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %s failed after %s\n",
+				request.GetMethod(), request.GetPath(), duration)
+		} else {
+			fmt.Fprintf(os.Stderr, "%s %s %d %s\n",
+				request.GetMethod(), request.GetPath(), response.Status(), duration)
+		}
+	}
+	return response, err
+}
+
+// ApplyDryRunFlag applies the value of the '--dry-run' command line flag to the given request.
+func ApplyDryRunFlag(request *sdk.Request, value bool) {
+	if value {
+		request.Parameter("dryRun", "true")
+	}
 }
 
 // ApplyBodyFlag applies the value of the '--body' command line flag to the given request.
 func ApplyBodyFlag(request *sdk.Request, value string) error {
 	var body []byte
 	var err error
-	if value != "" {
+	if isBodyURL(value) {
+		body, err = fetchBody(value)
+	} else if value != "" {
 		// #nosec G304
 		body, err = os.ReadFile(value)
 	} else {
@@ -458,6 +844,49 @@ func ApplyBodyFlag(request *sdk.Request, value string) error {
 	return nil
 }
 
+// bodyURLTimeout is the maximum time that downloading the request body from a URL can take.
+const bodyURLTimeout = 30 * time.Second
+
+// isBodyURL checks if the value of the '--body' command line flag is an HTTP URL instead of the
+// name of a file.
+func isBodyURL(value string) bool {
+	lower := strings.ToLower(value)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// fetchBody downloads the request body from the given URL, using the proxy given with the
+// '--ocm-proxy' command line flag if any, or else the proxy configured in the environment.
+func fetchBody(address string) ([]byte, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxyURL := proxy.URL(); proxyURL != "" {
+		parsed, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid proxy URL '%s': %v", proxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(parsed)
+	}
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   bodyURLTimeout,
+	}
+	response, err := client.Get(address)
+	if err != nil {
+		return nil, fmt.Errorf("Can't download request body from '%s': %v", address, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf(
+			"Can't download request body from '%s': server responded with status '%s'",
+			address, response.Status,
+		)
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Can't download request body from '%s': %v", address, err)
+	}
+	return body, nil
+}
+
 // ApplyPathArg applies the value of the path given in the command line to the given request.
 func ApplyPathArg(request *sdk.Request, value string) error {
 	parsed, err := url.Parse(value)
@@ -478,6 +907,151 @@ func Split(r rune) bool {
 	return r == '=' || r == ':'
 }
 
+// TaintEffects are the effects that Kubernetes accepts for node taints.
+var TaintEffects = []string{
+	"NoSchedule",
+	"PreferNoSchedule",
+	"NoExecute",
+}
+
+// ParseTaints parses the value of the '--taints' command line flag, a comma-separated list of
+// 'key=value:effect' items, checking that the effects are valid.
+func ParseTaints(value string) ([]*cmv1.TaintBuilder, error) {
+	taintBuilders := []*cmv1.TaintBuilder{}
+	if value == "" {
+		return taintBuilders, nil
+	}
+	for _, taint := range strings.Split(value, ",") {
+		equals := strings.Index(taint, "=")
+		colon := strings.LastIndex(taint, ":")
+		if equals == -1 || colon < equals {
+			return nil, fmt.Errorf("Expected key=value:scheduleType format for taints")
+		}
+		key := strings.TrimSpace(taint[:equals])
+		val := strings.TrimSpace(taint[equals+1 : colon])
+		effect := strings.TrimSpace(taint[colon+1:])
+		if key == "" {
+			return nil, fmt.Errorf("Expected key=value:scheduleType format for taints")
+		}
+		taintBuilder, err := newTaint(key, val, effect)
+		if err != nil {
+			return nil, err
+		}
+		taintBuilders = append(taintBuilders, taintBuilder)
+	}
+	return taintBuilders, nil
+}
+
+// newTaint checks the effect of the taint and returns the corresponding builder.
+func newTaint(key, value, effect string) (*cmv1.TaintBuilder, error) {
+	if !isTaintEffect(effect) {
+		return nil, fmt.Errorf(
+			"Invalid effect '%s' for taint '%s', valid values are: %s",
+			effect, key, strings.Join(TaintEffects, ", "),
+		)
+	}
+	return cmv1.NewTaint().Key(key).Value(value).Effect(effect), nil
+}
+
+// ReadLabelsFile reads the value of the '--labels-file' command line flag, a YAML file containing
+// a map of label names to label values.
+func ReadLabelsFile(file string) (map[string]string, error) {
+	labels := map[string]string{}
+	err := readYAMLFile(file, &labels)
+	if err != nil {
+		return nil, fmt.Errorf("Can't read labels file '%s': %v", file, err)
+	}
+	for key := range labels {
+		if strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("Labels file '%s' contains a label with an empty key", file)
+		}
+	}
+	return labels, nil
+}
+
+// ReadTaintsFile reads the value of the '--taints-file' command line flag, a YAML file containing
+// a list of taints, each with a 'key', an optional 'value' and an 'effect'.
+func ReadTaintsFile(file string) ([]*cmv1.TaintBuilder, error) {
+	var taints []struct {
+		Key    string `yaml:"key"`
+		Value  string `yaml:"value"`
+		Effect string `yaml:"effect"`
+	}
+	err := readYAMLFile(file, &taints)
+	if err != nil {
+		return nil, fmt.Errorf("Can't read taints file '%s': %v", file, err)
+	}
+	taintBuilders := []*cmv1.TaintBuilder{}
+	for i, taint := range taints {
+		if strings.TrimSpace(taint.Key) == "" {
+			return nil, fmt.Errorf("Taint %d of taints file '%s' doesn't have a key", i, file)
+		}
+		taintBuilder, err := newTaint(taint.Key, taint.Value, taint.Effect)
+		if err != nil {
+			return nil, err
+		}
+		taintBuilders = append(taintBuilders, taintBuilder)
+	}
+	return taintBuilders, nil
+}
+
+// MergeTaints returns the taints of the base list followed by the given overrides. A taint of the
+// base list is replaced by an override that has the same key and effect.
+func MergeTaints(base, overrides []*cmv1.TaintBuilder) ([]*cmv1.TaintBuilder, error) {
+	identity := func(taintBuilder *cmv1.TaintBuilder) (string, error) {
+		taint, err := taintBuilder.Build()
+		if err != nil {
+			return "", err
+		}
+		return taint.Key() + ":" + taint.Effect(), nil
+	}
+	overridden := map[string]bool{}
+	for _, override := range overrides {
+		id, err := identity(override)
+		if err != nil {
+			return nil, err
+		}
+		overridden[id] = true
+	}
+	result := []*cmv1.TaintBuilder{}
+	for _, taintBuilder := range base {
+		id, err := identity(taintBuilder)
+		if err != nil {
+			return nil, err
+		}
+		if !overridden[id] {
+			result = append(result, taintBuilder)
+		}
+	}
+	return append(result, overrides...), nil
+}
+
+// readYAMLFile decodes the content of the given YAML file into the given value, rejecting fields
+// that the value doesn't have.
+func readYAMLFile(file string, value interface{}) error {
+	// #nosec G304
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err = decoder.Decode(value)
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
+
+func isTaintEffect(effect string) bool {
+	for _, valid := range TaintEffects {
+		if effect == valid {
+			return true
+		}
+	}
+	return false
+}
+
 // ParseNameValuePair parses a name value pair.
 func ParseNameValuePair(text string) (name, value string) {
 	position := strings.Index(text, "=")
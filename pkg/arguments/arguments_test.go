@@ -0,0 +1,535 @@
+package arguments
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	"github.com/openshift-online/ocm-cli/pkg/output"
+)
+
+var _ = Describe("Version flag", func() {
+	parse := func(value string) string {
+		var version string
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.Var((*Version)(&version), "version", "")
+		err := fs.Parse([]string{"--version", value})
+		Expect(err).ToNot(HaveOccurred())
+		return version
+	}
+
+	It("Accepts versions without prefix", func() {
+		Expect(parse("4.15.0")).To(Equal("4.15.0"))
+	})
+
+	It("Removes the prefix", func() {
+		Expect(parse("openshift-v4.15.0")).To(Equal("4.15.0"))
+	})
+
+	It("Produces the same version identifier for both forms", func() {
+		short := cluster.EnsureOpenshiftVPrefix(parse("4.15.0-candidate"))
+		long := cluster.EnsureOpenshiftVPrefix(parse("openshift-v4.15.0-candidate"))
+		Expect(short).To(Equal("openshift-v4.15.0-candidate"))
+		Expect(long).To(Equal(short))
+	})
+
+	It("Can be retrieved as a string", func() {
+		var version string
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.Var((*Version)(&version), "version", "")
+		err := fs.Parse([]string{"--version", "openshift-v4.15.0"})
+		Expect(err).ToNot(HaveOccurred())
+		value, err := fs.GetString("version")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal("4.15.0"))
+	})
+})
+
+var _ = Describe("Option descriptions", func() {
+	options := []Option{
+		{Value: "4.16.1", Description: "default"},
+		{Value: "4.15.9"},
+	}
+
+	It("Returns the description of the option", func() {
+		describe := optionDescriptions(options)
+		Expect(describe("4.16.1", 0)).To(Equal("default"))
+	})
+
+	It("Returns an empty string for options without description", func() {
+		describe := optionDescriptions(options)
+		Expect(describe("4.15.9", 1)).To(BeEmpty())
+	})
+
+	It("Returns an empty string if the index doesn't match the value", func() {
+		describe := optionDescriptions(options)
+		Expect(describe("4.15.9", 0)).To(BeEmpty())
+		Expect(describe("4.16.1", 5)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Options with suggestions", func() {
+	options := []Option{
+		{Value: "m5.xlarge"},
+		{Value: "m5.2xlarge"},
+		{Value: "r5.xlarge"},
+		{Value: "c5.24xlarge"},
+	}
+
+	makeFlags := func(value string) *pflag.FlagSet {
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.String("instance-type", "", "")
+		Expect(fs.Set("instance-type", value)).To(Succeed())
+		return fs
+	}
+
+	It("Accepts a valid option", func() {
+		err := CheckOneOfWithSuggestions(makeFlags("m5.xlarge"), "instance-type", options)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Suggests only the closest options", func() {
+		err := CheckOneOfWithSuggestions(makeFlags("m5.xlarg"), "instance-type", options)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HaveSuffix("Did you mean: m5.xlarge, m5.2xlarge, r5.xlarge"))
+		Expect(err.Error()).ToNot(ContainSubstring("c5.24xlarge"))
+	})
+
+	It("Doesn't list options when none is close", func() {
+		err := CheckOneOfWithSuggestions(makeFlags("huge"), "instance-type", options)
+		Expect(err).To(MatchError(
+			"A valid --instance-type must be specified, 'huge' isn't a valid option",
+		))
+	})
+})
+
+var _ = Describe("Autoscaling flags", func() {
+	makeFlags := func(argv ...string) (*pflag.FlagSet, cluster.Autoscaling, int) {
+		var autoscaling cluster.Autoscaling
+		var computeNodes int
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.IntVar(&computeNodes, "compute-nodes", 0, "")
+		AddAutoscalingFlags(fs, &autoscaling)
+		err := fs.Parse(argv)
+		Expect(err).ToNot(HaveOccurred())
+		return fs, autoscaling, computeNodes
+	}
+
+	It("Accepts autoscaling without compute nodes", func() {
+		fs, autoscaling, computeNodes := makeFlags(
+			"--enable-autoscaling", "--min-replicas", "2", "--max-replicas", "4",
+		)
+		Expect(CheckAutoscalingFlags(fs, autoscaling, computeNodes)).To(Succeed())
+	})
+
+	It("Accepts zero compute nodes without autoscaling", func() {
+		fs, autoscaling, computeNodes := makeFlags("--compute-nodes", "0")
+		Expect(CheckAutoscalingFlags(fs, autoscaling, computeNodes)).To(Succeed())
+	})
+
+	It("Rejects compute nodes explicitly set to zero with autoscaling", func() {
+		fs, autoscaling, computeNodes := makeFlags(
+			"--enable-autoscaling", "--min-replicas", "2", "--max-replicas", "4",
+			"--compute-nodes", "0",
+		)
+		err := CheckAutoscalingFlags(fs, autoscaling, computeNodes)
+		Expect(err).To(MatchError(ContainSubstring(
+			"--compute-nodes is not allowed with --enable-autoscaling",
+		)))
+	})
+})
+
+var _ = Describe("Wait flags", func() {
+	makeFlags := func(argv ...string) (*pflag.FlagSet, ocm.WaitOptions) {
+		var wait ocm.WaitOptions
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		AddWaitFlags(fs, &wait, "")
+		err := fs.Parse(argv)
+		Expect(err).ToNot(HaveOccurred())
+		return fs, wait
+	}
+
+	It("Uses the default interval and timeout", func() {
+		fs, wait := makeFlags("--wait")
+		Expect(CheckWaitFlags(fs, wait)).To(Succeed())
+		Expect(wait.Interval).To(Equal(ocm.DefaultWaitInterval))
+		Expect(wait.Timeout).To(Equal(ocm.DefaultWaitTimeout))
+	})
+
+	It("Accepts custom interval and timeout", func() {
+		fs, wait := makeFlags("--wait", "--wait-interval", "5s", "--wait-timeout", "10m")
+		Expect(CheckWaitFlags(fs, wait)).To(Succeed())
+		Expect(wait.Interval).To(Equal(5 * time.Second))
+		Expect(wait.Timeout).To(Equal(10 * time.Minute))
+	})
+
+	It("Rejects timeout without wait", func() {
+		fs, wait := makeFlags("--wait-timeout", "10m")
+		Expect(CheckWaitFlags(fs, wait)).To(MatchError(
+			"--wait-timeout flag is meaningless without --wait",
+		))
+	})
+
+	It("Rejects an interval that isn't positive", func() {
+		fs, wait := makeFlags("--wait", "--wait-interval", "0s")
+		Expect(CheckWaitFlags(fs, wait)).To(MatchError(
+			"--wait-interval must be positive, but it is 0s",
+		))
+	})
+})
+
+var _ = Describe("Watch flag", func() {
+	parse := func(argv ...string) time.Duration {
+		var watch time.Duration
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		AddWatchFlag(fs, &watch)
+		err := fs.Parse(argv)
+		Expect(err).ToNot(HaveOccurred())
+		return watch
+	}
+
+	It("Is disabled by default", func() {
+		watch := parse()
+		Expect(watch).To(BeZero())
+		Expect(CheckWatchFlag(watch, output.FormatTable)).To(Succeed())
+	})
+
+	It("Uses the default interval without a value", func() {
+		watch := parse("--watch")
+		Expect(watch).To(Equal(output.DefaultWatchInterval))
+		Expect(CheckWatchFlag(watch, output.FormatWide)).To(Succeed())
+	})
+
+	It("Accepts a custom interval", func() {
+		watch := parse("--watch=30s")
+		Expect(watch).To(Equal(30 * time.Second))
+		Expect(CheckWatchFlag(watch, output.FormatTable)).To(Succeed())
+	})
+
+	It("Rejects intervals that are too short", func() {
+		watch := parse("--watch=100ms")
+		Expect(CheckWatchFlag(watch, output.FormatTable)).To(MatchError(
+			"Invalid --watch: interval must be at least 1s, but it is 100ms",
+		))
+	})
+
+	It("Rejects structured output formats", func() {
+		watch := parse("--watch")
+		Expect(CheckWatchFlag(watch, output.FormatJSON)).To(MatchError(
+			"Flag --watch can't be used with output format 'json'",
+		))
+	})
+})
+
+var _ = Describe("Taints", func() {
+	DescribeTable("Accepts valid effects",
+		func(effect string) {
+			taints, err := ParseTaints("my-key=my-value:" + effect)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(taints).To(HaveLen(1))
+			taint, err := taints[0].Build()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(taint.Key()).To(Equal("my-key"))
+			Expect(taint.Value()).To(Equal("my-value"))
+			Expect(taint.Effect()).To(Equal(effect))
+		},
+		Entry("NoSchedule", "NoSchedule"),
+		Entry("PreferNoSchedule", "PreferNoSchedule"),
+		Entry("NoExecute", "NoExecute"),
+	)
+
+	DescribeTable("Rejects invalid effects",
+		func(value string, effect string) {
+			_, err := ParseTaints(value)
+			Expect(err).To(MatchError(
+				"Invalid effect '" + effect + "' for taint 'my-key', " +
+					"valid values are: NoSchedule, PreferNoSchedule, NoExecute",
+			))
+		},
+		Entry("Unknown effect", "my-key=my-value:NoRun", "NoRun"),
+		Entry("Wrong case", "my-key=my-value:noschedule", "noschedule"),
+		Entry("Empty effect", "my-key=my-value:", ""),
+	)
+
+	It("Rejects taints without effect", func() {
+		_, err := ParseTaints("my-key=my-value")
+		Expect(err).To(MatchError("Expected key=value:scheduleType format for taints"))
+	})
+
+	It("Accepts taints with empty value", func() {
+		taints, err := ParseTaints("my-key=:NoSchedule")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(taints).To(HaveLen(1))
+	})
+
+	It("Parses multiple taints", func() {
+		taints, err := ParseTaints("a=b:NoSchedule,c=d:NoExecute")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(taints).To(HaveLen(2))
+	})
+
+	It("Returns an empty list for an empty value", func() {
+		taints, err := ParseTaints("")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(taints).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Name value files", func() {
+	var file string
+
+	BeforeEach(func() {
+		file = filepath.Join(GinkgoT().TempDir(), "parameters")
+	})
+
+	It("Replaces the file with its lines, ignoring comments and empty lines", func() {
+		err := os.WriteFile(file, []byte(
+			"# Clusters in AWS\n"+
+				"search=cloud_provider.id = 'aws'\n"+
+				"\n"+
+				"  order=name asc  \n",
+		), 0600)
+		Expect(err).ToNot(HaveOccurred())
+		values, err := expandNVFiles("parameter", []string{"size=10", "@" + file})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(values).To(Equal([]string{
+			"size=10",
+			"search=cloud_provider.id = 'aws'",
+			"order=name asc",
+		}))
+	})
+
+	It("Fails if the file doesn't exist", func() {
+		_, err := expandNVFiles("parameter", []string{"@" + file})
+		Expect(err).To(MatchError(ContainSubstring("Can't read parameters file")))
+	})
+})
+
+var _ = Describe("Labels and taints files", func() {
+	var file string
+
+	BeforeEach(func() {
+		file = filepath.Join(GinkgoT().TempDir(), "values.yaml")
+	})
+
+	write := func(content string) {
+		err := os.WriteFile(file, []byte(content), 0600)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("Reads labels", func() {
+		write("foo: bar\nreplicas: 3\n")
+		labels, err := ReadLabelsFile(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(labels).To(Equal(map[string]string{
+			"foo":      "bar",
+			"replicas": "3",
+		}))
+	})
+
+	It("Accepts an empty labels file", func() {
+		write("")
+		labels, err := ReadLabelsFile(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(labels).To(BeEmpty())
+	})
+
+	It("Rejects labels that aren't a map", func() {
+		write("- foo\n- bar\n")
+		_, err := ReadLabelsFile(file)
+		Expect(err).To(MatchError(ContainSubstring("Can't read labels file")))
+	})
+
+	It("Reads taints", func() {
+		write("- key: foo\n  value: bar\n  effect: NoSchedule\n- key: baz\n  effect: NoExecute\n")
+		taints, err := ReadTaintsFile(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(taints).To(HaveLen(2))
+		taint, err := taints[1].Build()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(taint.Key()).To(Equal("baz"))
+		Expect(taint.Value()).To(BeEmpty())
+		Expect(taint.Effect()).To(Equal("NoExecute"))
+	})
+
+	It("Rejects taints with unknown fields", func() {
+		write("- key: foo\n  efect: NoSchedule\n")
+		_, err := ReadTaintsFile(file)
+		Expect(err).To(MatchError(ContainSubstring("field efect not found")))
+	})
+
+	It("Rejects taints without key", func() {
+		write("- value: bar\n  effect: NoSchedule\n")
+		_, err := ReadTaintsFile(file)
+		Expect(err).To(MatchError(ContainSubstring("Taint 0 of taints file")))
+	})
+
+	It("Rejects taints with invalid effects", func() {
+		write("- key: foo\n  effect: NoRun\n")
+		_, err := ReadTaintsFile(file)
+		Expect(err).To(MatchError(
+			"Invalid effect 'NoRun' for taint 'foo', " +
+				"valid values are: NoSchedule, PreferNoSchedule, NoExecute",
+		))
+	})
+
+	It("Replaces taints with the same key and effect when merging", func() {
+		base, err := ParseTaints("a=1:NoSchedule,b=2:NoSchedule,a=3:NoExecute")
+		Expect(err).ToNot(HaveOccurred())
+		overrides, err := ParseTaints("a=4:NoSchedule")
+		Expect(err).ToNot(HaveOccurred())
+		merged, err := MergeTaints(base, overrides)
+		Expect(err).ToNot(HaveOccurred())
+		var values []string
+		for _, taintBuilder := range merged {
+			taint, err := taintBuilder.Build()
+			Expect(err).ToNot(HaveOccurred())
+			values = append(values, taint.Key()+"="+taint.Value()+":"+taint.Effect())
+		}
+		Expect(values).To(Equal([]string{"b=2:NoSchedule", "a=3:NoExecute", "a=4:NoSchedule"}))
+	})
+})
+
+var _ = Describe("Retry flags", func() {
+	parse := func(method string, argv ...string) (RetryOptions, error) {
+		var retry RetryOptions
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		AddRetryFlags(fs, &retry)
+		err := fs.Parse(argv)
+		Expect(err).ToNot(HaveOccurred())
+		err = CheckRetryFlags(fs, &retry, method)
+		return retry, err
+	}
+
+	It("Doesn't retry by default", func() {
+		retry, err := parse(http.MethodGet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(retry.Attempts).To(BeZero())
+		Expect(retry.On).To(Equal([]string{"5xx", "connection"}))
+	})
+
+	It("Uses the default attempts when only --retry-on is given", func() {
+		retry, err := parse(http.MethodGet, "--retry-on", "429")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(retry.Attempts).To(Equal(DefaultRetryAttempts))
+	})
+
+	DescribeTable("Doesn't retry non idempotent methods unless --retry is given",
+		func(method string) {
+			retry, err := parse(method, "--retry-on", "5xx")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(retry.Attempts).To(BeZero())
+			retry, err = parse(method, "--retry", "2")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(retry.Attempts).To(Equal(2))
+		},
+		Entry("POST", http.MethodPost),
+		Entry("PATCH", http.MethodPatch),
+	)
+
+	It("Rejects negative attempts", func() {
+		_, err := parse(http.MethodGet, "--retry", "-1")
+		Expect(err).To(MatchError("--retry must be zero or positive, but it is -1"))
+	})
+})
+
+var _ = Describe("Production confirmation", func() {
+	It("Is skipped when the environment variable is a true value", func() {
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		AddConfirmFlag(fs, new(bool))
+		GinkgoT().Setenv("OCM_URL", "https://api.openshift.com")
+		for _, value := range []string{"true", "1", "TRUE"} {
+			GinkgoT().Setenv("OCM_SKIP_PRODUCTION_CONFIRMATION", value)
+			Expect(ConfirmProduction(fs, "patch '/api'")).To(Succeed())
+		}
+	})
+
+	It("Fails without prompting in production and names the ways to confirm", func() {
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		AddConfirmFlag(fs, new(bool))
+		GinkgoT().Setenv("OCM_URL", "https://api.openshift.com")
+		GinkgoT().Setenv("OCM_SKIP_PRODUCTION_CONFIRMATION", "")
+		err := ConfirmProductionWithoutPrompt(fs, "patch '/api'")
+		Expect(err).To(MatchError(
+			"Refusing to patch '/api' in the production environment 'https://api.openshift.com' " +
+				"without confirmation, use --confirm or set " +
+				"OCM_SKIP_PRODUCTION_CONFIRMATION=true to proceed",
+		))
+	})
+
+	It("Is skipped when --confirm is used", func() {
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		AddConfirmFlag(fs, new(bool))
+		Expect(fs.Parse([]string{"--confirm"})).To(Succeed())
+		GinkgoT().Setenv("OCM_URL", "https://api.openshift.com")
+		GinkgoT().Setenv("OCM_SKIP_PRODUCTION_CONFIRMATION", "")
+		Expect(ConfirmProductionWithoutPrompt(fs, "patch '/api'")).To(Succeed())
+	})
+
+	It("Isn't needed outside of production", func() {
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		AddConfirmFlag(fs, new(bool))
+		GinkgoT().Setenv("OCM_URL", "https://api.stage.openshift.com")
+		GinkgoT().Setenv("OCM_SKIP_PRODUCTION_CONFIRMATION", "")
+		Expect(ConfirmProductionWithoutPrompt(fs, "patch '/api'")).To(Succeed())
+	})
+})
+
+var _ = Describe("Integer prompts", func() {
+	makeFlags := func(argv ...string) (*pflag.FlagSet, *int) {
+		var interactive bool
+		var nodes int
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.BoolVar(&interactive, "interactive", false, "")
+		fs.IntVar(&nodes, "compute-nodes", 2, "")
+		err := fs.Parse(argv)
+		Expect(err).ToNot(HaveOccurred())
+		return fs, &nodes
+	}
+
+	atLeast := func(nodes *int, min int) func() error {
+		return func() error {
+			if *nodes < min {
+				return fmt.Errorf("must be at least %d", min)
+			}
+			return nil
+		}
+	}
+
+	It("Keeps the default when the flag isn't given", func() {
+		fs, nodes := makeFlags()
+		Expect(PromptInt(fs, "compute-nodes", atLeast(nodes, 3))).To(Succeed())
+		Expect(*nodes).To(Equal(2))
+	})
+
+	It("Accepts a valid value given in the command line", func() {
+		fs, nodes := makeFlags("--compute-nodes", "4")
+		Expect(PromptInt(fs, "compute-nodes", atLeast(nodes, 3))).To(Succeed())
+		Expect(*nodes).To(Equal(4))
+	})
+
+	It("Accepts any value given in the command line without validation", func() {
+		fs, nodes := makeFlags("--compute-nodes", "1")
+		Expect(PromptInt(fs, "compute-nodes", nil)).To(Succeed())
+		Expect(*nodes).To(Equal(1))
+	})
+
+	It("Rejects an invalid value given in the command line", func() {
+		fs, nodes := makeFlags("--compute-nodes", "1")
+		err := PromptInt(fs, "compute-nodes", atLeast(nodes, 3))
+		Expect(err).To(MatchError("Invalid --compute-nodes: must be at least 3"))
+	})
+
+	It("Fails if the flag isn't an integer flag", func() {
+		fs, _ := makeFlags()
+		Expect(PromptInt(fs, "interactive", nil)).To(HaveOccurred())
+	})
+})
@@ -20,13 +20,19 @@ package arguments
 
 import (
 	"fmt"
+	"os"
 	"os/user"
 	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/openshift-online/ocm-cli/pkg/config"
 	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	"github.com/openshift-online/ocm-cli/pkg/output"
+	"github.com/openshift-online/ocm-cli/pkg/properties"
+	"github.com/openshift-online/ocm-cli/pkg/urls"
+	"github.com/openshift-online/ocm-cli/pkg/utils"
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -48,6 +54,122 @@ func AddInteractiveFlag(flags *pflag.FlagSet, value *bool) {
 	)
 }
 
+// AddConfirmFlag adds the flag used to skip the confirmation of destructive operations.
+func AddConfirmFlag(flags *pflag.FlagSet, value *bool) {
+	flags.BoolVarP(
+		value,
+		"confirm",
+		"y",
+		false,
+		"Skip the confirmation prompt and proceed with the operation.",
+	)
+}
+
+// ConfirmDeletion asks the user to type 'yes' to confirm the deletion of the given resource,
+// unless the --confirm flag has been set. As the confirmation can't be requested when the
+// standard input isn't a terminal, in that case it fails unless --confirm has been set.
+func ConfirmDeletion(fs *pflag.FlagSet, kind string, name string) error {
+	confirmed, err := fs.GetBool("confirm")
+	if err != nil {
+		return fmt.Errorf(`no such flag "confirm"`)
+	}
+	if confirmed {
+		return nil
+	}
+	if !output.IsTerminal(os.Stdin) {
+		return fmt.Errorf(
+			"Refusing to delete %s '%s' without confirmation, use --confirm to proceed",
+			kind, name,
+		)
+	}
+
+	var response string
+	prompt := &survey.Input{
+		Message: fmt.Sprintf("Type 'yes' to confirm the deletion of %s '%s':", kind, name),
+	}
+	err = survey.AskOne(prompt, &response)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(response) != "yes" {
+		return fmt.Errorf("Deletion of %s '%s' cancelled", kind, name)
+	}
+	return nil
+}
+
+// ConfirmProduction asks the user to type 'yes' before running the given operation, for example
+// "delete machine pool 'mp-1'", when connected to the production environment. It doesn't ask if the
+// --confirm flag has been set or if the OCM_SKIP_PRODUCTION_CONFIRMATION environment variable is a
+// true value, like 'true' or '1'. The question is only asked when the standard input is a terminal,
+// otherwise it fails with an error that explains how to confirm the operation. Commands that
+// modify resources should add the --confirm flag and call this before sending the request.
+func ConfirmProduction(fs *pflag.FlagSet, operation string) error {
+	return confirmProduction(fs, operation, output.IsTerminal(os.Stdin))
+}
+
+// ConfirmProductionWithoutPrompt is like ConfirmProduction, but it never asks the question. It is
+// intended for commands that read something else from the standard input, like the body of a
+// request, so the user can't use it to answer.
+func ConfirmProductionWithoutPrompt(fs *pflag.FlagSet, operation string) error {
+	return confirmProduction(fs, operation, false)
+}
+
+func confirmProduction(fs *pflag.FlagSet, operation string, prompt bool) error {
+	confirmed, err := fs.GetBool("confirm")
+	if err != nil {
+		return fmt.Errorf(`no such flag "confirm"`)
+	}
+	if confirmed || skipProductionConfirmation() {
+		return nil
+	}
+
+	// Use the same URL that the connection will use:
+	gatewayURL := os.Getenv(properties.URLEnvKey)
+	if gatewayURL == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("Can't load config file: %v", err)
+		}
+		if cfg == nil {
+			return nil
+		}
+		gatewayURL = cfg.URL
+	}
+	if !urls.IsProductionURL(gatewayURL) {
+		return nil
+	}
+
+	if !prompt {
+		return fmt.Errorf(
+			"Refusing to %s in the production environment '%s' without confirmation, use "+
+				"--confirm or set %s=true to proceed",
+			operation, gatewayURL, properties.SkipProductionConfirmationEnvKey,
+		)
+	}
+	var response string
+	question := &survey.Input{
+		Message: fmt.Sprintf(
+			"You are connected to the production environment '%s'. Type 'yes' to %s:",
+			gatewayURL, operation,
+		),
+	}
+	err = survey.AskOne(question, &response)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(response) != "yes" {
+		return fmt.Errorf("Operation cancelled")
+	}
+	return nil
+}
+
+// skipProductionConfirmation checks if the confirmation has been disabled with the environment
+// variable.
+func skipProductionConfirmation() bool {
+	skip, err := strconv.ParseBool(os.Getenv(properties.SkipProductionConfirmationEnvKey))
+	return err == nil && skip
+}
+
 // SetQuestion sets a friendlier text to use when prompting instead of flag name.
 func SetQuestion(fs *pflag.FlagSet, flagName, question string) {
 	fs.SetAnnotation(flagName, questionAnnotationKey, []string{question})
@@ -138,7 +260,7 @@ func ifInteractive(fs *pflag.FlagSet, then func() error) error {
 // PromptBool sets a bool flag value interactively, unless already set.
 // Does nothing in non-interactive mode.
 func PromptBool(fs *pflag.FlagSet, flagName string) error {
-	value, err := fs.GetBool(flagName)
+	_, err := fs.GetBool(flagName)
 	if err != nil {
 		return fmt.Errorf("%v", err)
 	}
@@ -146,6 +268,8 @@ func PromptBool(fs *pflag.FlagSet, flagName string) error {
 
 	return ifInteractive(fs, func() error {
 		if !flag.Changed {
+			applySavedAnswer(flag)
+			value, _ := fs.GetBool(flagName)
 			prompt := &survey.Confirm{
 				Message: getQuestion(flag),
 				Help:    flag.Usage,
@@ -157,14 +281,29 @@ func PromptBool(fs *pflag.FlagSet, flagName string) error {
 				return err
 			}
 			fs.Set(flagName, strconv.FormatBool(response))
+			return SaveAnswer(flagName, strconv.FormatBool(response))
 		}
 		return nil
 	})
 }
 
+// PromptConfirm asks a yes or no question, with yes as the default answer. In non-interactive
+// mode it doesn't ask and returns false.
+func PromptConfirm(fs *pflag.FlagSet, message string) (confirmed bool, err error) {
+	err = ifInteractive(fs, func() error {
+		prompt := &survey.Confirm{
+			Message: message,
+			Default: true,
+		}
+		return survey.AskOne(prompt, &confirmed)
+	})
+	return
+}
+
 // PromptInt sets an integer flag value interactively, unless already set.
-// validation func is optional, and runs after the flag is already set.
-// Does nothing in non-interactive mode. TODO: always validate, prompt if bad.
+// validation func is optional, and runs after the flag is already set. If the value given in the
+// command line doesn't pass the validation it prompts again in interactive mode, and fails
+// in non-interactive mode.
 func PromptInt(fs *pflag.FlagSet, flagName string, validate func() error) error {
 	_, err := fs.GetInt(flagName)
 	if err != nil {
@@ -172,36 +311,55 @@ func PromptInt(fs *pflag.FlagSet, flagName string, validate func() error) error
 	}
 	flag := fs.Lookup(flagName)
 
+	if flag.Changed {
+		if validate == nil {
+			return nil
+		}
+		err = validate()
+		if err == nil {
+			return nil
+		}
+		interactive, _ := fs.GetBool("interactive")
+		if !interactive {
+			return fmt.Errorf("Invalid --%s: %v", flagName, err)
+		}
+		fmt.Fprintf(os.Stderr, "Invalid --%s: %v\n", flagName, err)
+	}
+
 	return ifInteractive(fs, func() error {
 		if !flag.Changed {
-			var response int
-			prompt := &survey.Input{
-				Message: getQuestion(flag),
-				Help:    flag.Usage,
-				Default: flag.Value.String(),
+			applySavedAnswer(flag)
+		}
+		var response int
+		prompt := &survey.Input{
+			Message: getQuestion(flag),
+			Help:    flag.Usage,
+			Default: flag.Value.String(),
+		}
+		// Set() flag as side effect of validation => prompts again if invalid.
+		validator := func(val interface{}) error {
+			str := val.(string)
+			err := fs.Set(flagName, str)
+			if err != nil {
+				return err
 			}
-			// Set() flag as side effect of validation => prompts again if invalid.
-			validator := func(val interface{}) error {
-				str := val.(string)
-				err := fs.Set(flagName, str)
-				if err != nil {
-					return err
-				}
-				if validate != nil {
-					return validate()
-				}
-				return nil
+			if validate != nil {
+				return validate()
 			}
-			return survey.AskOne(prompt, &response, survey.WithValidator(validator))
+			return nil
 		}
-		return nil
+		err := survey.AskOne(prompt, &response, survey.WithValidator(validator))
+		if err != nil {
+			return err
+		}
+		return SaveAnswer(flagName, flag.Value.String())
 	})
 }
 
 // PromptString sets a string flag value interactively, unless already set.
 // Does nothing in non-interactive mode.
 func PromptString(fs *pflag.FlagSet, flagName string) error {
-	value, err := fs.GetString(flagName)
+	_, err := fs.GetString(flagName)
 	if err != nil {
 		return fmt.Errorf("%v", err)
 	}
@@ -209,17 +367,19 @@ func PromptString(fs *pflag.FlagSet, flagName string) error {
 
 	return ifInteractive(fs, func() error {
 		if !flag.Changed {
+			applySavedAnswer(flag)
 			var response string
 			prompt := &survey.Input{
 				Message: getQuestion(flag),
 				Help:    flag.Usage,
-				Default: value,
+				Default: flag.Value.String(),
 			}
 			err = survey.AskOne(prompt, &response)
 			if err != nil {
 				return err
 			}
 			fs.Set(flagName, response)
+			return SaveAnswer(flagName, response)
 		}
 		return nil
 	})
@@ -263,6 +423,7 @@ func PromptFilePath(fs *pflag.FlagSet, flagName string, required bool) error {
 
 	return ifInteractive(fs, func() error {
 		if !flag.Changed {
+			applySavedAnswer(flag)
 			prompt := &survey.Input{
 				Message: getQuestion(flag),
 				Help:    flag.Usage,
@@ -286,6 +447,7 @@ func PromptFilePath(fs *pflag.FlagSet, flagName string, required bool) error {
 				return err
 			}
 			fs.Set(flagName, response)
+			return SaveAnswer(flagName, response)
 		}
 		return nil
 	})
@@ -325,10 +487,14 @@ func PromptIPNet(fs *pflag.FlagSet, flagName string) error {
 		if flag.DefValue == "<nil>" {
 			flag.DefValue = flag.Value.String()
 		}
+		defaultValue := flag.DefValue
+		if applySavedAnswer(flag) {
+			defaultValue = flag.Value.String()
+		}
 		prompt := &survey.Input{
 			Message: getQuestion(flag),
 			Help:    flag.Usage,
-			Default: flag.DefValue,
+			Default: defaultValue,
 		}
 		var response string
 		// Set() flag as side effect of validation => prompts again if invalid.
@@ -340,7 +506,14 @@ func PromptIPNet(fs *pflag.FlagSet, flagName string) error {
 			}
 			return fs.Set(flagName, str)
 		}
-		return survey.AskOne(prompt, &response, survey.WithValidator(validator))
+		err := survey.AskOne(prompt, &response, survey.WithValidator(validator))
+		if err != nil {
+			return err
+		}
+		if response == "" {
+			return nil
+		}
+		return SaveAnswer(flagName, response)
 	})
 }
 
@@ -359,12 +532,20 @@ func PromptOrCheckOneOf(fs *pflag.FlagSet, flagName string, options []Option) er
 // unless already set.
 func PromptOneOf(fs *pflag.FlagSet, flagName string, options []Option) error {
 	return ifInteractive(fs, func() error {
-		return doPromptOneOf(fs, flagName, options)
+		return doPromptOneOf(fs, flagName, options, false)
+	})
+}
+
+// PromptOneOfWithDescriptions is like PromptOneOf, but the menu also shows the descriptions of
+// the options next to their values. The flag is still set to the value of the selected option.
+func PromptOneOfWithDescriptions(fs *pflag.FlagSet, flagName string, options []Option) error {
+	return ifInteractive(fs, func() error {
+		return doPromptOneOf(fs, flagName, options, true)
 	})
 }
 
-func doPromptOneOf(fs *pflag.FlagSet, flagName string, options []Option) error {
-	value, err := fs.GetString(flagName)
+func doPromptOneOf(fs *pflag.FlagSet, flagName string, options []Option, withDescriptions bool) error {
+	_, err := fs.GetString(flagName)
 	if err != nil {
 		return fmt.Errorf("%v", err)
 	}
@@ -373,6 +554,8 @@ func doPromptOneOf(fs *pflag.FlagSet, flagName string, options []Option) error {
 	// A flag may have a default in non-interactive mode, but still be worth prompting
 	// in interactive mode unless explictily specified on command line.
 	if !flag.Changed {
+		applySavedAnswer(flag)
+		value := flag.Value.String()
 		values := optionValues(options)
 
 		// If the `Default` is not one of the allowed `Options`,
@@ -391,16 +574,31 @@ func doPromptOneOf(fs *pflag.FlagSet, flagName string, options []Option) error {
 			Options: values,
 			Default: defaultValue,
 		}
+		if withDescriptions {
+			prompt.Description = optionDescriptions(options)
+		}
 		var response string
 		err = survey.AskOne(prompt, &response)
 		if err != nil {
 			return err
 		}
 		fs.Set(flagName, response)
+		return SaveAnswer(flagName, response)
 	}
 	return nil
 }
 
+// optionDescriptions returns a function that survey uses to find the description of the option
+// that is displayed in the given position of the menu.
+func optionDescriptions(options []Option) func(value string, index int) string {
+	return func(value string, index int) string {
+		if index < 0 || index >= len(options) || options[index].Value != value {
+			return ""
+		}
+		return options[index].Description
+	}
+}
+
 // CheckOneOf returns error if flag has been set and is not one of given options.
 // It's appropriate for both optional flags (no error not given)
 // and required flags (Cobra validated they're given before command .Run).
@@ -411,6 +609,37 @@ func CheckOneOf(fs *pflag.FlagSet, flagName string, options []Option) error {
 	return nil
 }
 
+// maxSuggestions is the maximum number of options suggested by CheckOneOfWithSuggestions.
+const maxSuggestions = 5
+
+// CheckOneOfWithSuggestions is like CheckOneOf, but instead of listing all the options in the error
+// it lists only the ones closest to the given value. Use it when the list of options is long.
+func CheckOneOfWithSuggestions(fs *pflag.FlagSet, flagName string, options []Option) error {
+	if !fs.Changed(flagName) {
+		return nil
+	}
+	flag := fs.Lookup(flagName)
+	if flag == nil {
+		return fmt.Errorf("no such flag %q", flagName)
+	}
+
+	value := flag.Value.String()
+	values := optionValues(options)
+	for _, option := range values {
+		if value == option {
+			return nil
+		}
+	}
+	suggestions := utils.Suggestions(value, values, maxSuggestions)
+	if len(suggestions) == 0 {
+		return fmt.Errorf("A valid --%s must be specified, '%s' isn't a valid option", flagName, value)
+	}
+	return fmt.Errorf(
+		"A valid --%s must be specified, '%s' isn't a valid option.\nDid you mean: %s",
+		flagName, value, strings.Join(suggestions, ", "),
+	)
+}
+
 // requireOneOf returns error if flag is not one of given options.
 func requireOneOf(fs *pflag.FlagSet, flagName string, options []Option) error {
 	flag := fs.Lookup(flagName)
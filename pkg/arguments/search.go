@@ -0,0 +1,135 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions to build search queries from structured flags.
+
+package arguments
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// searchFieldRE is the regular expression that the names of the fields used in search queries
+// must match, for example `name` or `cloud_provider.id`.
+var searchFieldRE = regexp.MustCompile(`^[a-z_][a-z0-9_]*(\.[a-z_][a-z0-9_]*)*$`)
+
+// AddWhereFlag adds the '--where' flag to the given set of command line flags.
+func AddWhereFlag(fs *pflag.FlagSet, values *[]string) {
+	fs.StringArrayVar(
+		values,
+		"where",
+		nil,
+		"Condition used to filter the results. The value must be the name of a field "+
+			"followed by '=', '!=' or '~' and then the value, for example 'state=ready' or "+
+			"'name~my-%'. The '~' operator uses the SQL 'like' semantics. Values are quoted "+
+			"automatically. Can be used multiple times to specify multiple conditions, and "+
+			"all of them must be satisfied.",
+	)
+}
+
+// QuoteSearchValue returns the given value surrounded by single quotes and with the single quotes
+// that it contains escaped, so that it can be safely used in search queries.
+func QuoteSearchValue(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// Eq returns the search term that checks that the given field is equal to the given value.
+func Eq(field, value string) string {
+	return fmt.Sprintf("%s = %s", field, QuoteSearchValue(value))
+}
+
+// Ne returns the search term that checks that the given field isn't equal to the given value.
+func Ne(field, value string) string {
+	return fmt.Sprintf("%s != %s", field, QuoteSearchValue(value))
+}
+
+// Like returns the search term that checks that the given field matches the given pattern.
+func Like(field, pattern string) string {
+	return fmt.Sprintf("%s like %s", field, QuoteSearchValue(pattern))
+}
+
+// ParseWhereFlag converts the values of the '--where' command line flag into search terms.
+func ParseWhereFlag(values []string) (terms []string, err error) {
+	for _, value := range values {
+		var term string
+		term, err = parseWhereCondition(value)
+		if err != nil {
+			return
+		}
+		terms = append(terms, term)
+	}
+	return
+}
+
+// MergeWhereFlag converts the values of the '--where' command line flag into search terms and
+// merges them with the 'search' parameters given with the '--parameter' flag, as the server ignores
+// all but one of the 'search' query parameters. It returns the parameters to apply to the request.
+func MergeWhereFlag(parameters, where []string) ([]string, error) {
+	terms, err := ParseWhereFlag(where)
+	if err != nil {
+		return nil, err
+	}
+	if len(terms) == 0 {
+		return parameters, nil
+	}
+	var result []string
+	for _, parameter := range parameters {
+		name, value := ParseNameValuePair(parameter)
+		if name == "search" {
+			terms = append(terms, value)
+		} else {
+			result = append(result, parameter)
+		}
+	}
+	if len(terms) > 1 {
+		for i, term := range terms {
+			terms[i] = fmt.Sprintf("(%s)", term)
+		}
+	}
+	return append(result, "search="+strings.Join(terms, " and ")), nil
+}
+
+func parseWhereCondition(condition string) (string, error) {
+	index := strings.IndexAny(condition, "!=~")
+	if index == -1 {
+		return "", fmt.Errorf(
+			"Condition '%s' isn't valid: expected 'field=value', 'field!=value' or 'field~pattern'",
+			condition,
+		)
+	}
+	field := strings.TrimSpace(condition[:index])
+	if !searchFieldRE.MatchString(field) {
+		return "", fmt.Errorf("Condition '%s' isn't valid: field name '%s' isn't valid", condition, field)
+	}
+	rest := condition[index:]
+	switch {
+	case strings.HasPrefix(rest, "!="):
+		return Ne(field, rest[2:]), nil
+	case strings.HasPrefix(rest, "="):
+		return Eq(field, rest[1:]), nil
+	case strings.HasPrefix(rest, "~"):
+		return Like(field, rest[1:]), nil
+	default:
+		return "", fmt.Errorf(
+			"Condition '%s' isn't valid: expected 'field=value', 'field!=value' or 'field~pattern'",
+			condition,
+		)
+	}
+}
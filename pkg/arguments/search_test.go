@@ -0,0 +1,76 @@
+package arguments
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Search", func() {
+	Context("Quotes values", func() {
+		It("Surrounds the value with single quotes", func() {
+			Expect(QuoteSearchValue("my-cluster")).To(Equal("'my-cluster'"))
+		})
+		It("Escapes single quotes", func() {
+			Expect(QuoteSearchValue("it's")).To(Equal("'it''s'"))
+		})
+	})
+
+	Context("Parses the --where flag", func() {
+		It("Supports equality", func() {
+			terms, err := ParseWhereFlag([]string{"name=foo", "state=ready"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(terms).To(Equal([]string{"name = 'foo'", "state = 'ready'"}))
+		})
+		It("Supports inequality", func() {
+			terms, err := ParseWhereFlag([]string{"cloud_provider.id!=aws"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(terms).To(Equal([]string{"cloud_provider.id != 'aws'"}))
+		})
+		It("Supports patterns", func() {
+			terms, err := ParseWhereFlag([]string{"name~foo%"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(terms).To(Equal([]string{"name like 'foo%'"}))
+		})
+		It("Quotes values containing operators and quotes", func() {
+			terms, err := ParseWhereFlag([]string{"name=a=b' or '1'='1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(terms).To(Equal([]string{"name = 'a=b'' or ''1''=''1'"}))
+		})
+		It("Fails without an operator", func() {
+			_, err := ParseWhereFlag([]string{"name"})
+			Expect(err).To(HaveOccurred())
+		})
+		It("Fails with an invalid field name", func() {
+			_, err := ParseWhereFlag([]string{"name or 1=1"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("field name 'name or 1' isn't valid"))
+		})
+	})
+
+	Context("Merges the --where flag with the search parameter", func() {
+		It("Adds the search parameter", func() {
+			parameters, err := MergeWhereFlag([]string{"size=10"}, []string{"name~my-%"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parameters).To(Equal([]string{"size=10", "search=name like 'my-%'"}))
+		})
+		It("Combines the conditions with the search parameter", func() {
+			parameters, err := MergeWhereFlag(
+				[]string{"search=ebs_account_id = '123'"},
+				[]string{"name=my-org"},
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parameters).To(Equal([]string{
+				"search=(name = 'my-org') and (ebs_account_id = '123')",
+			}))
+		})
+		It("Doesn't change the parameters without conditions", func() {
+			parameters, err := MergeWhereFlag([]string{"search=name = 'my-org'"}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parameters).To(Equal([]string{"search=name = 'my-org'"}))
+		})
+		It("Fails with an invalid condition", func() {
+			_, err := MergeWhereFlag(nil, []string{"name"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,51 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--ca-file' command line option.
+
+package ca
+
+import (
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"github.com/openshift-online/ocm-cli/pkg/properties"
+)
+
+// AddFlag adds the CA file flag to the given set of command line flags.
+func AddFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&file,
+		"ca-file",
+		"",
+		"File containing additional PEM encoded CA certificates trusted when connecting to "+
+			"the OCM API, for example the CA of a TLS inspecting proxy. Can also be set with the "+
+			properties.CAFileEnvKey+" environment variable.",
+	)
+}
+
+// File returns the CA file given in the command line or in the environment, or an empty string if
+// it wasn't given.
+func File() string {
+	if file != "" {
+		return file
+	}
+	return os.Getenv(properties.CAFileEnvKey)
+}
+
+// file is the value of the CA file flag.
+var file string
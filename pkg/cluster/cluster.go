@@ -17,19 +17,22 @@ limitations under the License.
 package cluster
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"os"
 	"reflect"
 	"regexp"
 	"strings"
 	"time"
 
 	sdk "github.com/openshift-online/ocm-sdk-go"
-	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	asv1 "github.com/openshift-online/ocm-sdk-go/addonsmgmt/v1"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	acc_util "github.com/openshift-online/ocm-cli/pkg/account"
 )
 
 const (
@@ -39,10 +42,28 @@ const (
 	NetworkTypeSDN = "OpenShiftSDN"
 	NetworkTypeOVN = "OVNKubernetes"
 
+	ImdsOptional = string(cmv1.Ec2MetadataHttpTokensOptional)
+	ImdsRequired = string(cmv1.Ec2MetadataHttpTokensRequired)
+
 	AuthenticationWif = "Workload Identity Federation (WIF)"
 	AuthenticationKey = "Service account"
+
+	// MinWorkerDiskSize is the minimum size, in GiB, of the root volume of the worker nodes.
+	MinWorkerDiskSize = 128
+
+	// MinWorkerVolumeIOPS and MaxWorkerVolumeIOPS are the limits of the IOPS of the AWS gp3
+	// root volume of the worker nodes.
+	MinWorkerVolumeIOPS = 3000
+	MaxWorkerVolumeIOPS = 16000
 )
 
+// maxWorkerDiskSize contains the maximum size, in GiB, of the root volume of the worker nodes
+// for each cloud provider.
+var maxWorkerDiskSize = map[string]int{
+	ProviderAWS: 16384,
+	ProviderGCP: 65536,
+}
+
 type DefaultIngressSpec struct {
 	RouteSelectors           map[string]string
 	ExcludedNamespaces       []string
@@ -75,10 +96,26 @@ type Spec struct {
 	EtcdEncryption   bool
 	SubscriptionType string
 
+	// ARN of the customer AWS KMS key used to encrypt etcd, only for AWS CCS clusters
+	EtcdKMSKeyARN string
+
+	// Pull spec of a custom release image, only for channel groups other than 'stable'
+	ReleaseImage string
+
+	// Whether the cluster uses FIPS validated cryptography, required for FedRAMP clusters
+	FIPS bool
+
+	// Availability zones that the default machine pool is created in. They are sent as the zones
+	// of the cluster nodes, so they can't be combined with subnets, that already determine them
+	DefaultPoolAZs []string
+
 	// Scaling config
 	ComputeMachineType string
 	ComputeNodes       int
 	Autoscaling        Autoscaling
+	WorkerDiskSize     int
+	WorkerVolumeIOPS   int
+	ClusterAutoscaler  ClusterAutoscaler
 
 	// Network config
 	NetworkType string
@@ -88,12 +125,21 @@ type Spec struct {
 	HostPrefix  int
 	Private     *bool
 
+	// Whether the API endpoint of a private cluster stays public, so that only the application
+	// routes are private
+	PublicAPI bool
+
 	// Properties
 	CustomProperties map[string]string
 
 	// Default Ingress Attributes
 	DefaultIngress DefaultIngressSpec
 
+	// AWS-specific settings
+	Imds        string
+	PrivateLink bool
+	SharedVPC   SharedVPC
+
 	// Gcp-specific settings
 	GcpSecurity GcpSecurity
 
@@ -110,6 +156,16 @@ type Autoscaling struct {
 	MaxReplicas int
 }
 
+// ClusterAutoscaler contains the settings of the cluster autoscaler. The values are durations
+// like '15m' or '1h30m', and empty values are left to the defaults of the service.
+type ClusterAutoscaler struct {
+	MaxNodeProvisionTime       string
+	ScaleDownDelayAfterAdd     string
+	ScaleDownDelayAfterDelete  string
+	ScaleDownDelayAfterFailure string
+	ScaleDownUnneededTime      string
+}
+
 type CCS struct {
 	Enabled bool
 	AWS     AWSCredentials
@@ -129,13 +185,27 @@ type ExistingVPC struct {
 	AdditionalControlPlaneSecurityGroupIds []string
 }
 
+// SharedVPC contains the settings of AWS clusters installed in a VPC shared by another AWS account,
+// where the private hosted zone of the cluster is also created.
+type SharedVPC struct {
+	// HostedZoneID is the identifier of the private hosted zone in the account that owns the VPC.
+	HostedZoneID string
+
+	// Route53RoleARN is the ARN of the role, in the account that owns the VPC, used to manage
+	// the records of the private hosted zone.
+	Route53RoleARN string
+}
+
 type ClusterWideProxy struct {
 	Enabled                   bool
 	HTTPProxy                 *string
 	HTTPSProxy                *string
 	NoProxy                   *string
 	AdditionalTrustBundleFile *string
-	AdditionalTrustBundle     *string
+
+	// AdditionalTrustBundle is the PEM content of the bundle. When updating a cluster nil leaves
+	// the current bundle unchanged, and a pointer to an empty string removes it.
+	AdditionalTrustBundle *string
 }
 
 type AWSCredentials struct {
@@ -157,6 +227,31 @@ type GCPCredentials struct {
 	ClientX509CertURL       string `json:"client_x509_cert_url"`
 }
 
+// ReadGCPCredentials reads the key of a GCP service account from the given JSON file, in the format
+// that the GCP console downloads, and checks that it contains the fields needed to use it.
+func ReadGCPCredentials(file string) (credentials GCPCredentials, err error) {
+	// #nosec G304
+	data, err := os.ReadFile(file)
+	if err != nil {
+		err = fmt.Errorf("Can't read service account file '%s': %v", file, err)
+		return
+	}
+	err = json.Unmarshal(data, &credentials)
+	if err != nil {
+		err = fmt.Errorf("Service account file '%s' isn't valid JSON: %v", file, err)
+		return
+	}
+	if credentials.Type == "" || credentials.ProjectID == "" || credentials.ClientEmail == "" ||
+		credentials.PrivateKey == "" {
+		err = fmt.Errorf(
+			"Service account file '%s' doesn't contain a service account key, it should "+
+				"have 'type', 'project_id', 'client_email' and 'private_key' fields",
+			file,
+		)
+	}
+	return
+}
+
 type GcpSecurity struct {
 	SecureBoot bool `json:"secure_boot,omitempty"`
 }
@@ -171,10 +266,10 @@ type GcpPrivateSvcConnect struct {
 }
 
 type AddOnItem struct {
-	ID        string
-	Name      string
-	State     string
-	Available bool
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	Available bool   `json:"available"`
 }
 
 type lmtSprReasonItem struct {
@@ -351,9 +446,16 @@ func CreateCluster(cmv1Client *cmv1.Client, config Spec, dryRun bool) (*cmv1.Clu
 		clusterBuilder = clusterBuilder.DomainPrefix(config.DomainPrefix)
 	}
 
-	clusterBuilder = clusterBuilder.Version(
-		cmv1.NewVersion().
-			ID(config.Version).ChannelGroup(config.ChannelGroup))
+	if config.FIPS {
+		clusterBuilder = clusterBuilder.FIPS(true)
+	}
+
+	versionBuilder := cmv1.NewVersion().
+		ID(config.Version).ChannelGroup(config.ChannelGroup)
+	if config.ReleaseImage != "" {
+		versionBuilder = versionBuilder.ReleaseImage(config.ReleaseImage)
+	}
+	clusterBuilder = clusterBuilder.Version(versionBuilder)
 
 	if !config.Expiration.IsZero() {
 		clusterBuilder = clusterBuilder.ExpirationTimestamp(config.Expiration)
@@ -384,7 +486,7 @@ func CreateCluster(cmv1Client *cmv1.Client, config Spec, dryRun bool) (*cmv1.Clu
 	}
 
 	if config.Private != nil {
-		if *config.Private {
+		if *config.Private && !config.PublicAPI {
 			clusterBuilder = clusterBuilder.API(
 				cmv1.NewClusterAPI().
 					Listening(cmv1.ListeningMethodInternal),
@@ -397,6 +499,7 @@ func CreateCluster(cmv1Client *cmv1.Client, config Spec, dryRun bool) (*cmv1.Clu
 		}
 	}
 
+	awsBuilder := cmv1.NewAWS()
 	gcpBuilder := cmv1.NewGCP()
 
 	if config.CCS.Enabled {
@@ -407,7 +510,7 @@ func CreateCluster(cmv1Client *cmv1.Client, config Spec, dryRun bool) (*cmv1.Clu
 			if config.ExistingVPC.SubnetIDs != "" {
 				subnets = strings.Split(config.ExistingVPC.SubnetIDs, ",")
 			}
-			awsBuilder := cmv1.NewAWS().
+			awsBuilder.
 				AccountID(config.CCS.AWS.AccountID).
 				AccessKeyID(config.CCS.AWS.AccessKeyID).
 				SecretAccessKey(config.CCS.AWS.SecretAccessKey).
@@ -421,7 +524,20 @@ func CreateCluster(cmv1Client *cmv1.Client, config Spec, dryRun bool) (*cmv1.Clu
 			if len(config.ExistingVPC.AdditionalControlPlaneSecurityGroupIds) != 0 {
 				awsBuilder.AdditionalControlPlaneSecurityGroupIds(config.ExistingVPC.AdditionalControlPlaneSecurityGroupIds...)
 			}
-			clusterBuilder = clusterBuilder.AWS(awsBuilder)
+			if config.PrivateLink {
+				awsBuilder.PrivateLink(true)
+			}
+			if config.SharedVPC.HostedZoneID != "" {
+				awsBuilder.
+					PrivateHostedZoneID(config.SharedVPC.HostedZoneID).
+					PrivateHostedZoneRoleARN(config.SharedVPC.Route53RoleARN)
+			}
+			if config.EtcdKMSKeyARN != "" {
+				awsBuilder.EtcdEncryption(
+					cmv1.NewAwsEtcdEncryption().
+						KMSKeyARN(config.EtcdKMSKeyARN),
+				)
+			}
 		case ProviderGCP:
 			switch config.GcpAuthentication.Type {
 			case AuthenticationWif:
@@ -484,6 +600,15 @@ func CreateCluster(cmv1Client *cmv1.Client, config Spec, dryRun bool) (*cmv1.Clu
 		}
 	}
 
+	// The instance metadata protection mode is also supported by clusters that aren't CCS, so
+	// for them the AWS settings contain only that:
+	if config.Provider == ProviderAWS && config.Imds != "" {
+		awsBuilder.Ec2MetadataHttpTokens(cmv1.Ec2MetadataHttpTokens(config.Imds))
+	}
+	if config.Provider == ProviderAWS && (config.CCS.Enabled || config.Imds != "") {
+		clusterBuilder = clusterBuilder.AWS(awsBuilder)
+	}
+
 	if config.GcpSecurity.SecureBoot {
 		gcpSecurity := cmv1.NewGcpSecurity().SecureBoot(config.GcpSecurity.SecureBoot)
 		gcpBuilder.Security(gcpSecurity)
@@ -495,7 +620,8 @@ func CreateCluster(cmv1Client *cmv1.Client, config Spec, dryRun bool) (*cmv1.Clu
 	}
 
 	if config.ComputeMachineType != "" || config.ComputeNodes > 0 || len(config.ExistingVPC.AvailabilityZones) > 0 ||
-		config.Autoscaling.Enabled {
+		len(config.DefaultPoolAZs) > 0 ||
+		config.Autoscaling.Enabled || config.WorkerDiskSize > 0 || config.WorkerVolumeIOPS > 0 {
 		clusterNodesBuilder := cmv1.NewClusterNodes()
 		if config.ComputeMachineType != "" {
 			clusterNodesBuilder = clusterNodesBuilder.ComputeMachineType(
@@ -504,15 +630,33 @@ func CreateCluster(cmv1Client *cmv1.Client, config Spec, dryRun bool) (*cmv1.Clu
 		}
 		clusterNodesBuilder = buildCompute(config, clusterNodesBuilder)
 
+		if config.WorkerDiskSize > 0 || config.WorkerVolumeIOPS > 0 {
+			clusterNodesBuilder = clusterNodesBuilder.ComputeRootVolume(buildWorkerRootVolume(config))
+		}
+
+		// The zones of the default machine pool are only used when the subnets don't already
+		// determine the zones:
 		if len(config.ExistingVPC.AvailabilityZones) > 0 {
 			availabilityZones := strings.Join(config.ExistingVPC.AvailabilityZones, ",")
 			clusterNodesBuilder = clusterNodesBuilder.AvailabilityZones(strings.Split(availabilityZones, ",")...)
+		} else if len(config.DefaultPoolAZs) > 0 {
+			clusterNodesBuilder = clusterNodesBuilder.AvailabilityZones(config.DefaultPoolAZs...)
 		}
 		clusterBuilder = clusterBuilder.Nodes(clusterNodesBuilder)
 	}
 
-	if !reflect.DeepEqual(config.DefaultIngress, NewDefaultIngressSpec()) {
+	if config.ClusterAutoscaler != (ClusterAutoscaler{}) {
+		clusterBuilder = clusterBuilder.Autoscaler(buildClusterAutoscaler(config.ClusterAutoscaler))
+	}
+
+	// When only the application routes are private the listening method of the default ingress is
+	// set explicitly, as the API endpoint is public:
+	privateIngressOnly := config.Private != nil && *config.Private && config.PublicAPI
+	if privateIngressOnly || !reflect.DeepEqual(config.DefaultIngress, NewDefaultIngressSpec()) {
 		defaultIngress := cmv1.NewIngress().Default(true)
+		if privateIngressOnly {
+			defaultIngress.Listening(cmv1.ListeningMethodInternal)
+		}
 		if len(config.DefaultIngress.RouteSelectors) != 0 {
 			defaultIngress.RouteSelectors(config.DefaultIngress.RouteSelectors)
 		}
@@ -658,6 +802,223 @@ func buildCompute(config Spec, clusterNodesBuilder *cmv1.ClusterNodesBuilder) *c
 	return clusterNodesBuilder
 }
 
+// buildWorkerRootVolume returns the root volume of the worker nodes of the default machine pool.
+func buildWorkerRootVolume(config Spec) *cmv1.RootVolumeBuilder {
+	rootVolumeBuilder := cmv1.NewRootVolume()
+	switch config.Provider {
+	case ProviderAWS:
+		awsVolumeBuilder := cmv1.NewAWSVolume()
+		if config.WorkerDiskSize > 0 {
+			awsVolumeBuilder = awsVolumeBuilder.Size(config.WorkerDiskSize)
+		}
+		if config.WorkerVolumeIOPS > 0 {
+			awsVolumeBuilder = awsVolumeBuilder.IOPS(config.WorkerVolumeIOPS)
+		}
+		rootVolumeBuilder = rootVolumeBuilder.AWS(awsVolumeBuilder)
+	case ProviderGCP:
+		rootVolumeBuilder = rootVolumeBuilder.GCP(cmv1.NewGCPVolume().Size(config.WorkerDiskSize))
+	}
+	return rootVolumeBuilder
+}
+
+// buildClusterAutoscaler returns the cluster autoscaler containing only the settings that have
+// been given.
+func buildClusterAutoscaler(config ClusterAutoscaler) *cmv1.ClusterAutoscalerBuilder {
+	autoscalerBuilder := cmv1.NewClusterAutoscaler()
+	if config.MaxNodeProvisionTime != "" {
+		autoscalerBuilder = autoscalerBuilder.MaxNodeProvisionTime(config.MaxNodeProvisionTime)
+	}
+	scaleDownBuilder := cmv1.NewAutoscalerScaleDownConfig()
+	if config.ScaleDownDelayAfterAdd != "" {
+		scaleDownBuilder = scaleDownBuilder.DelayAfterAdd(config.ScaleDownDelayAfterAdd)
+	}
+	if config.ScaleDownDelayAfterDelete != "" {
+		scaleDownBuilder = scaleDownBuilder.DelayAfterDelete(config.ScaleDownDelayAfterDelete)
+	}
+	if config.ScaleDownDelayAfterFailure != "" {
+		scaleDownBuilder = scaleDownBuilder.DelayAfterFailure(config.ScaleDownDelayAfterFailure)
+	}
+	if config.ScaleDownUnneededTime != "" {
+		scaleDownBuilder = scaleDownBuilder.UnneededTime(config.ScaleDownUnneededTime)
+	}
+	if !scaleDownBuilder.Empty() {
+		autoscalerBuilder = autoscalerBuilder.ScaleDown(scaleDownBuilder)
+	}
+	return autoscalerBuilder
+}
+
+// MaxAutoscalerDuration is the maximum value of the durations of the cluster autoscaler settings.
+const MaxAutoscalerDuration = 24 * time.Hour
+
+// ValidateAutoscalerDuration checks that the given value is a duration, like '15m' or '1h30m',
+// between the given minimum and MaxAutoscalerDuration.
+func ValidateAutoscalerDuration(value string, min time.Duration) error {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("Value '%s' isn't a valid duration, it should look like '15m' or '1h30m'", value)
+	}
+	if duration < min || duration > MaxAutoscalerDuration {
+		return fmt.Errorf(
+			"Duration must be between %s and %s, but it is %s",
+			min, MaxAutoscalerDuration, duration,
+		)
+	}
+	return nil
+}
+
+// ValidateWorkerDiskSize checks that the given size, in GiB, of the root volume of the worker nodes
+// is within the limits supported by the given cloud provider.
+func ValidateWorkerDiskSize(provider string, size int) error {
+	max, ok := maxWorkerDiskSize[provider]
+	if !ok {
+		return fmt.Errorf("Worker disk size isn't supported for provider '%s'", provider)
+	}
+	if size < MinWorkerDiskSize || size > max {
+		return fmt.Errorf(
+			"Worker disk size must be between %d and %d GiB for provider '%s', but it is %d GiB",
+			MinWorkerDiskSize, max, provider, size,
+		)
+	}
+	return nil
+}
+
+// ValidateWorkerVolumeIOPS checks that the given IOPS of the root volume of the worker nodes are
+// supported. Only the gp3 volumes used by AWS clusters allow tuning the IOPS.
+func ValidateWorkerVolumeIOPS(provider string, iops int) error {
+	if provider != ProviderAWS {
+		return fmt.Errorf("Worker volume IOPS are only supported for provider '%s'", ProviderAWS)
+	}
+	if iops < MinWorkerVolumeIOPS || iops > MaxWorkerVolumeIOPS {
+		return fmt.Errorf(
+			"Worker volume IOPS must be between %d and %d, but they are %d",
+			MinWorkerVolumeIOPS, MaxWorkerVolumeIOPS, iops,
+		)
+	}
+	return nil
+}
+
+// iamRoleARNRE matches the ARN of an AWS IAM role, for example
+// 'arn:aws:iam::123456789012:role/my-route53-role'.
+var iamRoleARNRE = regexp.MustCompile(`^arn:aws(-[a-z]+)*:iam::\d{12}:role/[\w+=,.@/-]+$`)
+
+// ValidateIAMRoleARN checks that the given value is the ARN of an AWS IAM role.
+func ValidateIAMRoleARN(arn string) error {
+	if !iamRoleARNRE.MatchString(arn) {
+		return fmt.Errorf(
+			"Value '%s' isn't a valid AWS IAM role ARN, it should look like "+
+				"'arn:aws:iam::<account>:role/<name>'",
+			arn,
+		)
+	}
+	return nil
+}
+
+// releaseImageRE matches the pull spec of a release image with a tag or a digest, for example
+// 'quay.io/openshift-release-dev/ocp-release:4.16.0-x86_64'.
+var releaseImageRE = regexp.MustCompile(
+	`^[a-z0-9.-]+(:[0-9]+)?/[^\s@:]+(:[\w.-]+|@sha256:[a-f0-9]{64})$`,
+)
+
+// ValidateReleaseImage checks that a custom release image is a valid pull spec, and that it is
+// only used with channel groups other than 'stable'.
+func ValidateReleaseImage(image string, channelGroup string) error {
+	if channelGroup == "" || channelGroup == "stable" {
+		return fmt.Errorf("Custom release images can't be used with the 'stable' channel group")
+	}
+	if !releaseImageRE.MatchString(image) {
+		return fmt.Errorf(
+			"Value '%s' isn't a valid release image, it should look like "+
+				"'<registry>/<repository>:<tag>' or '<registry>/<repository>@sha256:<digest>'",
+			image,
+		)
+	}
+	return nil
+}
+
+// zoneSuffixRE matches the part of the name of an availability zone that follows the name of the
+// region, for example 'a' in 'us-east-1a' for AWS or '-b' in 'us-east1-b' for GCP.
+var zoneSuffixRE = regexp.MustCompile(`^-?[a-z]$`)
+
+// ValidateAvailabilityZones checks that the given availability zones are in the region, for
+// example 'us-east-1a' for AWS or 'us-east1-b' for GCP, and that none is repeated.
+func ValidateAvailabilityZones(zones []string, region string) error {
+	seen := map[string]bool{}
+	for _, zone := range zones {
+		suffix, ok := strings.CutPrefix(zone, region)
+		if !ok || region == "" || !zoneSuffixRE.MatchString(suffix) {
+			return fmt.Errorf("Availability zone '%s' isn't in region '%s'", zone, region)
+		}
+		if seen[zone] {
+			return fmt.Errorf("Availability zone '%s' is repeated", zone)
+		}
+		seen[zone] = true
+	}
+	return nil
+}
+
+// kmsKeyARNRE matches the ARN of an AWS KMS key, for example
+// 'arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab'.
+var kmsKeyARNRE = regexp.MustCompile(`^arn:aws(-[a-z]+)*:kms:[a-z0-9-]+:\d{12}:key/[a-zA-Z0-9-]+$`)
+
+// ValidateKMSKeyARN checks that the given value is the ARN of an AWS KMS key.
+func ValidateKMSKeyARN(arn string) error {
+	if !kmsKeyARNRE.MatchString(arn) {
+		return fmt.Errorf(
+			"Value '%s' isn't a valid AWS KMS key ARN, it should look like "+
+				"'arn:aws:kms:<region>:<account>:key/<id>'",
+			arn,
+		)
+	}
+	return nil
+}
+
+// ValidateNetworkCIDRs checks that the machine, service and pod CIDRs don't overlap, and that the
+// host prefix fits within the pod CIDR. CIDRs that are empty and a host prefix that is zero are
+// ignored, as the server will use its defaults for them.
+func ValidateNetworkCIDRs(machineCIDR, serviceCIDR, podCIDR net.IPNet, hostPrefix int) error {
+	cidrs := []struct {
+		name string
+		cidr net.IPNet
+	}{
+		{name: "Machine", cidr: machineCIDR},
+		{name: "Service", cidr: serviceCIDR},
+		{name: "Pod", cidr: podCIDR},
+	}
+	for i, a := range cidrs {
+		if cidrIsEmpty(a.cidr) {
+			continue
+		}
+		for _, b := range cidrs[i+1:] {
+			if cidrIsEmpty(b.cidr) {
+				continue
+			}
+			if cidrsOverlap(a.cidr, b.cidr) {
+				return fmt.Errorf(
+					"%s CIDR '%s' overlaps with %s CIDR '%s'",
+					a.name, a.cidr.String(), strings.ToLower(b.name), b.cidr.String(),
+				)
+			}
+		}
+	}
+	if hostPrefix != 0 && !cidrIsEmpty(podCIDR) {
+		ones, bits := podCIDR.Mask.Size()
+		if hostPrefix < ones || hostPrefix > bits {
+			return fmt.Errorf(
+				"Host prefix /%d doesn't fit within pod CIDR '%s', it must be between /%d "+
+					"and /%d",
+				hostPrefix, podCIDR.String(), ones, bits,
+			)
+		}
+	}
+	return nil
+}
+
+// cidrsOverlap returns true if any address is contained in both CIDRs. As CIDRs are either nested
+// or disjoint, that happens when one of them contains the network address of the other.
+func cidrsOverlap(a, b net.IPNet) bool {
+	return a.Contains(b.IP.Mask(b.Mask)) || b.Contains(a.IP.Mask(a.Mask))
+}
+
 func GetClusterOauthURL(cluster *cmv1.Cluster) string {
 	var oauthURL string
 	consoleURL := cluster.Console().URL()
@@ -721,6 +1082,19 @@ func GetMachinePools(client *cmv1.ClustersClient, clusterID string) ([]*cmv1.Mac
 	return response.Items().Slice(), nil
 }
 
+func GetKubeletConfigs(client *cmv1.ClustersClient, clusterID string) ([]*cmv1.KubeletConfig, error) {
+	response, err := client.Cluster(clusterID).KubeletConfigs().
+		List().
+		Page(1).
+		Size(-1).
+		Send()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get kubelet configs for cluster '%s': %v", clusterID, err)
+	}
+
+	return response.Items().Slice(), nil
+}
+
 func GetUpgradePolicies(client *cmv1.ClustersClient, clusterID string) ([]*cmv1.UpgradePolicy, error) {
 	response, err := client.Cluster(clusterID).UpgradePolicies().
 		List().
@@ -734,26 +1108,30 @@ func GetUpgradePolicies(client *cmv1.ClustersClient, clusterID string) ([]*cmv1.
 	return response.Items().Slice(), nil
 }
 
-func GetClusterAddOns(connection *sdk.Connection, clusterID string) ([]*AddOnItem, error) {
-	// Get organization ID (used to get add-on quotas)
-	acctResponse, err := connection.AccountsMgmt().V1().CurrentAccount().
+// GetUpgradePolicyState returns the state of the given upgrade policy of the given cluster, or nil
+// if the policy has no state.
+func GetUpgradePolicyState(client *cmv1.ClustersClient, clusterID string,
+	policyID string) (*cmv1.UpgradePolicyState, error) {
+	response, err := client.Cluster(clusterID).UpgradePolicies().UpgradePolicy(policyID).State().
 		Get().
 		Send()
+	if response != nil && response.Status() == http.StatusNotFound {
+		return nil, nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("Failed to get current account: %s", err)
+		return nil, fmt.Errorf("Failed to get state of upgrade policy '%s' for cluster '%s': %v",
+			policyID, clusterID, err)
 	}
-	organization := acctResponse.Body().Organization().ID()
 
-	// Get a list of quota-cost for the current organization
-	quotaCostResponse, err := connection.AccountsMgmt().V1().Organizations().
-		Organization(organization).QuotaCost().
-		List().
-		Parameter("fetchRelatedResources", true).
-		Send()
+	return response.Body(), nil
+}
+
+func GetClusterAddOns(connection *sdk.Connection, clusterID string) ([]*AddOnItem, error) {
+	// Get a list of quota-cost for the organization of the current account
+	quotaCosts, err := acc_util.GetQuotaCosts(connection, "")
 	if err != nil {
-		return nil, fmt.Errorf("Failed to get quota-cost: %v", err)
+		return nil, err
 	}
-	quotaCosts := quotaCostResponse.Items()
 
 	// Get complete list of enabled add-ons
 	addOnsResponse, err := connection.AddonsMgmt().V1().Addons().
@@ -793,7 +1171,7 @@ func GetClusterAddOns(connection *sdk.Connection, clusterID string) ([]*AddOnIte
 			}
 
 			// Only display add-ons for which the org has quota
-			quotaCosts.Each(func(quotaCost *amv1.QuotaCost) bool {
+			for _, quotaCost := range quotaCosts {
 				relatedResources := quotaCost.RelatedResources()
 				for _, relatedResource := range relatedResources {
 					if relatedResource.ResourceType() == "add-on" &&
@@ -802,8 +1180,7 @@ func GetClusterAddOns(connection *sdk.Connection, clusterID string) ([]*AddOnIte
 						break
 					}
 				}
-				return true
-			})
+			}
 
 			// Get the state of add-on installations on the cluster
 			addOnInstallations.Each(func(addOnInstallation *asv1.AddonInstallation) bool {
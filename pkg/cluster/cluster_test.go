@@ -0,0 +1,348 @@
+package cluster
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateWorkerDiskSize(t *testing.T) {
+	tests := []struct {
+		provider string
+		size     int
+		valid    bool
+	}{
+		{provider: ProviderAWS, size: MinWorkerDiskSize, valid: true},
+		{provider: ProviderAWS, size: 300, valid: true},
+		{provider: ProviderAWS, size: 16384, valid: true},
+		{provider: ProviderAWS, size: 16385, valid: false},
+		{provider: ProviderAWS, size: MinWorkerDiskSize - 1, valid: false},
+		{provider: ProviderGCP, size: 65536, valid: true},
+		{provider: ProviderGCP, size: 65537, valid: false},
+		{provider: ProviderGCP, size: 100, valid: false},
+		{provider: "azure", size: 300, valid: false},
+	}
+
+	for _, test := range tests {
+		err := ValidateWorkerDiskSize(test.provider, test.size)
+		if test.valid && err != nil {
+			t.Errorf("expected %d GiB to be valid for %s, got: %v", test.size, test.provider, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("expected %d GiB to be invalid for %s", test.size, test.provider)
+		}
+	}
+}
+
+func TestValidateWorkerVolumeIOPS(t *testing.T) {
+	tests := []struct {
+		provider string
+		iops     int
+		valid    bool
+	}{
+		{provider: ProviderAWS, iops: MinWorkerVolumeIOPS, valid: true},
+		{provider: ProviderAWS, iops: MaxWorkerVolumeIOPS, valid: true},
+		{provider: ProviderAWS, iops: MinWorkerVolumeIOPS - 1, valid: false},
+		{provider: ProviderAWS, iops: MaxWorkerVolumeIOPS + 1, valid: false},
+		{provider: ProviderGCP, iops: MinWorkerVolumeIOPS, valid: false},
+	}
+
+	for _, test := range tests {
+		err := ValidateWorkerVolumeIOPS(test.provider, test.iops)
+		if test.valid && err != nil {
+			t.Errorf("expected %d IOPS to be valid for %s, got: %v", test.iops, test.provider, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("expected %d IOPS to be invalid for %s", test.iops, test.provider)
+		}
+	}
+}
+
+func TestBuildWorkerRootVolume(t *testing.T) {
+	volume, err := buildWorkerRootVolume(Spec{
+		Provider:         ProviderAWS,
+		WorkerVolumeIOPS: 6000,
+	}).Build()
+	if err != nil {
+		t.Fatalf("failed to build root volume: %v", err)
+	}
+	if volume.AWS().IOPS() != 6000 {
+		t.Errorf("expected 6000 IOPS, got %d", volume.AWS().IOPS())
+	}
+	if _, ok := volume.AWS().GetSize(); ok {
+		t.Errorf("expected size to be unset")
+	}
+}
+
+func TestValidateKMSKeyARN(t *testing.T) {
+	tests := []struct {
+		arn   string
+		valid bool
+	}{
+		{arn: "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab", valid: true},
+		{arn: "arn:aws-us-gov:kms:us-gov-west-1:123456789012:key/mrk-1234abcd", valid: true},
+		{arn: "arn:aws:kms:us-east-1:123456789012:alias/my-key", valid: false},
+		{arn: "arn:aws:s3:::my-bucket", valid: false},
+		{arn: "arn:aws:kms:us-east-1:1234:key/1234abcd", valid: false},
+		{arn: "1234abcd-12ab-34cd-56ef-1234567890ab", valid: false},
+		{arn: "", valid: false},
+	}
+
+	for _, test := range tests {
+		err := ValidateKMSKeyARN(test.arn)
+		if test.valid && err != nil {
+			t.Errorf("expected '%s' to be valid, got: %v", test.arn, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("expected '%s' to be invalid", test.arn)
+		}
+	}
+}
+
+func TestValidateIAMRoleARN(t *testing.T) {
+	tests := []struct {
+		arn   string
+		valid bool
+	}{
+		{arn: "arn:aws:iam::123456789012:role/my-route53-role", valid: true},
+		{arn: "arn:aws-us-gov:iam::123456789012:role/path/to/my-role", valid: true},
+		{arn: "arn:aws:iam::123456789012:user/my-user", valid: false},
+		{arn: "arn:aws:iam::1234:role/my-role", valid: false},
+		{arn: "arn:aws:kms:us-east-1:123456789012:key/1234abcd", valid: false},
+		{arn: "my-role", valid: false},
+		{arn: "", valid: false},
+	}
+
+	for _, test := range tests {
+		err := ValidateIAMRoleARN(test.arn)
+		if test.valid && err != nil {
+			t.Errorf("expected '%s' to be valid, got: %v", test.arn, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("expected '%s' to be invalid", test.arn)
+		}
+	}
+}
+
+func TestValidateAvailabilityZones(t *testing.T) {
+	tests := []struct {
+		zones  []string
+		region string
+		valid  bool
+	}{
+		{zones: []string{"us-east-1a", "us-east-1b"}, region: "us-east-1", valid: true},
+		{zones: []string{"us-east1-b"}, region: "us-east1", valid: true},
+		{zones: []string{"us-west-2a"}, region: "us-east-1", valid: false},
+		{zones: []string{"us-east-1a", "us-east-1a"}, region: "us-east-1", valid: false},
+		{zones: []string{"us-east-1"}, region: "us-east-1", valid: false},
+		{zones: []string{"us-east-1ab"}, region: "us-east-1", valid: false},
+		{zones: []string{""}, region: "us-east-1", valid: false},
+		{zones: []string{"a"}, region: "", valid: false},
+	}
+
+	for _, test := range tests {
+		err := ValidateAvailabilityZones(test.zones, test.region)
+		if test.valid && err != nil {
+			t.Errorf("expected %v to be valid, got: %v", test.zones, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("expected %v to be invalid", test.zones)
+		}
+	}
+}
+
+func TestValidateReleaseImage(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a1", 32)
+	tests := []struct {
+		image        string
+		channelGroup string
+		valid        bool
+	}{
+		{image: "quay.io/openshift-release-dev/ocp-release:4.16.0-x86_64", channelGroup: "nightly",
+			valid: true},
+		{image: "quay.io/openshift-release-dev/ocp-release@" + digest, channelGroup: "candidate",
+			valid: true},
+		{image: "registry.example.com:5000/my/release:4.16.0", channelGroup: "nightly", valid: true},
+		{image: "quay.io/openshift-release-dev/ocp-release:4.16.0-x86_64", channelGroup: "stable",
+			valid: false},
+		{image: "quay.io/openshift-release-dev/ocp-release:4.16.0-x86_64", channelGroup: "",
+			valid: false},
+		{image: "quay.io/openshift-release-dev/ocp-release", channelGroup: "nightly", valid: false},
+		{image: "ocp-release:4.16.0", channelGroup: "nightly", valid: false},
+		{image: "quay.io/ocp release:4.16.0", channelGroup: "nightly", valid: false},
+		{image: "quay.io/ocp-release@sha256:abc", channelGroup: "nightly", valid: false},
+	}
+
+	for _, test := range tests {
+		err := ValidateReleaseImage(test.image, test.channelGroup)
+		if test.valid && err != nil {
+			t.Errorf("expected '%s' with channel group '%s' to be valid, got: %v",
+				test.image, test.channelGroup, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("expected '%s' with channel group '%s' to be invalid", test.image, test.channelGroup)
+		}
+	}
+}
+
+func TestValidateAutoscalerDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		min   time.Duration
+		valid bool
+	}{
+		{value: "15m", min: time.Second, valid: true},
+		{value: "1h30m", min: time.Second, valid: true},
+		{value: "24h", min: time.Second, valid: true},
+		{value: "0s", min: 0, valid: true},
+		{value: "0s", min: time.Second, valid: false},
+		{value: "25h", min: 0, valid: false},
+		{value: "-10m", min: 0, valid: false},
+		{value: "15", min: 0, valid: false},
+		{value: "", min: 0, valid: false},
+	}
+
+	for _, test := range tests {
+		err := ValidateAutoscalerDuration(test.value, test.min)
+		if test.valid && err != nil {
+			t.Errorf("expected '%s' to be valid with minimum %s, got: %v", test.value, test.min, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("expected '%s' to be invalid with minimum %s", test.value, test.min)
+		}
+	}
+}
+
+func TestBuildClusterAutoscaler(t *testing.T) {
+	autoscaler, err := buildClusterAutoscaler(ClusterAutoscaler{
+		MaxNodeProvisionTime:   "20m",
+		ScaleDownDelayAfterAdd: "5m",
+	}).Build()
+	if err != nil {
+		t.Fatalf("failed to build cluster autoscaler: %v", err)
+	}
+	if autoscaler.MaxNodeProvisionTime() != "20m" {
+		t.Errorf("expected max node provision time '20m', got '%s'", autoscaler.MaxNodeProvisionTime())
+	}
+	scaleDown, ok := autoscaler.GetScaleDown()
+	if !ok {
+		t.Fatalf("expected scale down settings")
+	}
+	if scaleDown.DelayAfterAdd() != "5m" {
+		t.Errorf("expected delay after add '5m', got '%s'", scaleDown.DelayAfterAdd())
+	}
+	if _, ok := scaleDown.GetDelayAfterDelete(); ok {
+		t.Errorf("expected delay after delete to be unset")
+	}
+
+	autoscaler, err = buildClusterAutoscaler(ClusterAutoscaler{
+		MaxNodeProvisionTime: "20m",
+	}).Build()
+	if err != nil {
+		t.Fatalf("failed to build cluster autoscaler: %v", err)
+	}
+	if _, ok := autoscaler.GetScaleDown(); ok {
+		t.Errorf("expected scale down settings to be unset")
+	}
+}
+
+func TestValidateNetworkCIDRs(t *testing.T) {
+	cidr := func(value string) net.IPNet {
+		if value == "" {
+			return net.IPNet{}
+		}
+		_, result, err := net.ParseCIDR(value)
+		if err != nil {
+			t.Fatalf("can't parse CIDR '%s': %v", value, err)
+		}
+		return *result
+	}
+	tests := []struct {
+		machine    string
+		service    string
+		pod        string
+		hostPrefix int
+		message    string
+	}{
+		{machine: "10.0.0.0/16", service: "172.30.0.0/16", pod: "10.128.0.0/14", hostPrefix: 23},
+		{machine: "", service: "", pod: "", hostPrefix: 0},
+		{machine: "10.0.0.0/16", service: "", pod: "", hostPrefix: 23},
+		{machine: "10.0.0.0/16", service: "10.0.0.0/16", pod: "10.128.0.0/14", hostPrefix: 23,
+			message: "Machine CIDR '10.0.0.0/16' overlaps with service CIDR '10.0.0.0/16'"},
+		{machine: "10.0.0.0/8", service: "172.30.0.0/16", pod: "10.128.0.0/14", hostPrefix: 23,
+			message: "Machine CIDR '10.0.0.0/8' overlaps with pod CIDR '10.128.0.0/14'"},
+		{machine: "10.0.0.0/16", service: "10.130.0.0/16", pod: "10.128.0.0/14", hostPrefix: 23,
+			message: "Service CIDR '10.130.0.0/16' overlaps with pod CIDR '10.128.0.0/14'"},
+		{machine: "", service: "172.30.0.0/16", pod: "172.16.0.0/12", hostPrefix: 23,
+			message: "Service CIDR '172.30.0.0/16' overlaps with pod CIDR '172.16.0.0/12'"},
+		{machine: "10.0.0.0/16", service: "172.30.0.0/16", pod: "10.128.0.0/14", hostPrefix: 13,
+			message: "Host prefix /13 doesn't fit within pod CIDR '10.128.0.0/14', it must be " +
+				"between /14 and /32"},
+		{machine: "10.0.0.0/16", service: "172.30.0.0/16", pod: "10.128.0.0/14", hostPrefix: 33,
+			message: "Host prefix /33 doesn't fit within pod CIDR '10.128.0.0/14', it must be " +
+				"between /14 and /32"},
+	}
+
+	for _, test := range tests {
+		err := ValidateNetworkCIDRs(cidr(test.machine), cidr(test.service), cidr(test.pod),
+			test.hostPrefix)
+		if test.message == "" && err != nil {
+			t.Errorf("expected machine CIDR '%s', service CIDR '%s', pod CIDR '%s' and host "+
+				"prefix %d to be valid, got: %v",
+				test.machine, test.service, test.pod, test.hostPrefix, err)
+		}
+		if test.message != "" && (err == nil || err.Error() != test.message) {
+			t.Errorf("expected error '%s', got: %v", test.message, err)
+		}
+	}
+}
+
+func TestReadGCPCredentials(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		file := filepath.Join(dir, name)
+		err := os.WriteFile(file, []byte(content), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+	valid := write("valid.json", `{
+		"type": "service_account",
+		"project_id": "my-project",
+		"private_key_id": "my-key-id",
+		"private_key": "my-key",
+		"client_email": "osd-ccs-admin@my-project.iam.gserviceaccount.com",
+		"client_id": "123"
+	}`)
+	tests := []struct {
+		file string
+		err  string
+	}{
+		{file: valid},
+		{file: filepath.Join(dir, "missing.json"), err: "Can't read service account file"},
+		{file: write("invalid.json", `{`), err: "isn't valid JSON"},
+		{
+			file: write("incomplete.json", `{"type": "service_account", "project_id": "my-project"}`),
+			err:  "doesn't contain a service account key",
+		},
+	}
+
+	for _, test := range tests {
+		credentials, err := ReadGCPCredentials(test.file)
+		if test.err == "" {
+			if err != nil {
+				t.Errorf("expected '%s' to be valid, got: %v", test.file, err)
+			} else if credentials.ProjectID != "my-project" || credentials.ClientID != "123" {
+				t.Errorf("unexpected credentials read from '%s': %+v", test.file, credentials)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("expected reading '%s' to fail with '%s', got: %v", test.file, test.err, err)
+		}
+	}
+}
@@ -18,6 +18,8 @@ package cluster
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -26,6 +28,8 @@ import (
 	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+
+	"github.com/openshift-online/ocm-cli/pkg/utils"
 )
 
 const (
@@ -162,21 +166,14 @@ func PrintClusterDescription(connection *sdk.Connection, cluster *cmv1.Cluster)
 	}
 
 	// Print short cluster description:
-	fmt.Printf("\n"+
-		"ID:				%s\n"+
-		"External ID:			%s\n"+
-		"Name:				%s\n"+
-		"Domain Prefix:			%s\n"+
-		"Display Name:			%s\n"+
-		"State:				%s %s\n",
-		cluster.ID(),
-		cluster.ExternalID(),
-		cluster.Name(),
-		cluster.DomainPrefix(),
-		sub.DisplayName(),
-		cluster.State(),
-		provisioningStatus,
-	)
+	fmt.Println()
+	printField(FieldID, cluster.ID())
+	printField(FieldExternalID, cluster.ExternalID())
+	printField(FieldName, cluster.Name())
+	printField(FieldDomainPrefix, cluster.DomainPrefix())
+	displayName, historyURL := subscriptionDetails(sub)
+	fmt.Printf("Display Name:			%s\n", displayName)
+	printField(FieldState, fmt.Sprintf("%s %s", cluster.State(), provisioningStatus))
 
 	if cluster.Status().Description() != "" {
 		fmt.Printf("Details:			%s\n",
@@ -184,46 +181,26 @@ func PrintClusterDescription(connection *sdk.Connection, cluster *cmv1.Cluster)
 		)
 	}
 
-	var computesStr string
-	if cluster.Nodes().AutoscaleCompute() != nil {
-		computesStr = fmt.Sprintf("%d-%d (Autoscaled)",
-			cluster.Nodes().AutoscaleCompute().MinReplicas(),
-			cluster.Nodes().AutoscaleCompute().MaxReplicas(),
-		)
-	} else {
-		computesStr = strconv.Itoa(cluster.Nodes().Compute())
-	}
-
-	fmt.Printf("API URL:			%s\n"+
-		"API Listening:			%s\n"+
-		"Console URL:			%s\n"+
-		"Cluster History URL:		%s\n"+
+	printField(FieldAPIURL, apiURL)
+	printField(FieldAPIListening, apiListening)
+	printField(FieldConsoleURL, cluster.Console().URL())
+	fmt.Printf("Cluster History URL:		%s\n"+
 		"Control Plane:\n			%s\n"+
 		"Infra:\n			%s\n"+
-		"Compute:\n			%s\n"+
-		"Product:			%s\n"+
-		"Subscription type:		%s\n"+
-		"Provider:			%s\n"+
-		"Version:			%s\n"+
-		"Region:				%s\n"+
-		"Multi-az:			%t\n"+
-		"CNI Type:			%s\n",
-		apiURL,
-		apiListening,
-		cluster.Console().URL(),
-		fmt.Sprintf("https://cloud.redhat.com/openshift/details/s/%s#clusterHistory", cluster.Subscription().ID()),
+		"Compute:\n			%s\n",
+		historyURL,
 		printNodeInfo(strconv.Itoa(cluster.Nodes().Master()), cluster.AWS().AdditionalControlPlaneSecurityGroupIds()),
 		printNodeInfo(strconv.Itoa(cluster.Nodes().Infra()), cluster.AWS().AdditionalInfraSecurityGroupIds()),
 		// To view additional compute SGs customer can use describe machine-pool
-		printNodeInfo(computesStr, []string{}),
-		cluster.Product().ID(),
-		cluster.BillingModel(),
-		cluster.CloudProvider().ID(),
-		cluster.OpenshiftVersion(),
-		cluster.Region().ID(),
-		cluster.MultiAZ(),
-		cluster.Network().Type(),
+		printNodeInfo(computeReplicas(cluster.Nodes()), []string{}),
 	)
+	printField(FieldProduct, cluster.Product().ID())
+	printField(FieldSubscriptionType, cluster.BillingModel())
+	printField(FieldProvider, cluster.CloudProvider().ID())
+	printField(FieldVersion, cluster.OpenshiftVersion())
+	printField(FieldRegion, cluster.Region().ID())
+	printField(FieldMultiAZ, cluster.MultiAZ())
+	printField(FieldCNIType, cluster.Network().Type())
 
 	// AWS-specific info
 	if cluster.CloudProvider().ID() == ProviderAWS {
@@ -263,31 +240,26 @@ func PrintClusterDescription(connection *sdk.Connection, cluster *cmv1.Cluster)
 		}
 	}
 
-	fmt.Printf("CCS:				%t\n"+
-		"HCP:				%t\n"+
-		"Existing VPC:			%s\n"+
-		"Channel Group:			%v\n"+
-		"Cluster Admin:			%t\n"+
+	printField(FieldCCS, cluster.CCS().Enabled())
+	printField(FieldHCP, cluster.Hypershift().Enabled())
+	fmt.Printf("Existing VPC:			%s\n", isExistingVPC)
+	printField(FieldChannelGroup, cluster.Version().ChannelGroup())
+	fmt.Printf("Cluster Admin:			%t\n"+
 		"Organization:			%s\n"+
 		"Creator:			%s\n"+
 		"Email:				%s\n"+
-		"AccountNumber:          	%s\n"+
-		"Created:			%v\n",
-		cluster.CCS().Enabled(),
-		cluster.Hypershift().Enabled(),
-		isExistingVPC,
-		cluster.Version().ChannelGroup(),
+		"AccountNumber:          	%s\n",
 		clusterAdminEnabled,
 		organization,
 		creator,
 		email,
 		accountNumber,
-		cluster.CreationTimestamp().Round(time.Second).Format(time.RFC3339Nano),
 	)
+	printField(FieldCreationTimestamp, cluster.CreationTimestamp().Round(time.Second).Format(time.RFC3339Nano))
 
 	expirationTime, hasExpirationTimestamp := cluster.GetExpirationTimestamp()
 	if hasExpirationTimestamp {
-		fmt.Printf("Expiration:			%v\n", expirationTime.Round(time.Second).Format(time.RFC3339Nano))
+		printField(FieldExpirationTimestamp, expirationTime.Round(time.Second).Format(time.RFC3339Nano))
 	}
 
 	// Hive
@@ -327,6 +299,108 @@ func PrintClusterDescription(connection *sdk.Connection, cluster *cmv1.Cluster)
 	return nil
 }
 
+// PrintNodesSummary prints a summary of the nodes of the cluster: the number of control plane,
+// infra and compute nodes, and the instance type of the compute nodes.
+func PrintNodesSummary(cluster *cmv1.Cluster) {
+	fmt.Print(nodesSummary(cluster))
+}
+
+func nodesSummary(cluster *cmv1.Cluster) string {
+	nodes := cluster.Nodes()
+	return fieldLine(FieldID, cluster.ID()) +
+		fieldLine(FieldName, cluster.Name()) +
+		fieldLine(FieldControlPlaneNodes, nodes.Master()) +
+		fieldLine(FieldInfraNodes, nodes.Infra()) +
+		fieldLine(FieldComputeNodes, computeReplicas(nodes)) +
+		fieldLine(FieldComputeMachineType, nodes.ComputeMachineType().ID())
+}
+
+// PrintProxySummary prints the cluster-wide proxy settings of the cluster and whether an
+// additional trust bundle is configured. The content of the trust bundle isn't printed.
+func PrintProxySummary(cluster *cmv1.Cluster) {
+	fmt.Print(proxySummary(cluster))
+}
+
+func proxySummary(cluster *cmv1.Cluster) string {
+	proxy := cluster.Proxy()
+	return fieldLine(FieldID, cluster.ID()) +
+		fieldLine(FieldName, cluster.Name()) +
+		fieldLine(FieldHTTPProxy, valueOrNotAvailable(proxy.HTTPProxy())) +
+		fieldLine(FieldHTTPSProxy, valueOrNotAvailable(proxy.HTTPSProxy())) +
+		fieldLine(FieldNoProxy, valueOrNotAvailable(proxy.NoProxy())) +
+		fieldLine(FieldAdditionalTrustBundle, cluster.AdditionalTrustBundle() != "")
+}
+
+// valueOrNotAvailable returns the given value, or N/A if it is empty.
+func valueOrNotAvailable(value string) string {
+	if value == "" {
+		return notAvailable
+	}
+	return value
+}
+
+// subscriptionDetails returns the display name of the cluster and the URL of its history in the
+// console. Clusters that aren't reporting metrics may not have a subscription, and then both
+// values are N/A.
+func subscriptionDetails(sub *amv1.Subscription) (displayName string, historyURL string) {
+	if sub == nil || sub.ID() == "" {
+		return notAvailable, notAvailable
+	}
+	displayName = valueOrNotAvailable(sub.DisplayName())
+	historyURL = fmt.Sprintf("https://cloud.redhat.com/openshift/details/s/%s#clusterHistory", sub.ID())
+	return
+}
+
+// PrintTimestamps prints when the cluster was created, when it expires, if it has an expiration
+// time, and when its subscription was last updated. Each time is printed in RFC3339 format and
+// relative to now.
+func PrintTimestamps(connection *sdk.Connection, cluster *cmv1.Cluster) error {
+	var updated time.Time
+	subID := cluster.Subscription().ID()
+	if subID != "" {
+		response, err := connection.AccountsMgmt().V1().Subscriptions().Subscription(subID).Get().Send()
+		if err != nil && (response == nil || response.Status() != 404) {
+			return fmt.Errorf("Can't get subscription '%s': %v", subID, err)
+		}
+		updated = response.Body().UpdatedAt()
+	}
+	fmt.Print(timestamps(cluster, updated, time.Now()))
+	return nil
+}
+
+func timestamps(cluster *cmv1.Cluster, updated time.Time, now time.Time) string {
+	result := fieldLine(FieldID, cluster.ID()) +
+		fieldLine(FieldName, cluster.Name()) +
+		fieldLine(FieldCreationTimestamp, timestampValue(cluster.CreationTimestamp(), now))
+	expiration, ok := cluster.GetExpirationTimestamp()
+	if ok {
+		result += fieldLine(FieldExpirationTimestamp, timestampValue(expiration, now))
+	}
+	result += fieldLine(fieldLastUpdated, timestampValue(updated, now))
+	return result
+}
+
+// timestampValue returns the given time in RFC3339 format followed by the time relative to now,
+// or N/A if the time is zero.
+func timestampValue(t time.Time, now time.Time) string {
+	if t.IsZero() {
+		return notAvailable
+	}
+	return fmt.Sprintf("%s (%s)", t.Round(time.Second).Format(time.RFC3339), utils.RelativeTime(t, now))
+}
+
+// computeReplicas returns the number of compute nodes, or the range of the number of nodes if
+// autoscaling is enabled.
+func computeReplicas(nodes *cmv1.ClusterNodes) string {
+	if nodes.AutoscaleCompute() != nil {
+		return fmt.Sprintf("%d-%d (Autoscaled)",
+			nodes.AutoscaleCompute().MinReplicas(),
+			nodes.AutoscaleCompute().MaxReplicas(),
+		)
+	}
+	return strconv.Itoa(nodes.Compute())
+}
+
 func printNodeInfo(replicasInfo string, securityGroups []string) string {
 	nodeStr := fmt.Sprintf("\tReplicas: %s", replicasInfo)
 	if len(securityGroups) > 0 {
@@ -369,16 +443,58 @@ func findHyperShiftMgmtSvcClusters(conn *sdk.Connection, cluster *cmv1.Cluster)
 	return mgmtClusterName, ""
 }
 
-func PrintClusterWarnings(connection *sdk.Connection, cluster *cmv1.Cluster) error {
+// ClusterWarning is a warning about a cluster, taken from its service logs.
+type ClusterWarning struct {
+	// Code is the identifier of the service log entry that contains the warning.
+	Code string `json:"code"`
+
+	// Summary is the one line title of the warning.
+	Summary string `json:"summary"`
+
+	// Message is the detailed description of the warning.
+	Message string `json:"message"`
+}
+
+// GetClusterWarnings returns the warnings of the given cluster, without printing them, so that
+// they can be used in different output formats.
+func GetClusterWarnings(connection *sdk.Connection, cluster *cmv1.Cluster) ([]ClusterWarning, error) {
 	serviceLogs, err := connection.ServiceLogs().V1().Clusters().ClusterLogs().List().ClusterID(cluster.ID()).Send()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	serviceLogs.Items().Each(func(entry *slv1.LogEntry) bool {
-		if entry.Severity() == slv1.SeverityWarning {
-			fmt.Printf("⚠️ WARNING:\n%s\n%s\n", entry.Summary(), entry.Description())
+	return clusterWarnings(serviceLogs.Items().Slice()), nil
+}
+
+// clusterWarnings extracts the warnings from the given service log entries.
+func clusterWarnings(entries []*slv1.LogEntry) []ClusterWarning {
+	warnings := []ClusterWarning{}
+	for _, entry := range entries {
+		if entry.Severity() != slv1.SeverityWarning {
+			continue
 		}
-		return true
-	})
+		warnings = append(warnings, ClusterWarning{
+			Code:    entry.ID(),
+			Summary: entry.Summary(),
+			Message: entry.Description(),
+		})
+	}
+	return warnings
+}
+
+// PrintWarnings writes the given warnings to the given stream in human readable format.
+func PrintWarnings(stream io.Writer, warnings []ClusterWarning) {
+	for _, warning := range warnings {
+		fmt.Fprintf(stream, "⚠️ WARNING:\n%s\n%s\n", warning.Summary, warning.Message)
+	}
+}
+
+// PrintClusterWarnings gets the warnings of the given cluster and prints them to the standard
+// output in human readable format. Use GetClusterWarnings for other output formats.
+func PrintClusterWarnings(connection *sdk.Connection, cluster *cmv1.Cluster) error {
+	warnings, err := GetClusterWarnings(connection, cluster)
+	if err != nil {
+		return err
+	}
+	PrintWarnings(os.Stdout, warnings)
 	return nil
 }
@@ -1,8 +1,13 @@
 package cluster
 
 import (
-	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"bytes"
 	"testing"
+	"time"
+
+	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
 )
 
 // newTestCluster assembles a *cmv1.Cluster while handling the error to help out with inline test-case generation
@@ -38,3 +43,213 @@ func TestFindHyperShiftMgmtSvcClusters(t *testing.T) {
 		}
 	}
 }
+
+func TestNodesSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		cluster  *cmv1.Cluster
+		expected string
+	}{
+		{
+			name: "Fixed number of compute nodes",
+			cluster: newTestCluster(t, cmv1.NewCluster().ID("123").Name("my-cluster").Nodes(
+				cmv1.NewClusterNodes().
+					Master(3).
+					Infra(2).
+					Compute(4).
+					ComputeMachineType(cmv1.NewMachineType().ID("m5.xlarge")),
+			)),
+			expected: "ID:\t\t\t\t123\n" +
+				"Name:\t\t\t\tmy-cluster\n" +
+				"Control Plane Nodes:\t\t3\n" +
+				"Infra Nodes:\t\t\t2\n" +
+				"Compute Nodes:\t\t\t4\n" +
+				"Compute Instance Type:\t\tm5.xlarge\n",
+		},
+		{
+			name: "Autoscaled compute nodes",
+			cluster: newTestCluster(t, cmv1.NewCluster().ID("456").Name("your-cluster").Nodes(
+				cmv1.NewClusterNodes().
+					Master(3).
+					Infra(2).
+					AutoscaleCompute(cmv1.NewMachinePoolAutoscaling().MinReplicas(2).MaxReplicas(6)).
+					ComputeMachineType(cmv1.NewMachineType().ID("n2-standard-4")),
+			)),
+			expected: "ID:\t\t\t\t456\n" +
+				"Name:\t\t\t\tyour-cluster\n" +
+				"Control Plane Nodes:\t\t3\n" +
+				"Infra Nodes:\t\t\t2\n" +
+				"Compute Nodes:\t\t\t2-6 (Autoscaled)\n" +
+				"Compute Instance Type:\t\tn2-standard-4\n",
+		},
+	}
+
+	for _, test := range tests {
+		summary := nodesSummary(test.cluster)
+		if summary != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, summary)
+		}
+	}
+}
+
+func TestClusterWarnings(t *testing.T) {
+	var entries []*slv1.LogEntry
+	for _, builder := range []*slv1.LogEntryBuilder{
+		slv1.NewLogEntry().ID("123").Severity(slv1.SeverityWarning).
+			Summary("Quota almost exhausted").Description("Only one node left."),
+		slv1.NewLogEntry().ID("456").Severity(slv1.SeverityInfo).
+			Summary("Cluster installed").Description("The cluster is ready."),
+	} {
+		entry, err := builder.Build()
+		if err != nil {
+			t.Fatalf("failed to build log entry: %s", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	warnings := clusterWarnings(entries)
+	expected := []ClusterWarning{{
+		Code:    "123",
+		Summary: "Quota almost exhausted",
+		Message: "Only one node left.",
+	}}
+	if len(warnings) != 1 || warnings[0] != expected[0] {
+		t.Fatalf("expected warnings %v, got %v", expected, warnings)
+	}
+
+	var buffer bytes.Buffer
+	PrintWarnings(&buffer, warnings)
+	text := "⚠️ WARNING:\nQuota almost exhausted\nOnly one node left.\n"
+	if buffer.String() != text {
+		t.Errorf("expected output %q, got %q", text, buffer.String())
+	}
+}
+
+func TestProxySummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		cluster  *cmv1.Cluster
+		expected string
+	}{
+		{
+			name: "Proxy with additional trust bundle",
+			cluster: newTestCluster(t, cmv1.NewCluster().ID("123").Name("my-cluster").
+				Proxy(
+					cmv1.NewProxy().
+						HTTPProxy("http://proxy.example.com:8080").
+						HTTPSProxy("https://proxy.example.com:8443").
+						NoProxy("example.com,10.0.0.0/16"),
+				).
+				AdditionalTrustBundle("-----BEGIN CERTIFICATE-----")),
+			expected: "ID:\t\t\t\t123\n" +
+				"Name:\t\t\t\tmy-cluster\n" +
+				"HTTP Proxy:\t\t\thttp://proxy.example.com:8080\n" +
+				"HTTPS Proxy:\t\t\thttps://proxy.example.com:8443\n" +
+				"No Proxy:\t\t\texample.com,10.0.0.0/16\n" +
+				"Additional Trust Bundle:\ttrue\n",
+		},
+		{
+			name:    "No proxy",
+			cluster: newTestCluster(t, cmv1.NewCluster().ID("456").Name("your-cluster")),
+			expected: "ID:\t\t\t\t456\n" +
+				"Name:\t\t\t\tyour-cluster\n" +
+				"HTTP Proxy:\t\t\tN/A\n" +
+				"HTTPS Proxy:\t\t\tN/A\n" +
+				"No Proxy:\t\t\tN/A\n" +
+				"Additional Trust Bundle:\tfalse\n",
+		},
+	}
+
+	for _, test := range tests {
+		summary := proxySummary(test.cluster)
+		if summary != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, summary)
+		}
+	}
+}
+
+func TestTimestamps(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		cluster  *cmv1.Cluster
+		updated  time.Time
+		expected string
+	}{
+		{
+			name: "With expiration",
+			cluster: newTestCluster(t, cmv1.NewCluster().ID("123").Name("my-cluster").
+				CreationTimestamp(now.Add(-3*24*time.Hour)).
+				ExpirationTimestamp(now.Add(2*time.Hour))),
+			updated: now.Add(-10 * time.Minute),
+			expected: "ID:\t\t\t\t123\n" +
+				"Name:\t\t\t\tmy-cluster\n" +
+				"Created:\t\t\t2024-05-07T12:00:00Z (3 days ago)\n" +
+				"Expiration:\t\t\t2024-05-10T14:00:00Z (in 2 hours)\n" +
+				"Last Updated:\t\t\t2024-05-10T11:50:00Z (10 minutes ago)\n",
+		},
+		{
+			name: "Without expiration or subscription",
+			cluster: newTestCluster(t, cmv1.NewCluster().ID("456").Name("your-cluster").
+				CreationTimestamp(now.Add(-time.Hour))),
+			expected: "ID:\t\t\t\t456\n" +
+				"Name:\t\t\t\tyour-cluster\n" +
+				"Created:\t\t\t2024-05-10T11:00:00Z (1 hour ago)\n" +
+				"Last Updated:\t\t\tN/A\n",
+		},
+	}
+
+	for _, test := range tests {
+		result := timestamps(test.cluster, test.updated, now)
+		if result != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, result)
+		}
+	}
+}
+
+func TestSubscriptionDetails(t *testing.T) {
+	withName, err := amv1.NewSubscription().ID("456").DisplayName("my-cluster").Build()
+	if err != nil {
+		t.Fatalf("failed to build subscription: %s", err)
+	}
+	withoutName, err := amv1.NewSubscription().ID("789").Build()
+	if err != nil {
+		t.Fatalf("failed to build subscription: %s", err)
+	}
+
+	tests := []struct {
+		name                string
+		sub                 *amv1.Subscription
+		expectedDisplayName string
+		expectedHistoryURL  string
+	}{
+		{
+			name:                "No subscription",
+			sub:                 nil,
+			expectedDisplayName: "N/A",
+			expectedHistoryURL:  "N/A",
+		},
+		{
+			name:                "Subscription with display name",
+			sub:                 withName,
+			expectedDisplayName: "my-cluster",
+			expectedHistoryURL:  "https://cloud.redhat.com/openshift/details/s/456#clusterHistory",
+		},
+		{
+			name:                "Subscription without display name",
+			sub:                 withoutName,
+			expectedDisplayName: "N/A",
+			expectedHistoryURL:  "https://cloud.redhat.com/openshift/details/s/789#clusterHistory",
+		},
+	}
+
+	for _, test := range tests {
+		displayName, historyURL := subscriptionDetails(test.sub)
+		if displayName != test.expectedDisplayName {
+			t.Errorf("%s: expected display name %q, got %q", test.name, test.expectedDisplayName, displayName)
+		}
+		if historyURL != test.expectedHistoryURL {
+			t.Errorf("%s: expected history URL %q, got %q", test.name, test.expectedHistoryURL, historyURL)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Field is a field of the cluster object displayed by the describe command. The column is the path
+// of the field inside the cluster object, so it can also be used as a column of the tables
+// displayed by the list command.
+type Field struct {
+	Column string
+	Label  string
+}
+
+var (
+	FieldID                = Field{Column: "id", Label: "ID"}
+	FieldExternalID        = Field{Column: "external_id", Label: "External ID"}
+	FieldName              = Field{Column: "name", Label: "Name"}
+	FieldDomainPrefix      = Field{Column: "domain_prefix", Label: "Domain Prefix"}
+	FieldState             = Field{Column: "state", Label: "State"}
+	FieldAPIURL            = Field{Column: "api.url", Label: "API URL"}
+	FieldAPIListening      = Field{Column: "api.listening", Label: "API Listening"}
+	FieldConsoleURL        = Field{Column: "console.url", Label: "Console URL"}
+	FieldProduct           = Field{Column: "product.id", Label: "Product"}
+	FieldSubscriptionType  = Field{Column: "billing_model", Label: "Subscription type"}
+	FieldProvider          = Field{Column: "cloud_provider.id", Label: "Provider"}
+	FieldVersion           = Field{Column: "openshift_version", Label: "Version"}
+	FieldRegion            = Field{Column: "region.id", Label: "Region"}
+	FieldMultiAZ           = Field{Column: "multi_az", Label: "Multi-az"}
+	FieldCNIType           = Field{Column: "network.type", Label: "CNI Type"}
+	FieldCCS               = Field{Column: "ccs.enabled", Label: "CCS"}
+	FieldHCP               = Field{Column: "hypershift.enabled", Label: "HCP"}
+	FieldChannelGroup      = Field{Column: "version.channel_group", Label: "Channel Group"}
+	FieldCreationTimestamp = Field{Column: "creation_timestamp", Label: "Created"}
+
+	FieldControlPlaneNodes  = Field{Column: "nodes.master", Label: "Control Plane Nodes"}
+	FieldInfraNodes         = Field{Column: "nodes.infra", Label: "Infra Nodes"}
+	FieldComputeNodes       = Field{Column: "nodes.compute", Label: "Compute Nodes"}
+	FieldComputeMachineType = Field{Column: "nodes.compute_machine_type.id", Label: "Compute Instance Type"}
+
+	FieldHTTPProxy             = Field{Column: "proxy.http_proxy", Label: "HTTP Proxy"}
+	FieldHTTPSProxy            = Field{Column: "proxy.https_proxy", Label: "HTTPS Proxy"}
+	FieldNoProxy               = Field{Column: "proxy.no_proxy", Label: "No Proxy"}
+	FieldAdditionalTrustBundle = Field{Column: "additional_trust_bundle", Label: "Additional Trust Bundle"}
+
+	FieldExpirationTimestamp = Field{Column: "expiration_timestamp", Label: "Expiration"}
+)
+
+// fieldLastUpdated is the time when the subscription of the cluster was last updated. It isn't a
+// field of the cluster object, so it has no column.
+var fieldLastUpdated = Field{Label: "Last Updated"}
+
+// DescribeFields contains the fields of the cluster object displayed by the describe command, in
+// the order that they are displayed.
+var DescribeFields = []Field{
+	FieldID,
+	FieldExternalID,
+	FieldName,
+	FieldDomainPrefix,
+	FieldState,
+	FieldAPIURL,
+	FieldAPIListening,
+	FieldConsoleURL,
+	FieldProduct,
+	FieldSubscriptionType,
+	FieldProvider,
+	FieldVersion,
+	FieldRegion,
+	FieldMultiAZ,
+	FieldCNIType,
+	FieldCCS,
+	FieldHCP,
+	FieldChannelGroup,
+	FieldCreationTimestamp,
+}
+
+// DescribeColumns returns the table columns that display the same fields than the describe
+// command, separated by commas.
+func DescribeColumns() string {
+	columns := make([]string, len(DescribeFields))
+	for i, field := range DescribeFields {
+		columns[i] = field.Column
+	}
+	return strings.Join(columns, ", ")
+}
+
+// describeValueColumn is the position where the describe command aligns the values of the fields.
+const describeValueColumn = 32
+
+// printField prints a line of the cluster description containing the label of the field and the
+// given value.
+func printField(field Field, value interface{}) {
+	fmt.Print(fieldLine(field, value))
+}
+
+// fieldLine returns the line of the cluster description for the given field and value, using
+// tabs to align the value.
+func fieldLine(field Field, value interface{}) string {
+	label := field.Label + ":"
+	tabs := (describeValueColumn - len(label) + 7) / 8
+	if tabs < 1 {
+		tabs = 1
+	}
+	return fmt.Sprintf("%s%s%v\n", label, strings.Repeat("\t", tabs), value)
+}
@@ -0,0 +1,41 @@
+package cluster
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFieldLine(t *testing.T) {
+	tests := []struct {
+		field    Field
+		value    interface{}
+		expected string
+	}{
+		{field: FieldID, value: "123", expected: "ID:\t\t\t\t123\n"},
+		{field: FieldExternalID, value: "abc", expected: "External ID:\t\t\tabc\n"},
+		{field: FieldRegion, value: "us-east-1", expected: "Region:\t\t\t\tus-east-1\n"},
+		{field: FieldSubscriptionType, value: "standard", expected: "Subscription type:\t\tstandard\n"},
+		{field: FieldMultiAZ, value: true, expected: "Multi-az:\t\t\ttrue\n"},
+		{field: Field{Label: "A label longer than the value column"}, value: 1,
+			expected: "A label longer than the value column:\t1\n"},
+	}
+
+	for _, test := range tests {
+		line := fieldLine(test.field, test.value)
+		if line != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, line)
+		}
+	}
+}
+
+func TestDescribeColumns(t *testing.T) {
+	columns := strings.Split(DescribeColumns(), ", ")
+	if len(columns) != len(DescribeFields) {
+		t.Fatalf("expected %d columns, got %d", len(DescribeFields), len(columns))
+	}
+	for i, field := range DescribeFields {
+		if columns[i] != field.Column {
+			t.Errorf("expected column %d to be %q, got %q", i, field.Column, columns[i])
+		}
+	}
+}
@@ -99,3 +99,39 @@ func GetEnabledVersions(client *cmv1.Client,
 	})
 	return versions, defaultVersion, nil
 }
+
+// GetChannelGroups returns the channel groups that contain an enabled version with the given raw
+// identifier (e.g. "4.16.1"), sorted alphabetically.
+func GetChannelGroups(client *cmv1.Client, rawVersion string) (channelGroups []string, err error) {
+	collection := client.Versions()
+	page := 1
+	size := 100
+	filter := fmt.Sprintf("enabled = 'true' AND raw_id = '%s'", rawVersion)
+	found := map[string]bool{}
+	for {
+		response, err := collection.List().
+			Search(filter).
+			Page(page).
+			Size(size).
+			Send()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, version := range response.Items().Slice() {
+			channelGroup := version.ChannelGroup()
+			if channelGroup != "" && !found[channelGroup] {
+				found[channelGroup] = true
+				channelGroups = append(channelGroups, channelGroup)
+			}
+		}
+
+		if response.Size() < size {
+			break
+		}
+		page++
+	}
+
+	sort.Strings(channelGroups)
+	return channelGroups, nil
+}
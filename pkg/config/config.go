@@ -149,10 +149,13 @@ func Save(cfg *Config) error {
 	return nil
 }
 
-// Location returns the location of the configuration file. If a configuration file
-// already exists in the HOME directory, it uses that, otherwise it prefers to
-// use the XDG config directory.
+// Location returns the location of the configuration file. The '--config' command line flag takes
+// precedence, then the 'OCM_CONFIG' environment variable. If a configuration file already exists
+// in the HOME directory, it uses that, otherwise it prefers to use the XDG config directory.
 func Location() (path string, err error) {
+	if flagLocation != "" {
+		return flagLocation, nil
+	}
 	if ocmconfig := os.Getenv("OCM_CONFIG"); ocmconfig != "" {
 		return ocmconfig, nil
 	}
@@ -249,15 +252,22 @@ func (c *Config) Armed() (armed bool, reason string, err error) {
 
 // Disarm removes from the configuration all the settings that are needed for authentication.
 func (c *Config) Disarm() {
-	c.AccessToken = ""
+	c.DisarmCredentials()
 	c.ClientID = ""
-	c.ClientSecret = ""
 	c.Insecure = false
-	c.Password = ""
-	c.RefreshToken = ""
 	c.Scopes = nil
 	c.TokenURL = ""
 	c.URL = ""
+}
+
+// DisarmCredentials removes from the configuration the tokens, passwords and secrets, but keeps
+// the settings that describe where and how to authenticate, like the URLs and the client
+// identifier, so that the next login uses the same environment.
+func (c *Config) DisarmCredentials() {
+	c.AccessToken = ""
+	c.ClientSecret = ""
+	c.Password = ""
+	c.RefreshToken = ""
 	c.User = ""
 }
 
@@ -171,3 +171,29 @@ var _ = Describe("Armed", func() {
 		Expect(reason).To(Equal("credentials aren't set"))
 	})
 })
+
+var _ = Describe("Credential names", func() {
+	It("Returns nothing if there are no credentials", func() {
+		config := &Config{
+			ClientID: "my-client",
+			URL:      "http://my-server.example.com",
+			TokenURL: "http://my-sso.example.com",
+		}
+		Expect(config.credentialNames()).To(BeEmpty())
+	})
+
+	It("Returns the names of the credentials that are set", func() {
+		config := &Config{
+			AccessToken:  "my-access-token",
+			ClientID:     "my-client",
+			ClientSecret: "my-secret",
+			RefreshToken: "my-refresh-token",
+			URL:          "http://my-server.example.com",
+		}
+		Expect(config.credentialNames()).To(Equal([]string{
+			"access_token",
+			"client_secret",
+			"refresh_token",
+		}))
+	})
+})
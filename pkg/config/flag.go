@@ -0,0 +1,37 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--config' command line option.
+
+package config
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// AddFlag adds the config file flag to the given set of command line flags.
+func AddFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&flagLocation,
+		"config",
+		"",
+		"Location of the configuration file. Takes precedence over the OCM_CONFIG "+
+			"environment variable and the default location.",
+	)
+}
+
+// flagLocation is the value of the config file flag.
+var flagLocation string
@@ -0,0 +1,140 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to move the configuration from the configuration file
+// to the OS keyring.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/openshift-online/ocm-sdk-go/authentication/securestore"
+)
+
+// Migration describes the result of moving the configuration file to the OS keyring.
+type Migration struct {
+	// Keyring is the name of the keyring backend where the configuration was stored.
+	Keyring string
+
+	// File is the location of the configuration file that was rewritten.
+	File string
+
+	// Backup is the location of the copy of the original configuration file.
+	Backup string
+
+	// Credentials contains the names of the tokens, passwords and secrets that were removed from
+	// the configuration file.
+	Credentials []string
+}
+
+// MigrateToKeyring stores the configuration loaded from the configuration file in the given
+// keyring backend. The original file is copied to a backup file next to it, and then rewritten
+// without the tokens, passwords and secrets, keeping only the settings that describe where and
+// how to authenticate.
+func MigrateToKeyring(keyring string) (result *Migration, err error) {
+	if current, ok := IsKeyringManaged(); ok {
+		err = fmt.Errorf("can't migrate, configuration is already managed by keyring '%s'", current)
+		return
+	}
+	err = securestore.ValidateBackend(keyring)
+	if err != nil {
+		return
+	}
+
+	// Load the current configuration file:
+	file, err := Location()
+	if err != nil {
+		return
+	}
+	// #nosec G304
+	original, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		err = fmt.Errorf("can't migrate, config file '%s' doesn't exist", file)
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("can't read config file '%s': %v", file, err)
+		return
+	}
+	cfg, err := loadFromFile()
+	if err != nil {
+		return
+	}
+	credentials := cfg.credentialNames()
+	if len(credentials) == 0 {
+		err = fmt.Errorf("can't migrate, config file '%s' doesn't contain credentials", file)
+		return
+	}
+
+	// Store the complete configuration in the keyring before touching the file, so that a
+	// failure doesn't lose the credentials:
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		err = fmt.Errorf("can't marshal config: %v", err)
+		return
+	}
+	err = securestore.UpsertConfigToKeyring(keyring, data)
+	if err != nil {
+		err = fmt.Errorf("can't save config to OS keyring [%s]: %v", keyring, err)
+		return
+	}
+
+	// Keep a copy of the original file and then remove the credentials from it:
+	backup := file + ".bak"
+	err = os.WriteFile(backup, original, 0600)
+	if err != nil {
+		err = fmt.Errorf("can't write backup file '%s': %v", backup, err)
+		return
+	}
+	cfg.DisarmCredentials()
+	err = Save(cfg)
+	if err != nil {
+		return
+	}
+
+	result = &Migration{
+		Keyring:     keyring,
+		File:        file,
+		Backup:      backup,
+		Credentials: credentials,
+	}
+	return
+}
+
+// credentialNames returns the names of the settings of the configuration that contain tokens,
+// passwords or secrets, in the same order used by the configuration file.
+func (c *Config) credentialNames() []string {
+	var names []string
+	if c.AccessToken != "" {
+		names = append(names, "access_token")
+	}
+	if c.ClientSecret != "" {
+		names = append(names, "client_secret")
+	}
+	if c.Password != "" {
+		names = append(names, "password")
+	}
+	if c.RefreshToken != "" {
+		names = append(names, "refresh_token")
+	}
+	if c.User != "" {
+		names = append(names, "user")
+	}
+	return names
+}
@@ -127,3 +127,19 @@ func TokenType(token *jwt.Token) (typ string, err error) {
 	typ = value
 	return
 }
+
+// TokenExpiresAt returns the time when the given token expires, or the zero time if it doesn't
+// expire. Encrypted tokens can't be parsed, so callers should check them with IsEncryptedToken
+// first.
+func TokenExpiresAt(textToken string) (expiresAt time.Time, err error) {
+	token, err := ParseToken(textToken)
+	if err != nil {
+		return
+	}
+	expires, left, err := tokenExpiration(token)
+	if err != nil || !expires {
+		return
+	}
+	expiresAt = time.Now().Add(left).Round(time.Second)
+	return
+}
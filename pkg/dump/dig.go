@@ -0,0 +1,113 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dump
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/openshift-online/ocm-sdk-go/data"
+)
+
+// digSegmentRE matches a segment of a path, which is a field name optionally followed by one or
+// more array indexes, like 'items[0]'.
+var digSegmentRE = regexp.MustCompile(`^([^\[\]]*)((?:\[\d+\])*)$`)
+
+// digIndexRE matches the array indexes of a segment of a path.
+var digIndexRE = regexp.MustCompile(`\[(\d+)\]`)
+
+// Dig extracts the value in the given path from the given JSON document and dumps it to the given
+// stream. The path contains field names separated by dots, and each field name can be followed by
+// array indexes, for example '.items[0].name'. Strings, numbers and booleans are dumped as text,
+// without quotes. Objects and arrays are dumped as JSON, in a single line if single is true. Values
+// that don't exist are dumped as 'null'.
+func Dig(stream io.Writer, body []byte, path string, single bool) error {
+	var object interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	err := decoder.Decode(&object)
+	if err != nil {
+		return fmt.Errorf("Can't extract '%s', the body isn't a JSON document: %v", path, err)
+	}
+
+	value, err := dig(object, path)
+	if err != nil {
+		return err
+	}
+
+	var text []byte
+	switch typed := value.(type) {
+	case nil:
+		text = []byte("null")
+	case string:
+		text = []byte(typed)
+	case json.Number:
+		text = []byte(typed.String())
+	case bool:
+		text = []byte(strconv.FormatBool(typed))
+	default:
+		if single {
+			text, err = json.Marshal(typed)
+		} else {
+			text, err = json.MarshalIndent(typed, "", "  ")
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return dumpBytes(stream, text)
+}
+
+// dig returns the value in the given path of the given object, or nil if it doesn't exist.
+func dig(object interface{}, path string) (interface{}, error) {
+	digger, err := data.NewDigger().Build(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	path = strings.TrimPrefix(strings.TrimSpace(path), ".")
+	if path == "" {
+		return object, nil
+	}
+	for _, segment := range strings.Split(path, ".") {
+		matches := digSegmentRE.FindStringSubmatch(strings.TrimSpace(segment))
+		if matches == nil {
+			return nil, fmt.Errorf("Path segment '%s' isn't valid", segment)
+		}
+		if matches[1] != "" {
+			object = digger.Dig(object, matches[1])
+		}
+		for _, index := range digIndexRE.FindAllStringSubmatch(matches[2], -1) {
+			position, _ := strconv.Atoi(index[1])
+			items, ok := object.([]interface{})
+			if !ok || position >= len(items) {
+				return nil, nil
+			}
+			object = items[position]
+		}
+		if object == nil {
+			return nil, nil
+		}
+	}
+	return object, nil
+}
@@ -18,8 +18,11 @@ limitations under the License.
 package dump
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
+	"os"
+	"path/filepath"
 	"runtime"
 
 	"github.com/nwidger/jsoncolor"
@@ -45,6 +48,34 @@ func Pretty(stream io.Writer, body []byte) error {
 	return dumpMonochrome(stream, data)
 }
 
+// Raw dumps the given data to the given stream exactly as it is, without indenting it and without
+// adding colors or a trailing line break.
+func Raw(stream io.Writer, body []byte) error {
+	_, err := stream.Write(body)
+	return err
+}
+
+// CreateFile creates the file where a response body will be dumped, including the parent
+// directories if needed. The file will only be readable and writable by the owner, as it may
+// contain sensitive data, also when it already existed with other permissions. The caller should
+// check the error returned when closing the file, as that is when writes may fail.
+func CreateFile(path string) (*os.File, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, err
+	}
+	err = file.Chmod(0600)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return file, nil
+}
+
 func dumpColor(stream io.Writer, data interface{}) error {
 	encoder := jsoncolor.NewEncoder(stream)
 	encoder.SetEscapeHTML(false)
@@ -75,6 +106,21 @@ func Single(stream io.Writer, body []byte) error {
 	return dumpMonochromeSingleLine(stream, data)
 }
 
+// Compact dumps the given data to the given stream as a single line without white space, keeping
+// the order of the fields and the values exactly as they were received. Unlike Single it never
+// adds colors, so the result is suitable for appending to log files.
+func Compact(stream io.Writer, body []byte) error {
+	if len(body) == 0 {
+		return nil
+	}
+	buffer := &bytes.Buffer{}
+	err := json.Compact(buffer, body)
+	if err != nil {
+		return dumpBytes(stream, body)
+	}
+	return dumpBytes(stream, buffer.Bytes())
+}
+
 func dumpColorSingleLine(stream io.Writer, data interface{}) error {
 	encoder := jsoncolor.NewEncoder(stream)
 	err := encoder.Encode(data)
@@ -0,0 +1,106 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dump
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Flatten dumps the given JSON document to the given stream as one 'path = value' line for each
+// scalar value, keeping the order of the fields as they were received. Paths use the same syntax
+// accepted by Dig, field names separated by dots and array indexes in brackets, for example
+// 'items[0].nodes.compute = 3'. Strings are dumped without quotes, and empty objects and arrays as
+// '{}' and '[]'. Bodies that aren't JSON are dumped as they are.
+func Flatten(stream io.Writer, body []byte) error {
+	if len(body) == 0 {
+		return nil
+	}
+	buffer := &bytes.Buffer{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	err := flatten(buffer, decoder, "")
+	if err != nil {
+		return dumpBytes(stream, body)
+	}
+	_, err = stream.Write(buffer.Bytes())
+	return err
+}
+
+// flatten reads the next value from the decoder and writes the lines for it, using the given path
+// as prefix.
+func flatten(buffer *bytes.Buffer, decoder *json.Decoder, path string) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	switch token {
+	case json.Delim('{'):
+		empty := true
+		for decoder.More() {
+			empty = false
+			token, err = decoder.Token()
+			if err != nil {
+				return err
+			}
+			name := token.(string)
+			if path != "" {
+				name = path + "." + name
+			}
+			err = flatten(buffer, decoder, name)
+			if err != nil {
+				return err
+			}
+		}
+		if empty {
+			writeFlattened(buffer, path, "{}")
+		}
+		_, err = decoder.Token()
+		return err
+	case json.Delim('['):
+		index := 0
+		for decoder.More() {
+			err = flatten(buffer, decoder, fmt.Sprintf("%s[%d]", path, index))
+			if err != nil {
+				return err
+			}
+			index++
+		}
+		if index == 0 {
+			writeFlattened(buffer, path, "[]")
+		}
+		_, err = decoder.Token()
+		return err
+	case nil:
+		writeFlattened(buffer, path, "null")
+	default:
+		writeFlattened(buffer, path, fmt.Sprint(token))
+	}
+	return nil
+}
+
+// writeFlattened writes one line with the given path and value. Values at the top level of the
+// document don't have a path, so only the value is written.
+func writeFlattened(buffer *bytes.Buffer, path, value string) {
+	if path == "" {
+		fmt.Fprintln(buffer, value)
+		return
+	}
+	fmt.Fprintf(buffer, "%s = %s\n", path, value)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dump
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/openshift-online/ocm-cli/pkg/output"
+)
+
+// List dumps the given items to the given stream using the given structured output format. The
+// marshal function is used to convert the items to JSON, usually it will be one of the list
+// marshalling functions of the SDK, like `cmv1.MarshalClusterList`. Items that aren't SDK types can
+// use the Items function.
+func List[T any](stream io.Writer, format output.Format, items []T,
+	marshal func([]T, io.Writer) error) error {
+	if items == nil {
+		items = []T{}
+	}
+	buffer := &bytes.Buffer{}
+	err := marshal(items, buffer)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case output.FormatJSON:
+		return dumpJSONList(stream, buffer.Bytes())
+	case output.FormatYAML:
+		return dumpYAML(stream, buffer.Bytes())
+	case output.FormatNDJSON:
+		return dumpNDJSONList(stream, buffer.Bytes())
+	default:
+		return fmt.Errorf("Output format '%s' can't be used to dump a list", format)
+	}
+}
+
+// Object dumps the given JSON document, usually a single object returned by the API, to the given
+// stream using the given structured output format.
+func Object(stream io.Writer, format output.Format, body []byte) error {
+	switch format {
+	case output.FormatJSON:
+		return Pretty(stream, body)
+	case output.FormatYAML:
+		return dumpYAML(stream, body)
+	case output.FormatNDJSON:
+		return dumpNDJSONLine(stream, body)
+	default:
+		return fmt.Errorf("Output format '%s' can't be used to dump an object", format)
+	}
+}
+
+// Items is a marshal function for the List function that converts to JSON items that aren't SDK
+// types.
+func Items[T any](items []T, writer io.Writer) error {
+	return json.NewEncoder(writer).Encode(items)
+}
+
+func dumpJSONList(stream io.Writer, body []byte) error {
+	buffer := &bytes.Buffer{}
+	err := json.Indent(buffer, bytes.TrimSpace(body), "", "  ")
+	if err != nil {
+		return err
+	}
+	buffer.WriteString("\n")
+	_, err = stream.Write(buffer.Bytes())
+	return err
+}
+
+// dumpNDJSONList writes each of the items of the given JSON array in a separate line.
+func dumpNDJSONList(stream io.Writer, body []byte) error {
+	var items []json.RawMessage
+	err := json.Unmarshal(body, &items)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		err = dumpNDJSONLine(stream, item)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dumpNDJSONLine writes the given JSON document in a single line.
+func dumpNDJSONLine(stream io.Writer, body []byte) error {
+	buffer := &bytes.Buffer{}
+	err := json.Compact(buffer, bytes.TrimSpace(body))
+	if err != nil {
+		return err
+	}
+	buffer.WriteString("\n")
+	_, err = stream.Write(buffer.Bytes())
+	return err
+}
+
+// dumpYAML converts the given JSON document to YAML. The document is parsed into a node tree
+// instead of a map so that the order of the fields is preserved.
+func dumpYAML(stream io.Writer, body []byte) error {
+	var node yaml.Node
+	err := yaml.Unmarshal(body, &node)
+	if err != nil {
+		return err
+	}
+	resetYAMLStyle(&node)
+	encoder := yaml.NewEncoder(stream)
+	encoder.SetIndent(2)
+	err = encoder.Encode(&node)
+	if err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// resetYAMLStyle removes the flow and quoting styles that the parser assigns to the nodes of a
+// JSON document, so that the result is written in block style.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
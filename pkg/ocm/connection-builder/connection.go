@@ -14,7 +14,11 @@ limitations under the License.
 package connection
 
 import (
+	"crypto/x509"
 	"fmt"
+	"net/http"
+	"net/url"
+	"os"
 
 	"github.com/golang/glog"
 	sdk "github.com/openshift-online/ocm-sdk-go"
@@ -42,6 +46,18 @@ type ConnectionBuilder struct {
 	// agent is the UserAgent for a given CLI.
 	// defaults to OCM_CLI+version
 	agent string
+
+	// proxyUrl is the URL of the proxy used to connect to the API and to the token server.
+	// defaults to the proxy configured in the environment
+	proxyUrl string
+
+	// caFile is the file containing additional CA certificates trusted by the connection.
+	// defaults to trusting only the CA certificates of the system
+	caFile string
+
+	// retryLimit is the maximum number of times that the SDK retries a failed request.
+	// defaults to the limit of the SDK
+	retryLimit *int
 }
 
 // NewConnection creates a builder that can then be used to configure and build an OCM connection.
@@ -74,6 +90,24 @@ func (b *ConnectionBuilder) AsAgent(agent string) *ConnectionBuilder {
 	return b
 }
 
+// Override the proxy configured in the environment
+func (b *ConnectionBuilder) WithProxy(value string) *ConnectionBuilder {
+	b.proxyUrl = value
+	return b
+}
+
+// Trust the CA certificates in the given file in addition to the ones of the system
+func (b *ConnectionBuilder) WithCAFile(value string) *ConnectionBuilder {
+	b.caFile = value
+	return b
+}
+
+// Override the number of times that the SDK retries a failed request
+func (b *ConnectionBuilder) WithRetryLimit(value int) *ConnectionBuilder {
+	b.retryLimit = &value
+	return b
+}
+
 // Build uses the information stored in the builder to create a new OCM connection.
 func (b *ConnectionBuilder) Build() (result *sdk.Connection, err error) {
 	if b.cfg == nil {
@@ -113,6 +147,27 @@ func (b *ConnectionBuilder) Build() (result *sdk.Connection, err error) {
 		builder.URL(b.apiUrlOverride)
 	}
 
+	if b.proxyUrl != "" {
+		var wrapper sdk.TransportWrapper
+		wrapper, err = proxyTransportWrapper(b.proxyUrl)
+		if err != nil {
+			return
+		}
+		builder.TransportWrapper(wrapper)
+	}
+
+	if b.caFile != "" {
+		err = checkCAFile(b.caFile)
+		if err != nil {
+			return
+		}
+		builder.TrustedCAFile(b.caFile)
+	}
+
+	if b.retryLimit != nil {
+		builder.RetryLimit(*b.retryLimit)
+	}
+
 	// Create the connection:
 	return builder.Build()
 }
@@ -171,6 +226,39 @@ func (b *ConnectionBuilder) getLogger() (logging.Logger, error) {
 		Build()
 }
 
+// Returns a transport wrapper that replaces the proxy of the underlying HTTP transport with the
+// given one. It needs to be the last wrapper added to the builder, as that is the one that
+// receives the transport created by the SDK.
+func proxyTransportWrapper(value string) (sdk.TransportWrapper, error) {
+	proxyUrl, err := url.Parse(value)
+	if err != nil || proxyUrl.Scheme == "" || proxyUrl.Host == "" {
+		return nil, fmt.Errorf("Invalid proxy URL '%s': it must be an absolute URL", value)
+	}
+	return func(wrapped http.RoundTripper) http.RoundTripper {
+		transport, ok := wrapped.(*http.Transport)
+		if !ok {
+			return wrapped
+		}
+		transport = transport.Clone()
+		transport.Proxy = http.ProxyURL(proxyUrl)
+		return transport
+	}, nil
+}
+
+// Checks that the given file can be read and contains at least one PEM encoded certificate, so
+// that a wrong file is reported before trying to connect.
+func checkCAFile(file string) error {
+	// #nosec G304
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("Can't read CA file '%s': %v", file, err)
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM(data) {
+		return fmt.Errorf("CA file '%s' doesn't contain any PEM encoded certificate", file)
+	}
+	return nil
+}
+
 // Returns the configured agent or a default value if there is none configured
 func (b *ConnectionBuilder) getAgent() string {
 	if b.agent != "" {
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/openshift-online/ocm-cli/pkg/ca"
 	"github.com/openshift-online/ocm-cli/pkg/debug"
 	"github.com/openshift-online/ocm-cli/pkg/info"
 	conn "github.com/openshift-online/ocm-cli/pkg/ocm/connection-builder"
 	"github.com/openshift-online/ocm-cli/pkg/properties"
+	"github.com/openshift-online/ocm-cli/pkg/proxy"
+	sdk "github.com/openshift-online/ocm-sdk-go"
 )
 
 func NewConnection() *conn.ConnectionBuilder {
@@ -23,5 +26,27 @@ func NewConnection() *conn.ConnectionBuilder {
 		connection = connection.WithApiUrl(overrideUrl)
 	}
 
+	// use the proxy given in the command line, if any, instead of the environment variables
+	if proxyUrl := proxy.URL(); proxyUrl != "" {
+		connection = connection.WithProxy(proxyUrl)
+	}
+
+	// trust the additional CA certificates given in the command line or the environment, if any
+	if caFile := ca.File(); caFile != "" {
+		connection = connection.WithCAFile(caFile)
+	}
+
 	return connection
 }
+
+// WithConnection creates a connection to the OCM API, passes it to the given function and closes
+// it when the function returns, regardless of the result.
+func WithConnection(f func(connection *sdk.Connection) error) error {
+	connection, err := NewConnection().Build()
+	if err != nil {
+		return fmt.Errorf("Failed to create OCM connection: %v", err)
+	}
+	defer connection.Close()
+
+	return f(connection)
+}
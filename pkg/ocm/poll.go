@@ -0,0 +1,146 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the types and functions used by the commands that wait for an operation to
+// complete, so that all of them poll the API in the same way.
+
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultWaitInterval is the default time between checks when waiting.
+	DefaultWaitInterval = 30 * time.Second
+
+	// DefaultWaitTimeout is the default maximum time to wait.
+	DefaultWaitTimeout = 1 * time.Hour
+)
+
+// WaitOptions contains the settings of the commands that can wait for an operation to complete.
+type WaitOptions struct {
+	Enabled  bool
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
+// Clock is the source of time used by the poller. It is replaced in tests so that they don't need
+// to wait for real.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// Predicate checks the state of the operation that is being waited for. It returns true when the
+// wait is over, or an error if it should be aborted.
+type Predicate func(ctx context.Context) (done bool, err error)
+
+// Poller calls a predicate periodically till it returns true, an error, or the timeout expires.
+// Don't create instances of this type directly, use the NewPoller function instead.
+type Poller struct {
+	interval time.Duration
+	timeout  time.Duration
+	clock    Clock
+}
+
+// NewPoller creates a poller that uses the default interval and timeout.
+func NewPoller() *Poller {
+	return &Poller{
+		interval: DefaultWaitInterval,
+		timeout:  DefaultWaitTimeout,
+		clock:    realClock{},
+	}
+}
+
+// Options sets the interval and the timeout from the given wait options.
+func (p *Poller) Options(value WaitOptions) *Poller {
+	return p.Interval(value.Interval).Timeout(value.Timeout)
+}
+
+// Interval sets the time between calls to the predicate.
+func (p *Poller) Interval(value time.Duration) *Poller {
+	p.interval = value
+	return p
+}
+
+// Timeout sets the maximum time to wait.
+func (p *Poller) Timeout(value time.Duration) *Poller {
+	p.timeout = value
+	return p
+}
+
+// Clock sets the source of time. This is intended for tests.
+func (p *Poller) Clock(value Clock) *Poller {
+	p.clock = value
+	return p
+}
+
+// Run calls the predicate immediately and then every interval till it returns true or an error.
+// It fails if the timeout expires or the context is canceled before that.
+func (p *Poller) Run(ctx context.Context, predicate Predicate) error {
+	if p.interval <= 0 {
+		return fmt.Errorf("Wait interval must be positive, but it is %s", p.interval)
+	}
+	if p.timeout <= 0 {
+		return fmt.Errorf("Wait timeout must be positive, but it is %s", p.timeout)
+	}
+	deadline := p.clock.Now().Add(p.timeout)
+	for {
+		done, err := predicate(ctx)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		remaining := deadline.Sub(p.clock.Now())
+		if remaining <= 0 {
+			return fmt.Errorf("Timed out after waiting %s", p.timeout)
+		}
+		delay := p.interval
+		if delay > remaining {
+			delay = remaining
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.clock.After(delay):
+		}
+	}
+}
+
+// IsTransientStatus returns true if the given HTTP status code means that the server couldn't
+// answer the request at that moment, but may answer it if it is sent again later. These are the
+// server errors and the 429 status code. A zero status code, used when the request couldn't be
+// sent at all, isn't considered transient.
+func IsTransientStatus(status int) bool {
+	return status >= 500 || status == http.StatusTooManyRequests
+}
+
+// realClock is the clock that uses the time of the system.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" // nolint
+	. "github.com/onsi/gomega"    // nolint
+)
+
+// fakeClock is a clock that advances only when the poller waits, so that the tests run
+// immediately.
+type fakeClock struct {
+	now    time.Time
+	delays []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.delays = append(c.delays, d)
+	c.now = c.now.Add(d)
+	result := make(chan time.Time, 1)
+	result <- c.now
+	return result
+}
+
+var _ = Describe("Poller", func() {
+	var ctx context.Context
+	var clock *fakeClock
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		clock = &fakeClock{
+			now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+	})
+
+	It("Stops as soon as the predicate is true", func() {
+		calls := 0
+		err := NewPoller().
+			Interval(10*time.Second).
+			Timeout(time.Minute).
+			Clock(clock).
+			Run(ctx, func(ctx context.Context) (bool, error) {
+				calls++
+				return calls == 3, nil
+			})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(Equal(3))
+		Expect(clock.delays).To(Equal([]time.Duration{10 * time.Second, 10 * time.Second}))
+	})
+
+	It("Fails when the timeout expires", func() {
+		calls := 0
+		err := NewPoller().
+			Interval(25*time.Second).
+			Timeout(time.Minute).
+			Clock(clock).
+			Run(ctx, func(ctx context.Context) (bool, error) {
+				calls++
+				return false, nil
+			})
+		Expect(err).To(MatchError("Timed out after waiting 1m0s"))
+		Expect(calls).To(Equal(4))
+		Expect(clock.delays).To(Equal([]time.Duration{
+			25 * time.Second,
+			25 * time.Second,
+			10 * time.Second,
+		}))
+	})
+
+	It("Stops when the predicate fails", func() {
+		calls := 0
+		err := NewPoller().
+			Clock(clock).
+			Run(ctx, func(ctx context.Context) (bool, error) {
+				calls++
+				return false, fmt.Errorf("my error")
+			})
+		Expect(err).To(MatchError("my error"))
+		Expect(calls).To(Equal(1))
+		Expect(clock.delays).To(BeEmpty())
+	})
+
+	It("Takes the interval and timeout from the wait options", func() {
+		err := NewPoller().
+			Options(WaitOptions{
+				Interval: time.Second,
+				Timeout:  3 * time.Second,
+			}).
+			Clock(clock).
+			Run(ctx, func(ctx context.Context) (bool, error) {
+				return false, nil
+			})
+		Expect(err).To(MatchError("Timed out after waiting 3s"))
+		Expect(clock.delays).To(HaveLen(3))
+	})
+
+	It("Rejects an interval that isn't positive", func() {
+		err := NewPoller().
+			Interval(0).
+			Clock(clock).
+			Run(ctx, func(ctx context.Context) (bool, error) {
+				return true, nil
+			})
+		Expect(err).To(MatchError("Wait interval must be positive, but it is 0s"))
+	})
+})
+
+var _ = Describe("Transient status", func() {
+	It("Accepts server errors and 429", func() {
+		Expect(IsTransientStatus(http.StatusInternalServerError)).To(BeTrue())
+		Expect(IsTransientStatus(http.StatusServiceUnavailable)).To(BeTrue())
+		Expect(IsTransientStatus(http.StatusTooManyRequests)).To(BeTrue())
+	})
+
+	It("Rejects other client errors and transport errors", func() {
+		Expect(IsTransientStatus(http.StatusBadRequest)).To(BeFalse())
+		Expect(IsTransientStatus(http.StatusForbidden)).To(BeFalse())
+		Expect(IsTransientStatus(http.StatusNotFound)).To(BeFalse())
+		Expect(IsTransientStatus(0)).To(BeFalse())
+	})
+})
@@ -0,0 +1,129 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to implement the '--output' command line option of the
+// list commands.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// Format is the format used by list commands to write their results.
+type Format string
+
+const (
+	// FormatTable writes the results as a table with the default columns.
+	FormatTable Format = "table"
+
+	// FormatWide writes the results as a table with extra columns in addition to the default
+	// ones.
+	FormatWide Format = "wide"
+
+	// FormatJSON writes the results as a JSON array containing the objects returned by the
+	// API.
+	FormatJSON Format = "json"
+
+	// FormatYAML writes the results as a YAML sequence containing the objects returned by the
+	// API.
+	FormatYAML Format = "yaml"
+
+	// FormatNDJSON writes each of the objects returned by the API as a JSON document in a
+	// separate line. List commands write the objects as soon as each page is received, so that
+	// the results can be processed as a stream.
+	FormatNDJSON Format = "ndjson"
+)
+
+// formats is the list of supported formats, in the order that they are presented to the user.
+var formats = []Format{
+	FormatTable,
+	FormatWide,
+	FormatJSON,
+	FormatYAML,
+	FormatNDJSON,
+}
+
+// AddFormatFlag adds the output format flag to the given set of command line flags.
+func AddFormatFlag(flags *pflag.FlagSet) {
+	flags.StringVarP(
+		&flagFormat,
+		"output",
+		"o",
+		string(FormatTable),
+		fmt.Sprintf(
+			"Output format, one of: %s. The 'wide' format displays extra columns in "+
+				"addition to the default ones. The 'ndjson' format writes one JSON object "+
+				"per line.",
+			formatNames(),
+		),
+	)
+}
+
+// SelectedFormat returns the format selected with the output format flag, or an error if the
+// value of the flag isn't one of the supported formats.
+func SelectedFormat() (Format, error) {
+	return ParseFormat(flagFormat)
+}
+
+// ParseFormat checks that the given text is one of the supported formats. An empty text selects
+// the table format.
+func ParseFormat(text string) (Format, error) {
+	if text == "" {
+		return FormatTable, nil
+	}
+	for _, format := range formats {
+		if text == string(format) {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf(
+		"Unsupported output format '%s', valid values are: %s",
+		text, formatNames(),
+	)
+}
+
+// formatNames returns a comma separated list of the names of the supported formats.
+func formatNames() string {
+	names := make([]string, len(formats))
+	for i, format := range formats {
+		names[i] = string(format)
+	}
+	return strings.Join(names, ", ")
+}
+
+// IsWide returns true if the format is a table that displays extra columns.
+func (f Format) IsWide() bool {
+	return f == FormatWide
+}
+
+// IsStructured returns true if the format writes the objects returned by the API instead of a
+// table.
+func (f Format) IsStructured() bool {
+	return f == FormatJSON || f == FormatYAML || f == FormatNDJSON
+}
+
+// IsStreamed returns true if the format writes the objects as soon as they are received, instead
+// of collecting all of them into a single document.
+func (f Format) IsStreamed() bool {
+	return f == FormatNDJSON
+}
+
+// flagFormat is the value of the output format flag.
+var flagFormat = string(FormatTable)
@@ -0,0 +1,49 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	. "github.com/onsi/ginkgo/v2" // nolint
+	. "github.com/onsi/gomega"    // nolint
+)
+
+var _ = Describe("Format", func() {
+	DescribeTable(
+		"Parses supported formats",
+		func(text string, expected Format, wide, structured, streamed bool) {
+			format, err := ParseFormat(text)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(format).To(Equal(expected))
+			Expect(format.IsWide()).To(Equal(wide))
+			Expect(format.IsStructured()).To(Equal(structured))
+			Expect(format.IsStreamed()).To(Equal(streamed))
+		},
+		Entry("Empty", "", FormatTable, false, false, false),
+		Entry("Table", "table", FormatTable, false, false, false),
+		Entry("Wide", "wide", FormatWide, true, false, false),
+		Entry("JSON", "json", FormatJSON, false, true, false),
+		Entry("YAML", "yaml", FormatYAML, false, true, false),
+		Entry("NDJSON", "ndjson", FormatNDJSON, false, true, true),
+	)
+
+	It("Rejects unsupported formats", func() {
+		_, err := ParseFormat("xml")
+		Expect(err).To(MatchError(
+			"Unsupported output format 'xml', valid values are: table, wide, json, yaml, ndjson",
+		))
+	})
+})
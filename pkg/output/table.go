@@ -41,8 +41,10 @@ type TableBuilder struct {
 	specs         []string
 	digger        *data.Digger
 	values        map[string]reflect.Value
+	formats       map[string]func(interface{}) string
 	learning      bool
 	learningLimit int
+	wide          bool
 }
 
 // Table contains the data and logic needed to write tabular output.
@@ -57,11 +59,15 @@ type Table struct {
 	learning      bool
 	learningLimit int
 	learningRows  [][]string
+
+	// Footer row, written after all the other rows when the table is flushed.
+	footer []string
 }
 
 // tableYAML is used to load a table description from a YAML document.
 type tableYAML struct {
 	Columns []*columnYAML `yaml:"columns"`
+	Wide    []string      `yaml:"wide"`
 }
 
 // Column contains the data and logic needed to write columns.
@@ -86,6 +92,9 @@ type Column struct {
 	// This can be the actual value of the column or, more generally, a function that will be
 	// called to obtain the actual value.
 	value reflect.Value
+
+	// Optional function used to convert the value of the column to the text that is displayed.
+	format func(interface{}) string
 }
 
 // columnYAML is used to load a column description from a YAML document.
@@ -100,6 +109,7 @@ func (p *Printer) NewTable() *TableBuilder {
 	return &TableBuilder{
 		printer:       p,
 		values:        map[string]reflect.Value{},
+		formats:       map[string]func(interface{}) string{},
 		learning:      true,
 		learningLimit: 100,
 	}
@@ -133,6 +143,15 @@ func (b *TableBuilder) Value(name string, value interface{}) *TableBuilder {
 	return b
 }
 
+// Format sets the function that will be used to convert the value of the given column to the text
+// that is displayed. The function is called with the value extracted from the row object, after
+// calling the function set with the Value method if there is one. It isn't called for nil values.
+// See FormatTimestamp and FormatSize for transformers that can be used for common types of values.
+func (b *TableBuilder) Format(name string, fn func(interface{}) string) *TableBuilder {
+	b.formats[name] = fn
+	return b
+}
+
 // Digger sets the digger that will be used to extract fields from row objects. If not specified the
 // digger of the printer will be used.
 func (b *TableBuilder) Digger(value *data.Digger) *TableBuilder {
@@ -160,6 +179,15 @@ func (b *TableBuilder) LearningLimit(value int) *TableBuilder {
 	return b
 }
 
+// Wide enables or disables the wide mode of the table. When wide mode is enabled the columns listed
+// in the `wide` section of the description of the table are added after the requested columns,
+// unless they have already been requested explicitly. The default value is that wide mode is
+// disabled.
+func (b *TableBuilder) Wide(value bool) *TableBuilder {
+	b.wide = value
+	return b
+}
+
 // Build uses the configuration stored in the builder to create a table.
 func (b *TableBuilder) Build(ctx context.Context) (result *Table, err error) {
 	// Check parameters:
@@ -236,6 +264,22 @@ func (b *TableBuilder) loadTable(columnNames []string) (result *Table, err error
 		return
 	}
 
+	// Add the extra columns of the wide mode:
+	if b.wide {
+		for _, wideName := range tableData.Wide {
+			found := false
+			for _, columnName := range columnNames {
+				if columnName == wideName {
+					found = true
+					break
+				}
+			}
+			if !found {
+				columnNames = append(columnNames, wideName)
+			}
+		}
+	}
+
 	// Load the descriptions of the columns from the asset:
 	columnsFromAsset := make([]*Column, len(tableData.Columns))
 	for i, columnData := range tableData.Columns {
@@ -307,6 +351,7 @@ func (b *TableBuilder) defaultColumn(columnName string) *Column {
 		learn:  b.defaultColumnLearn(columnName),
 		width:  b.defaultColumnWidth(columnName),
 		value:  b.defaultColumnValue(columnName),
+		format: b.formats[columnName],
 	}
 }
 
@@ -337,26 +382,9 @@ func (b *TableBuilder) defaultColumnValue(columnName string) reflect.Value {
 
 // WriteRow writes a row of a table using the given values.
 func (t *Table) WriteRow(rowValues []interface{}) error {
-	// Check that the number of values matches the number of columns:
-	valueCount := len(rowValues)
-	columnCount := len(t.columns)
-	if valueCount != columnCount {
-		return fmt.Errorf(
-			"table '%s' has %d columns, but %d values have been given",
-			t.name, columnCount, valueCount,
-		)
-	}
-
-	// Convert the row values to strings:
-	rowData := make([]string, columnCount)
-	for i, columnValue := range rowValues {
-		var columnData string
-		if columnValue != nil {
-			columnData = fmt.Sprintf("%v", columnValue)
-		} else {
-			columnData = "NONE"
-		}
-		rowData[i] = columnData
+	rowData, err := t.convertRow(rowValues)
+	if err != nil {
+		return err
 	}
 
 	// Try to accumulate the row for learning:
@@ -377,6 +405,55 @@ func (t *Table) WriteRow(rowValues []interface{}) error {
 	return nil
 }
 
+// Footer sets the values of a row, for example with totals, that will be written after all the
+// other rows when the table is flushed or closed. If the widths of the columns are still being
+// learned the footer is also used to learn them, so that it isn't trimmed.
+func (t *Table) Footer(footerValues ...interface{}) error {
+	footerData, err := t.convertRow(footerValues)
+	if err != nil {
+		return err
+	}
+	t.footer = footerData
+	return nil
+}
+
+// TotalFooter sets a footer row containing the given number of items in the first column, like
+// 'Total: 3', and leaving the rest of the columns empty.
+func (t *Table) TotalFooter(count int) error {
+	footerValues := make([]interface{}, len(t.columns))
+	for i := range footerValues {
+		footerValues[i] = ""
+	}
+	if len(footerValues) > 0 {
+		footerValues[0] = fmt.Sprintf("Total: %d", count)
+	}
+	return t.Footer(footerValues...)
+}
+
+// convertRow checks that the number of values matches the number of columns and converts them to
+// the strings that are displayed.
+func (t *Table) convertRow(rowValues []interface{}) ([]string, error) {
+	valueCount := len(rowValues)
+	columnCount := len(t.columns)
+	if valueCount != columnCount {
+		return nil, fmt.Errorf(
+			"table '%s' has %d columns, but %d values have been given",
+			t.name, columnCount, valueCount,
+		)
+	}
+	rowData := make([]string, columnCount)
+	for i, columnValue := range rowValues {
+		var columnData string
+		if columnValue != nil {
+			columnData = fmt.Sprintf("%v", columnValue)
+		} else {
+			columnData = "NONE"
+		}
+		rowData[i] = columnData
+	}
+	return rowData, nil
+}
+
 // accumulateRow checks if it is necessary to accumulate the given row in order to learn how to
 // display columns. If the row is accumulated it returns true. The caller should not display that
 // row yet. If the row isn't accumulated it returns false and the client should display that row.
@@ -441,6 +518,9 @@ func (t *Table) learnColumnWidths() {
 				learnedWidth = actualWidth
 			}
 		}
+		if t.footer != nil && len(t.footer[i]) > learnedWidth {
+			learnedWidth = len(t.footer[i])
+		}
 		column.Adjust(learnedWidth)
 	}
 }
@@ -494,7 +574,11 @@ func (t *Table) WriteHeaders() error {
 func (t *Table) WriteObject(object interface{}) error {
 	values := make([]interface{}, len(t.columns))
 	for i, column := range t.columns {
-		values[i] = column.Value(object)
+		value := column.Value(object)
+		if value != nil && column.format != nil {
+			value = column.format(value)
+		}
+		values[i] = value
 	}
 	return t.WriteRow(values)
 }
@@ -508,10 +592,18 @@ func (t *Table) Flush() error {
 			return err
 		}
 	}
+
+	// Write the footer, only once:
+	if t.footer != nil {
+		footerData := t.footer
+		t.footer = nil
+		return t.writeRow(footerData)
+	}
 	return nil
 }
 
-// Close releases all the resources used by the table.
+// Close releases all the resources used by the table. It also writes the rows that may still be
+// pending, so it should always be called, even when the caller returns early.
 func (t *Table) Close() error {
 	return t.Flush()
 }
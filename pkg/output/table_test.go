@@ -19,6 +19,7 @@ package output
 import (
 	"bytes"
 	"context"
+	"errors"
 	"strings"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -82,6 +83,31 @@ var _ = Describe("Table", func() {
 		))
 	})
 
+	It("Adds the extra columns in wide mode", func() {
+		// Create the table:
+		table, err := printer.NewTable().
+			Name("clusters").
+			Columns(
+				"id",
+				"external_id",
+				"name",
+			).
+			Wide(true).
+			Build(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		// Write the headers:
+		err = table.WriteHeaders()
+		Expect(err).ToNot(HaveOccurred())
+		err = table.Close()
+		Expect(err).ToNot(HaveOccurred())
+
+		// Check the generated text, the `external_id` column shouldn't be repeated:
+		Expect(buffer.String()).To(MatchRegexp(
+			`^ID\s+EXTERNAL ID\s+NAME\s+MULTI AZ\s+CCS\s+CREATED\s*$`,
+		))
+	})
+
 	It("Doesn't trim `external_id` column", func() {
 		// Create the table:
 		table, err := printer.NewTable().
@@ -142,6 +168,38 @@ var _ = Describe("Table", func() {
 		))
 	})
 
+	It("Applies column transformers", func() {
+		// Create the table:
+		table, err := printer.NewTable().
+			Name("clusters").
+			Columns("id", "name", "my_column").
+			Value("my_column", 2048).
+			Format("name", func(value interface{}) string {
+				return strings.ToUpper(value.(string))
+			}).
+			Format("my_column", FormatSize).
+			Build(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		// Create the object that will be written to the table:
+		object, err := cmv1.NewCluster().
+			ID("123").
+			Name("mycluster").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+
+		// Write the object to the table:
+		err = table.WriteObject(object)
+		Expect(err).ToNot(HaveOccurred())
+		err = table.Close()
+		Expect(err).ToNot(HaveOccurred())
+
+		// Check the generated text:
+		Expect(buffer.String()).To(MatchRegexp(
+			`^123\s+MYCLUSTER\s+2.0 KiB\s*$`,
+		))
+	})
+
 	It("Honors calculated column", func() {
 		// Create the table:
 		table, err := printer.NewTable().
@@ -303,4 +361,122 @@ var _ = Describe("Table", func() {
 		Expect(lines[1]).To(Equal(`123   my_github`))
 		Expect(lines[2]).To(Equal(`456   your_gith`))
 	})
+
+	It("Writes all the rows when there are less than the learning limit", func() {
+		// Create the table:
+		table, err := printer.NewTable().
+			Name("clusters").
+			Columns("id", "name").
+			LearningLimit(10).
+			Build(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		// Write less rows than the learning limit:
+		err = table.WriteHeaders()
+		Expect(err).ToNot(HaveOccurred())
+		for _, id := range []string{"123", "456", "789"} {
+			object, err := cmv1.NewCluster().
+				ID(id).
+				Name("cluster_" + id).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			err = table.WriteObject(object)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		// Nothing should be written till the table is flushed:
+		Expect(buffer.String()).To(BeEmpty())
+		err = table.Flush()
+		Expect(err).ToNot(HaveOccurred())
+
+		// Closing after flushing shouldn't write the rows again:
+		err = table.Close()
+		Expect(err).ToNot(HaveOccurred())
+
+		// Check the generated text:
+		lines := strings.Split(buffer.String(), "\n")
+		Expect(lines).To(HaveLen(5))
+		Expect(lines[0]).To(MatchRegexp(`^ID\s+NAME\s*$`))
+		Expect(lines[1]).To(MatchRegexp(`^123\s+cluster_123\s*$`))
+		Expect(lines[2]).To(MatchRegexp(`^456\s+cluster_456\s*$`))
+		Expect(lines[3]).To(MatchRegexp(`^789\s+cluster_789\s*$`))
+	})
+
+	It("Writes the footer after the rows and learns its widths", func() {
+		// Create the table:
+		table, err := printer.NewTable().
+			Name("idps").
+			Columns("name", "type").
+			Build(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		// Set the footer before writing the rows, it should still be written last:
+		err = table.TotalFooter(2)
+		Expect(err).ToNot(HaveOccurred())
+		err = table.WriteHeaders()
+		Expect(err).ToNot(HaveOccurred())
+		err = table.WriteRow([]interface{}{"gh", "GitHub"})
+		Expect(err).ToNot(HaveOccurred())
+		err = table.WriteRow([]interface{}{"ldap", "LDAP"})
+		Expect(err).ToNot(HaveOccurred())
+		err = table.Flush()
+		Expect(err).ToNot(HaveOccurred())
+
+		// Closing after flushing shouldn't write the footer again:
+		err = table.Close()
+		Expect(err).ToNot(HaveOccurred())
+
+		// Check the generated text, the first column is as wide as the footer:
+		lines := strings.Split(buffer.String(), "\n")
+		Expect(lines).To(HaveLen(5))
+		Expect(lines[0]).To(Equal(`NAME      TYPE  `))
+		Expect(lines[1]).To(Equal(`gh        GitHub`))
+		Expect(lines[2]).To(Equal(`ldap      LDAP  `))
+		Expect(lines[3]).To(Equal(`Total: 2        `))
+	})
+
+	It("Rejects footers with the wrong number of values", func() {
+		// Create the table:
+		table, err := printer.NewTable().
+			Name("clusters").
+			Columns("id", "name").
+			Build(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		defer table.Close()
+
+		// Try to set the footer:
+		err = table.Footer("Total: 1")
+		Expect(err).To(MatchError("table 'clusters' has 2 columns, but 1 values have been given"))
+	})
+
+	It("Returns the errors of the rows written when flushing", func() {
+		// Create a printer that fails to write:
+		failing, err := NewPrinter().
+			Writer(failingWriter{}).
+			Build(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		defer failing.Close()
+
+		// Create the table:
+		table, err := failing.NewTable().
+			Name("clusters").
+			Columns("id", "name").
+			Build(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		// The headers are accumulated for learning, so writing them doesn't fail yet:
+		err = table.WriteHeaders()
+		Expect(err).ToNot(HaveOccurred())
+
+		// But flushing them does:
+		err = table.Flush()
+		Expect(err).To(MatchError("write failed"))
+	})
 })
+
+// failingWriter is a writer that always fails.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
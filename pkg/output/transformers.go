@@ -0,0 +1,90 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains transformers that can be used with the Format method of the table builder to
+// display column values in a human friendly way.
+
+package output
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/openshift-online/ocm-cli/pkg/utils"
+)
+
+// FormatTimestamp displays a time relative to now, for example '3 days ago'. It accepts time
+// values and strings in RFC3339 format. Other values are displayed unchanged.
+func FormatTimestamp(value interface{}) string {
+	return formatTimestamp(value, time.Now())
+}
+
+func formatTimestamp(value interface{}, now time.Time) string {
+	var t time.Time
+	switch typed := value.(type) {
+	case time.Time:
+		t = typed
+	case *time.Time:
+		if typed == nil {
+			return ""
+		}
+		t = *typed
+	case string:
+		parsed, err := time.Parse(time.RFC3339, typed)
+		if err != nil {
+			return typed
+		}
+		t = parsed
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+	if t.IsZero() {
+		return ""
+	}
+	return utils.RelativeTime(t, now)
+}
+
+// sizeUnits are the binary units used to display sizes.
+var sizeUnits = []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// FormatSize displays a number of bytes using the largest binary unit that keeps the number
+// above one, for example '1.5 GiB'. Values that aren't numbers are displayed unchanged.
+func FormatSize(value interface{}) string {
+	var size float64
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		size = float64(reflected.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		size = float64(reflected.Uint())
+	case reflect.Float32, reflect.Float64:
+		size = reflected.Float()
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+	if size < 1024 && size > -1024 {
+		return fmt.Sprintf("%d B", int64(size))
+	}
+	unit := ""
+	for _, unit = range sizeUnits {
+		size /= 1024
+		if size < 1024 && size > -1024 {
+			break
+		}
+	}
+	return fmt.Sprintf("%.1f %s", size, unit)
+}
@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" // nolint
+	. "github.com/onsi/gomega"    // nolint
+)
+
+var _ = Describe("Transformers", func() {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	DescribeTable(
+		"Formats timestamps relative to now",
+		func(value interface{}, expected string) {
+			Expect(formatTimestamp(value, now)).To(Equal(expected))
+		},
+		Entry("Time", now.Add(-3*24*time.Hour), "3 days ago"),
+		Entry("Pointer to time", func() *time.Time {
+			t := now.Add(-2 * time.Hour)
+			return &t
+		}(), "2 hours ago"),
+		Entry("RFC3339 string", "2024-05-10T11:30:00Z", "30 minutes ago"),
+		Entry("Zero time", time.Time{}, ""),
+		Entry("Other string", "never", "never"),
+		Entry("Other type", 42, "42"),
+	)
+
+	DescribeTable(
+		"Formats sizes in binary units",
+		func(value interface{}, expected string) {
+			Expect(FormatSize(value)).To(Equal(expected))
+		},
+		Entry("Bytes", 512, "512 B"),
+		Entry("Kibibytes", 1536, "1.5 KiB"),
+		Entry("Gibibytes", int64(16)*1024*1024*1024, "16.0 GiB"),
+		Entry("Float", float64(3*1024*1024), "3.0 MiB"),
+		Entry("Unsigned", uint64(1024), "1.0 KiB"),
+		Entry("Not a number", "big", "big"),
+	)
+})
@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the code that refreshes the output periodically.
+
+package output
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// DefaultWatchInterval is the time between refreshes used when the interval isn't given
+// explicitly.
+const DefaultWatchInterval = 5 * time.Second
+
+// MinWatchInterval is the minimum time between refreshes, to avoid flooding the server with
+// requests.
+const MinWatchInterval = time.Second
+
+// clearScreen is the sequence of control characters that moves the cursor to the top left corner
+// of the terminal and clears it.
+const clearScreen = "\033[H\033[2J"
+
+// Watch calls the given function to write the output and then, if the interval isn't zero, calls
+// it again each time the interval expires, till the context is cancelled or the function fails.
+// When the output is a terminal the screen is cleared before each refresh, otherwise the output of
+// each refresh is separated from the previous one with an empty line. The function should create
+// and flush its own tables, so that the widths of the columns are learned again in each refresh.
+func (p *Printer) Watch(ctx context.Context, interval time.Duration, write func() error) error {
+	if interval == 0 {
+		return write()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	first := true
+	for {
+		var err error
+		switch {
+		case p.terminal:
+			_, err = io.WriteString(p, clearScreen)
+		case !first:
+			_, err = io.WriteString(p, "\n")
+		}
+		if err != nil {
+			return err
+		}
+		first = false
+		err = write()
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
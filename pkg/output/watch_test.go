@@ -0,0 +1,117 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" // nolint
+	. "github.com/onsi/gomega"    // nolint
+)
+
+var _ = Describe("Watch", func() {
+	var ctx context.Context
+	var buffer *bytes.Buffer
+	var printer *Printer
+
+	BeforeEach(func() {
+		var err error
+
+		// Create a context:
+		ctx = context.Background()
+
+		// Create a printer that writes to a memory buffer so that we can check the results:
+		buffer = &bytes.Buffer{}
+		printer, err = NewPrinter().
+			Writer(buffer).
+			Build(ctx)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		err := printer.Close()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	// writeTable writes a table with a row containing the given value, creating a new table
+	// each time, like the list commands do:
+	writeTable := func(value string) error {
+		table, err := printer.NewTable().
+			Name("clusters").
+			Columns("id").
+			Build(ctx)
+		if err != nil {
+			return err
+		}
+		defer table.Close()
+		err = table.WriteHeaders()
+		if err != nil {
+			return err
+		}
+		err = table.WriteRow([]interface{}{value})
+		if err != nil {
+			return err
+		}
+		return table.Flush()
+	}
+
+	It("Writes once without interval", func() {
+		calls := 0
+		err := printer.Watch(ctx, 0, func() error {
+			calls++
+			return writeTable("123")
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(Equal(1))
+		Expect(buffer.String()).To(MatchRegexp(`^ID *\n123 *\n$`))
+	})
+
+	It("Refreshes till the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		calls := 0
+		err := printer.Watch(ctx, 10*time.Millisecond, func() error {
+			calls++
+			if calls == 3 {
+				cancel()
+			}
+			return writeTable(fmt.Sprintf("%d", calls*111))
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(Equal(3))
+
+		// The headers are written again in each refresh, and refreshes are separated by empty
+		// lines because the output isn't a terminal:
+		Expect(buffer.String()).To(MatchRegexp(
+			`^ID *\n111 *\n\nID *\n222 *\n\nID *\n333 *\n$`,
+		))
+	})
+
+	It("Stops when writing fails", func() {
+		calls := 0
+		err := printer.Watch(ctx, 10*time.Millisecond, func() error {
+			calls++
+			return errors.New("my error")
+		})
+		Expect(err).To(MatchError("my error"))
+		Expect(calls).To(Equal(1))
+	})
+})
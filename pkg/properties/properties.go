@@ -17,6 +17,12 @@ limitations under the License.
 package properties
 
 const (
-	KeyringEnvKey = "OCM_KEYRING"
-	URLEnvKey     = "OCM_URL"
+	KeyringEnvKey            = "OCM_KEYRING"
+	URLEnvKey                = "OCM_URL"
+	DisableRegionCacheEnvKey = "OCM_DISABLE_RH_REGIONS_CACHE"
+	CAFileEnvKey             = "OCM_CA_FILE"
+
+	// SkipProductionConfirmationEnvKey disables the additional confirmation that commands that
+	// modify resources request when connected to the production environment.
+	SkipProductionConfirmationEnvKey = "OCM_SKIP_PRODUCTION_CONFIRMATION"
 )
@@ -18,9 +18,13 @@ package provider
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/openshift-online/ocm-cli/pkg/arguments"
+	"github.com/openshift-online/ocm-cli/pkg/cluster"
+	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
 )
 
 func getMachineTypes(client *cmv1.Client, provider string) (machineTypes []*cmv1.MachineType, err error) {
@@ -51,7 +55,34 @@ func getMachineTypes(client *cmv1.Client, provider string) (machineTypes []*cmv1
 	return
 }
 
-func GetMachineTypeOptions(client *cmv1.Client, provider string, ccs bool) (options []arguments.Option, err error) {
+// MachineTypeCategories are the categories that can be used to filter machine types.
+var MachineTypeCategories = []string{
+	string(cmv1.MachineTypeCategoryGeneralPurpose),
+	string(cmv1.MachineTypeCategoryMemoryOptimized),
+	string(cmv1.MachineTypeCategoryComputeOptimized),
+	string(cmv1.MachineTypeCategoryAcceleratedComputing),
+}
+
+// ValidateMachineTypeCategory checks that the given category is one of the supported machine type
+// categories. An empty category is valid and means that machine types aren't filtered.
+func ValidateMachineTypeCategory(category string) error {
+	if category == "" {
+		return nil
+	}
+	for _, valid := range MachineTypeCategories {
+		if category == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid machine type category '%s', valid values are: %s",
+		category, strings.Join(MachineTypeCategories, ", "))
+}
+
+// GetMachineTypes returns the machine types of the given provider, excluding the ones that
+// require CCS if ccs is false. If category isn't empty only the machine types of that category
+// are returned.
+func GetMachineTypes(client *cmv1.Client, provider string, ccs bool,
+	category string) (result []*cmv1.MachineType, err error) {
 	machineTypes, err := getMachineTypes(client, provider)
 	if err != nil {
 		err = fmt.Errorf("Failed to retrieve machine types: %s", err)
@@ -62,6 +93,22 @@ func GetMachineTypeOptions(client *cmv1.Client, provider string, ccs bool) (opti
 		if m.CCSOnly() && !ccs {
 			continue
 		}
+		if category != "" && string(m.Category()) != category {
+			continue
+		}
+		result = append(result, m)
+	}
+	return
+}
+
+func GetMachineTypeOptions(client *cmv1.Client, provider string, ccs bool,
+	category string) (options []arguments.Option, err error) {
+	machineTypes, err := GetMachineTypes(client, provider, ccs, category)
+	if err != nil {
+		return
+	}
+
+	for _, m := range machineTypes {
 		options = append(options, arguments.Option{
 			Value:       m.ID(),
 			Description: m.Name(),
@@ -69,3 +116,30 @@ func GetMachineTypeOptions(client *cmv1.Client, provider string, ccs bool) (opti
 	}
 	return
 }
+
+// ClusterMachineTypeCompletion returns a completion function for flags that accept a machine type
+// for an existing cluster, where there is no '--provider' flag. The cluster is taken from the flag
+// with the given name, and the suggestions are the machine types of the cloud provider of that
+// cluster, excluding the ones that require CCS if the cluster isn't CCS.
+func ClusterMachineTypeCompletion(clusterFlag string) arguments.CobraCompletionFunc {
+	return func(cmd *cobra.Command, argv []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		key, _ := cmd.Flags().GetString(clusterFlag)
+		if key == "" {
+			cobra.CompErrorln(fmt.Sprintf("flag --%s is needed to complete machine types", clusterFlag))
+			return []string{}, cobra.ShellCompDirectiveNoFileComp
+		}
+		if !cluster.IsValidClusterKey(key) {
+			cobra.CompErrorln(fmt.Sprintf("cluster key '%s' isn't valid", key))
+			return []string{}, cobra.ShellCompDirectiveNoFileComp
+		}
+		complete := arguments.MakeCompleteFunc(func(connection *sdk.Connection) ([]arguments.Option, error) {
+			target, err := cluster.GetCluster(connection, key)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get cluster '%s': %v", key, err)
+			}
+			return GetMachineTypeOptions(connection.ClustersMgmt().V1(),
+				target.CloudProvider().ID(), target.CCS().Enabled(), "")
+		})
+		return complete(cmd, argv, toComplete)
+	}
+}
@@ -75,3 +75,30 @@ func GetWifConfigNameOptions(client *cmv1.Client) (options []arguments.Option, e
 	}
 	return
 }
+
+// AuditLogForwardingRoles are the predefined GCP roles that need to be granted to the service
+// accounts of a WIF configuration so that the cluster can forward its audit logs. The API doesn't
+// return the roles that a feature needs, so this is the only place where the CLI keeps them, and
+// it has to be updated when the requirements of the service change. The check and the guidance
+// given when roles are missing both use this list.
+var AuditLogForwardingRoles = []string{
+	"logging.logWriter",
+}
+
+// MissingWifConfigRoles returns the roles of the given list that aren't granted to any of the
+// service accounts of the WIF configuration.
+func MissingWifConfigRoles(wifConfig *cmv1.WifConfig, roles []string) []string {
+	granted := map[string]bool{}
+	for _, sa := range wifConfig.Gcp().ServiceAccounts() {
+		for _, role := range sa.Roles() {
+			granted[role.RoleId()] = true
+		}
+	}
+	var missing []string
+	for _, role := range roles {
+		if !granted[role] {
+			missing = append(missing, role)
+		}
+	}
+	return missing
+}
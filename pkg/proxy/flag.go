@@ -0,0 +1,42 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--ocm-proxy' command line option.
+
+package proxy
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// AddFlag adds the proxy flag to the given set of command line flags.
+func AddFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&url,
+		"ocm-proxy",
+		"",
+		"URL of the proxy used to connect to the OCM API. When set it overrides the "+
+			"HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.",
+	)
+}
+
+// URL returns the proxy URL given in the command line, or an empty string if it wasn't given.
+func URL() string {
+	return url
+}
+
+// url is the value of the proxy flag.
+var url string
@@ -0,0 +1,170 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to discover the OCM regions. The result of the discovery
+// is cached in the configuration directory, so that it isn't fetched again by every command.
+
+package urls
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+
+	"github.com/openshift-online/ocm-cli/pkg/config"
+	"github.com/openshift-online/ocm-cli/pkg/properties"
+)
+
+// rhRegionsCacheFile is the name of the file, inside the configuration directory, where the
+// result of the region discovery is cached.
+const rhRegionsCacheFile = "ocm-rh-regions-cache.json"
+
+// rhRegionsCacheTTL is how long a cached result of the region discovery is used before fetching
+// it again.
+const rhRegionsCacheTTL = time.Hour
+
+// fetchRhRegions fetches the regions from the discovery file. It is a variable so that tests can
+// replace it.
+var fetchRhRegions = sdk.GetRhRegions
+
+// rhRegionsCacheEntry is the result of the discovery for one environment.
+type rhRegionsCacheEntry struct {
+	Time    time.Time             `json:"time"`
+	Regions map[string]sdk.Region `json:"regions"`
+}
+
+// GetRhRegions returns the OCM regions of the environment that the given gateway URL is part of.
+// The result is taken from the cache if it is recent enough, unless refresh is true or the cache
+// has been disabled with the OCM_DISABLE_RH_REGIONS_CACHE environment variable.
+func GetRhRegions(gatewayURL string, refresh bool) (map[string]sdk.Region, error) {
+	if !rhRegionsCacheEnabled() {
+		return fetchRhRegions(gatewayURL)
+	}
+
+	// The discovery file is the same for all the URLs of an environment, so use it as the key:
+	key, err := sdk.DetermineRegionDiscoveryUrl(gatewayURL)
+	if err != nil {
+		return nil, fmt.Errorf("Can't determine region discovery URL: %v", err)
+	}
+	file, err := rhRegionsCacheLocation()
+	if err != nil {
+		return fetchRhRegions(gatewayURL)
+	}
+	cache := loadRhRegionsCache(file)
+	entry, ok := cache[key]
+	if ok && !refresh && time.Since(entry.Time) < rhRegionsCacheTTL {
+		return entry.Regions, nil
+	}
+
+	regions, err := fetchRhRegions(gatewayURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Failing to update the cache shouldn't make the command fail, the only consequence is that
+	// the regions will be fetched again next time:
+	cache[key] = rhRegionsCacheEntry{
+		Time:    time.Now(),
+		Regions: regions,
+	}
+	_ = saveRhRegionsCache(file, cache)
+
+	return regions, nil
+}
+
+// GetRhRegion returns the OCM region with the given name from the environment that the given
+// gateway URL is part of. If the region isn't in the cached result of the discovery it is fetched
+// again, as the region may have been added after the cache was written.
+func GetRhRegion(gatewayURL string, regionName string) (sdk.Region, error) {
+	regions, err := GetRhRegions(gatewayURL, false)
+	if err != nil {
+		return sdk.Region{}, err
+	}
+	region, ok := regions[regionName]
+	if ok {
+		return region, nil
+	}
+	if rhRegionsCacheEnabled() {
+		regions, err = GetRhRegions(gatewayURL, true)
+		if err != nil {
+			return sdk.Region{}, err
+		}
+		region, ok = regions[regionName]
+		if ok {
+			return region, nil
+		}
+	}
+	return sdk.Region{}, fmt.Errorf("Can't find region %s", regionName)
+}
+
+// rhRegionsCacheEnabled checks if the cache hasn't been disabled with the environment variable.
+func rhRegionsCacheEnabled() bool {
+	value := os.Getenv(properties.DisableRegionCacheEnvKey)
+	if value == "" {
+		return true
+	}
+	disabled, err := strconv.ParseBool(value)
+	return err == nil && !disabled
+}
+
+// rhRegionsCacheLocation returns the location of the cache file, which is in the same directory
+// as the configuration file.
+func rhRegionsCacheLocation() (string, error) {
+	file, err := config.Location()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(file), rhRegionsCacheFile), nil
+}
+
+// loadRhRegionsCache loads the cache from the given file. If the file doesn't exist or can't be
+// parsed it returns an empty cache.
+func loadRhRegionsCache(file string) map[string]rhRegionsCacheEntry {
+	cache := map[string]rhRegionsCacheEntry{}
+	// #nosec G304
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return cache
+	}
+	err = json.Unmarshal(data, &cache)
+	if err != nil || cache == nil {
+		return map[string]rhRegionsCacheEntry{}
+	}
+	return cache
+}
+
+// saveRhRegionsCache saves the cache to the given file.
+func saveRhRegionsCache(file string, cache map[string]rhRegionsCacheEntry) error {
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return fmt.Errorf("can't marshal regions cache: %v", err)
+	}
+	dir := filepath.Dir(file)
+	err = os.MkdirAll(dir, os.FileMode(0755))
+	if err != nil {
+		return fmt.Errorf("can't create directory %s: %v", dir, err)
+	}
+	err = os.WriteFile(file, data, 0600)
+	if err != nil {
+		return fmt.Errorf("can't write file '%s': %v", file, err)
+	}
+	return nil
+}
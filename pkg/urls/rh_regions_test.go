@@ -0,0 +1,113 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package urls
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+
+	"github.com/openshift-online/ocm-cli/pkg/properties"
+)
+
+var _ = Describe("RH regions", func() {
+	var fetches []string
+	var regions map[string]sdk.Region
+	var tmpDir string
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "ocm-rh-regions-*")
+		Expect(err).ToNot(HaveOccurred())
+		os.Setenv("OCM_CONFIG", filepath.Join(tmpDir, "ocm.json"))
+
+		fetches = nil
+		regions = map[string]sdk.Region{
+			"singapore": {URL: "api.singapore.openshift.com"},
+		}
+		DeferCleanup(func(original func(string) (map[string]sdk.Region, error)) {
+			fetchRhRegions = original
+		}, fetchRhRegions)
+		fetchRhRegions = func(url string) (map[string]sdk.Region, error) {
+			fetches = append(fetches, url)
+			return regions, nil
+		}
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("OCM_CONFIG")
+		os.Unsetenv(properties.DisableRegionCacheEnvKey)
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	It("Reuses the cached regions", func() {
+		first, err := GetRhRegions("https://api.openshift.com", false)
+		Expect(err).ToNot(HaveOccurred())
+		second, err := GetRhRegions("https://api.openshift.com", false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetches).To(HaveLen(1))
+		Expect(second).To(Equal(first))
+		Expect(filepath.Join(tmpDir, rhRegionsCacheFile)).To(BeAnExistingFile())
+	})
+
+	It("Keys the cache by environment", func() {
+		_, err := GetRhRegions("https://api.openshift.com", false)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = GetRhRegions("https://api.stage.openshift.com", false)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = GetRhRegions("https://api.singapore.stage.openshift.com", false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetches).To(Equal([]string{
+			"https://api.openshift.com",
+			"https://api.stage.openshift.com",
+		}))
+	})
+
+	It("Fetches the regions again when refresh is requested", func() {
+		_, err := GetRhRegions("https://api.openshift.com", false)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = GetRhRegions("https://api.openshift.com", true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetches).To(HaveLen(2))
+	})
+
+	It("Doesn't use the cache when it is disabled", func() {
+		os.Setenv(properties.DisableRegionCacheEnvKey, "true")
+		_, err := GetRhRegions("https://api.openshift.com", false)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = GetRhRegions("https://api.openshift.com", false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetches).To(HaveLen(2))
+		Expect(filepath.Join(tmpDir, rhRegionsCacheFile)).ToNot(BeAnExistingFile())
+	})
+
+	It("Fetches the regions again when a region isn't in the cache", func() {
+		_, err := GetRhRegions("https://api.openshift.com", false)
+		Expect(err).ToNot(HaveOccurred())
+		regions = map[string]sdk.Region{
+			"singapore": {URL: "api.singapore.openshift.com"},
+			"ireland":   {URL: "api.ireland.openshift.com"},
+		}
+		region, err := GetRhRegion("https://api.openshift.com", "ireland")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(region.URL).To(Equal("api.ireland.openshift.com"))
+		Expect(fetches).To(HaveLen(2))
+	})
+})
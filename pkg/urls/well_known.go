@@ -55,6 +55,26 @@ func ValidOCMUrlAliases() []string {
 	return keys
 }
 
+// IsProductionURL checks if the given API gateway URL belongs to the production environment, either
+// the global 'https://api.openshift.com' or one of the regional ones, like
+// 'https://api.aws.ap-southeast-1.openshift.com'. Staging and integration URLs aren't production.
+func IsProductionURL(gatewayURL string) bool {
+	parsed, err := url.Parse(gatewayURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if !strings.HasPrefix(host, "api.") || !strings.HasSuffix(host, ".openshift.com") {
+		return false
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "stage" || label == "integration" {
+			return false
+		}
+	}
+	return true
+}
+
 // URL Precedent (from highest priority to lowest priority):
 //  1. runtime `--url` cli arg (key found in `urlAliases`)
 //  2. runtime `--url` cli arg (non-empty string)
@@ -101,3 +101,20 @@ var _ = Describe("Gateway URL Resolution", func() {
 		}
 	})
 })
+
+var _ = Describe("Production URL", func() {
+	DescribeTable(
+		"Detects production URLs",
+		func(gatewayURL string, expected bool) {
+			Expect(IsProductionURL(gatewayURL)).To(Equal(expected))
+		},
+		Entry("Global", OCMProductionURL, true),
+		Entry("Trailing slash", "https://api.openshift.com/", true),
+		Entry("Regional", "https://api.aws.ap-southeast-1.openshift.com", true),
+		Entry("Staging", OCMStagingURL, false),
+		Entry("Integration", OCMIntegrationURL, false),
+		Entry("Regional staging", "https://api.aws.ap-southeast-1.stage.openshift.com", false),
+		Entry("Local", "http://localhost:8000", false),
+		Entry("Empty", "", false),
+	)
+})
@@ -103,3 +103,23 @@ func DelayedRetry(f func() error, maxRetries int, delay time.Duration) error {
 	}
 	return fmt.Errorf("Reached max retries. Last error: %s", err.Error())
 }
+
+// RetryWithBackoffandTimeout calls the given function until it succeeds, waiting the given initial
+// delay after the first failure and doubling it after each of the following ones. It stops when
+// the next attempt would start after the given timeout, and then it returns the last error
+// unchanged.
+func RetryWithBackoffandTimeout(f func() error, initialDelay time.Duration, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	delay := initialDelay
+	for {
+		err := f()
+		if err == nil {
+			return nil
+		}
+		if time.Now().Add(delay).After(deadline) {
+			return err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RetryWithBackoffandTimeout", func() {
+	It("Retries until the function succeeds", func() {
+		attempts := 0
+		err := RetryWithBackoffandTimeout(func() error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("transient")
+			}
+			return nil
+		}, time.Millisecond, time.Second)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(attempts).To(Equal(3))
+	})
+
+	It("Returns the last error when the timeout expires", func() {
+		attempts := 0
+		err := RetryWithBackoffandTimeout(func() error {
+			attempts++
+			return errors.New("permanent")
+		}, 10*time.Millisecond, 50*time.Millisecond)
+		Expect(err).To(MatchError("permanent"))
+		Expect(attempts).To(BeNumerically(">=", 2))
+		Expect(attempts).To(BeNumerically("<=", 4))
+	})
+})
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+)
+
+// Suggestions returns at most max values from the given candidates that are close to the given
+// value, the best matches first. Candidates that start with the value are preferred, then the
+// ones with the smallest edit distance. Candidates that are too different from the value aren't
+// returned at all, so the result may be empty.
+func Suggestions(value string, candidates []string, max int) []string {
+	type match struct {
+		candidate string
+		prefix    bool
+		distance  int
+	}
+
+	value = strings.ToLower(strings.TrimSpace(value))
+	threshold := len(value)/3 + 1
+	matches := []match{}
+	for _, candidate := range candidates {
+		lower := strings.ToLower(candidate)
+		distance := Levenshtein(value, lower)
+		prefix := value != "" && strings.HasPrefix(lower, value)
+		if !prefix && distance > threshold {
+			continue
+		}
+		matches = append(matches, match{
+			candidate: candidate,
+			prefix:    prefix,
+			distance:  distance,
+		})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].prefix != matches[j].prefix {
+			return matches[i].prefix
+		}
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].candidate < matches[j].candidate
+	})
+
+	if len(matches) > max {
+		matches = matches[:max]
+	}
+	result := make([]string, len(matches))
+	for i, match := range matches {
+		result[i] = match.candidate
+	}
+	return result
+}
+
+// Levenshtein returns the number of single character insertions, deletions and substitutions
+// needed to transform one string into the other.
+func Levenshtein(a, b string) int {
+	x := []rune(a)
+	y := []rune(b)
+	previous := make([]int, len(y)+1)
+	current := make([]int, len(y)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(x); i++ {
+		current[0] = i
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			current[j] = min(
+				previous[j]+1,
+				current[j-1]+1,
+				previous[j-1]+cost,
+			)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(y)]
+}
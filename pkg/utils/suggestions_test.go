@@ -0,0 +1,56 @@
+package utils
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Levenshtein", func() {
+	DescribeTable(
+		"Calculates the edit distance",
+		func(a, b string, expected int) {
+			Expect(Levenshtein(a, b)).To(Equal(expected))
+		},
+		Entry("Equal", "m5.xlarge", "m5.xlarge", 0),
+		Entry("Empty", "", "m5", 2),
+		Entry("Substitution", "m5.xlarge", "m6.xlarge", 1),
+		Entry("Insertion", "m5.large", "m5.xlarge", 1),
+		Entry("Deletion and substitution", "kitten", "sitting", 3),
+	)
+})
+
+var _ = Describe("Suggestions", func() {
+	candidates := []string{
+		"c5.2xlarge",
+		"m5.2xlarge",
+		"m5.4xlarge",
+		"m5.xlarge",
+		"m6i.xlarge",
+		"r5.xlarge",
+	}
+
+	It("Prefers candidates that start with the value", func() {
+		Expect(Suggestions("m5", candidates, 5)).To(Equal([]string{
+			"m5.xlarge",
+			"m5.2xlarge",
+			"m5.4xlarge",
+		}))
+	})
+
+	It("Returns the closest candidates for a misspelled value", func() {
+		Expect(Suggestions("m5.xlarg", candidates, 2)).To(Equal([]string{
+			"m5.xlarge",
+			"m5.2xlarge",
+		}))
+	})
+
+	It("Ignores case", func() {
+		Expect(Suggestions("M5.XLARGE", candidates, 1)).To(Equal([]string{
+			"m5.xlarge",
+		}))
+	})
+
+	It("Returns nothing when no candidate is close", func() {
+		Expect(Suggestions("my-custom-type", candidates, 5)).To(BeEmpty())
+	})
+})
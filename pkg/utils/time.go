@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+// RelativeTime returns a human readable description of the given time relative to now, for
+// example '3 days ago' or 'in 2 hours'. Only the largest unit is used, rounded down.
+func RelativeTime(t, now time.Time) string {
+	delta := now.Sub(t)
+	future := delta < 0
+	if future {
+		delta = -delta
+	}
+	var amount int
+	var unit string
+	switch {
+	case delta < time.Minute:
+		if future {
+			return "in less than a minute"
+		}
+		return "less than a minute ago"
+	case delta < time.Hour:
+		amount, unit = int(delta/time.Minute), "minute"
+	case delta < 24*time.Hour:
+		amount, unit = int(delta/time.Hour), "hour"
+	default:
+		amount, unit = int(delta/(24*time.Hour)), "day"
+	}
+	if amount != 1 {
+		unit += "s"
+	}
+	if future {
+		return fmt.Sprintf("in %d %s", amount, unit)
+	}
+	return fmt.Sprintf("%d %s ago", amount, unit)
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RelativeTime", func() {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	DescribeTable(
+		"Describes the time relative to now",
+		func(delta time.Duration, expected string) {
+			Expect(RelativeTime(now.Add(delta), now)).To(Equal(expected))
+		},
+		Entry("Now", time.Duration(0), "less than a minute ago"),
+		Entry("Seconds ago", -30*time.Second, "less than a minute ago"),
+		Entry("One minute ago", -time.Minute, "1 minute ago"),
+		Entry("Minutes ago", -59*time.Minute, "59 minutes ago"),
+		Entry("Hours ago", -5*time.Hour-30*time.Minute, "5 hours ago"),
+		Entry("One day ago", -24*time.Hour, "1 day ago"),
+		Entry("Days ago", -3*24*time.Hour-time.Hour, "3 days ago"),
+		Entry("Seconds ahead", 30*time.Second, "in less than a minute"),
+		Entry("Hours ahead", 2*time.Hour, "in 2 hours"),
+		Entry("Days ahead", 7*24*time.Hour, "in 7 days"),
+	)
+})
@@ -0,0 +1,254 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"    // nolint
+	. "github.com/onsi/gomega"       // nolint
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Account labels", func() {
+	var ctx context.Context
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	It("Lists the labels of the current account", func() {
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/current_account"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "Account",
+					"id": "123"
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/accounts/123/labels"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "LabelList",
+					"page": 1,
+					"size": 2,
+					"total": 2,
+					"items": [
+						{
+							"kind": "Label",
+							"key": "my.feature",
+							"value": "true"
+						},
+						{
+							"kind": "Label",
+							"key": "other.feature",
+							"value": "false"
+						}
+					]
+				}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("account", "labels", "list").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		lines := result.OutLines()
+		Expect(lines).To(HaveLen(3))
+		Expect(lines[0]).To(MatchRegexp(`^KEY\s+VALUE\s*$`))
+		Expect(lines[1]).To(MatchRegexp(`^my\.feature\s+true\s*$`))
+		Expect(lines[2]).To(MatchRegexp(`^other\.feature\s+false\s*$`))
+	})
+
+	It("Lists the labels of the given account in JSON format", func() {
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/accounts/456/labels"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "LabelList",
+					"page": 1,
+					"size": 1,
+					"total": 1,
+					"items": [
+						{
+							"kind": "Label",
+							"key": "my.feature",
+							"value": "true"
+						}
+					]
+				}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("account", "labels", "list", "--account", "456", "--output", "json").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		Expect(result.OutString()).To(MatchJSON(`[
+			{
+				"kind": "Label",
+				"key": "my.feature",
+				"value": "true"
+			}
+		]`))
+	})
+
+	It("Lists the labels of the given account in wide format", func() {
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/accounts/456/labels"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "LabelList",
+					"page": 1,
+					"size": 1,
+					"total": 1,
+					"items": [
+						{
+							"kind": "Label",
+							"key": "my.feature",
+							"value": "true",
+							"internal": false,
+							"created_at": "2024-01-02T03:04:05Z",
+							"updated_at": "2024-02-03T04:05:06Z"
+						}
+					]
+				}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("account", "labels", "list", "--account", "456", "--output", "wide").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		lines := result.OutLines()
+		Expect(lines).To(HaveLen(2))
+		Expect(lines[0]).To(MatchRegexp(`^KEY\s+VALUE\s+INTERNAL\s+CREATED\s+UPDATED\s*$`))
+		Expect(lines[1]).To(MatchRegexp(`^my\.feature\s+true\s+false\s+2024-01-02.*2024-02-03`))
+	})
+
+	It("Adds a label to the given account", func() {
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/accounts_mgmt/v1/accounts/456/labels"),
+				VerifyJSON(`{
+					"kind": "Label",
+					"key": "my.feature",
+					"value": "a=b"
+				}`),
+				RespondWithJSON(http.StatusCreated, `{
+					"kind": "Label",
+					"key": "my.feature",
+					"value": "a=b"
+				}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("account", "labels", "add", "--account", "456", "my.feature=a=b").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		Expect(result.OutString()).To(Equal(
+			"Added label 'my.feature=a=b' to account '456'\n",
+		))
+	})
+
+	It("Rejects labels without value", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Args("account", "labels", "add", "my.feature").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Label 'my.feature' isn't valid, expected 'KEY=VALUE'",
+		))
+	})
+
+	It("Explains that deleting labels requires administrator privileges", func() {
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodDelete,
+					"/api/accounts_mgmt/v1/accounts/456/labels/my.feature",
+				),
+				RespondWithJSON(http.StatusForbidden, `{
+					"kind": "Error",
+					"id": "403",
+					"href": "/api/accounts_mgmt/v1/errors/403",
+					"code": "ACCT-MGMT-11",
+					"reason": "Forbidden"
+				}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("account", "labels", "delete", "--account", "456", "my.feature").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Can't delete label 'my.feature' from account '456', administrator " +
+				"privileges are required",
+		))
+	})
+})
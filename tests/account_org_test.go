@@ -0,0 +1,147 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"    // nolint
+	. "github.com/onsi/gomega"       // nolint
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Account org", func() {
+	var ctx context.Context
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	const org = `{
+		"kind": "Organization",
+		"id": "123",
+		"href": "/api/accounts_mgmt/v1/organizations/123",
+		"name": "my_org",
+		"ebs_account_id": "456",
+		"external_id": "789"
+	}`
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	It("Writes the organization of the current account", func() {
+		// Prepare the server:
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/current_account"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "Account",
+					"id": "abc",
+					"organization": {
+						"kind": "Organization",
+						"id": "123"
+					}
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/organizations/123"),
+				RespondWithJSON(http.StatusOK, org),
+			),
+		)
+
+		// Run the command:
+		result := NewCommand().
+			ConfigString(config).
+			Args("account", "org").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		lines := result.OutLines()
+		Expect(lines).To(Equal([]string{
+			"ID:          123",
+			"Name:        my_org",
+			"EBS account: 456",
+			"External ID: 789",
+		}))
+	})
+
+	It("Writes the organization of another account as JSON", func() {
+		// Prepare the server:
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/accounts/def"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "Account",
+					"id": "def",
+					"organization": {
+						"kind": "Organization",
+						"id": "123"
+					}
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/organizations/123"),
+				RespondWithJSON(http.StatusOK, org),
+			),
+		)
+
+		// Run the command:
+		result := NewCommand().
+			ConfigString(config).
+			Args("account", "org", "--account", "def", "--output", "json").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		Expect(result.OutString()).To(MatchJSON(org))
+	})
+})
@@ -144,5 +144,61 @@ var _ = Describe("Account orgs", func() {
 				`^\s*456\s+your_org\s*$`,
 			))
 		})
+
+		It("Combines the --where conditions with the search parameter", func() {
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					VerifyFormKV(
+						"search",
+						"(name like 'my-%') and (ebs_account_id = '123')",
+					),
+					RespondWithJSON(
+						http.StatusOK,
+						`{
+							"kind": "OrganizationList",
+							"page": 1,
+							"size": 1,
+							"total": 1,
+							"items": [
+								{
+									"kind": "Organization",
+									"id": "123",
+									"href": "/api/accounts_mgmt/v1/organizations/123",
+									"name": "my-org"
+								}
+							]
+						}`,
+					),
+				),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"account", "orgs",
+					"--where", "name~my-%",
+					"--parameter", "search=ebs_account_id = '123'",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			lines := result.OutLines()
+			Expect(lines).To(HaveLen(2))
+			Expect(lines[1]).To(MatchRegexp(
+				`^\s*123\s+my-org\s*$`,
+			))
+		})
+
+		It("Rejects invalid --where conditions", func() {
+			result := NewCommand().
+				ConfigString(config).
+				Args("account", "orgs", "--where", "name").
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(result.ErrString()).To(ContainSubstring("Condition 'name' isn't valid"))
+			Expect(apiServer.ReceivedRequests()).To(BeEmpty())
+		})
 	})
 })
@@ -0,0 +1,373 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"    // nolint
+	. "github.com/onsi/gomega"       // nolint
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Cluster credentials rotate", func() {
+	var ctx context.Context
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	It("Replaces the AWS access key without writing it to the output", func() {
+		respondWithCluster(apiServer, `{
+			"kind": "Cluster",
+			"id": "123",
+			"name": "my-cluster",
+			"cloud_provider": {
+				"id": "aws"
+			},
+			"ccs": {
+				"enabled": true
+			}
+		}`)
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/123"),
+				VerifyJSON(`{
+					"kind": "Cluster",
+					"aws": {
+						"access_key_id": "my-key",
+						"secret_access_key": "my-secret-key"
+					}
+				}`),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "Cluster",
+					"id": "123",
+					"name": "my-cluster"
+				}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"cluster", "credentials", "rotate",
+				"--aws-access-key-id", "my-key",
+				"--aws-secret-access-key", "my-secret-key",
+				"my-cluster",
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		Expect(result.OutString()).To(Equal(
+			"Updated the cloud credentials of cluster 'my-cluster'\n",
+		))
+		Expect(result.OutString()).ToNot(ContainSubstring("my-secret-key"))
+	})
+
+	It("Replaces the GCP service account", func() {
+		respondWithCluster(apiServer, `{
+			"kind": "Cluster",
+			"id": "123",
+			"name": "my-cluster",
+			"cloud_provider": {
+				"id": "gcp"
+			},
+			"ccs": {
+				"enabled": true
+			},
+			"gcp": {
+				"project_id": "my-project"
+			}
+		}`)
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/123"),
+				VerifyJSON(`{
+					"kind": "Cluster",
+					"gcp": {
+						"type": "service_account",
+						"project_id": "my-project",
+						"private_key_id": "my-key-id",
+						"private_key": "my-private-key",
+						"client_email": "osd-ccs-admin@my-project.iam.gserviceaccount.com",
+						"client_id": "my-client-id",
+						"auth_uri": "https://accounts.google.com/o/oauth2/auth",
+						"token_uri": "https://oauth2.googleapis.com/token",
+						"auth_provider_x509_cert_url": "https://www.googleapis.com/oauth2/v1/certs",
+						"client_x509_cert_url": "https://www.googleapis.com/robot/v1/metadata/x509/osd"
+					}
+				}`),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "Cluster",
+					"id": "123",
+					"name": "my-cluster"
+				}`),
+			),
+		)
+
+		keyFile, err := os.CreateTemp("", "ocm-test-*.json")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.Remove(keyFile.Name())
+		_, err = keyFile.WriteString(`{
+			"type": "service_account",
+			"project_id": "my-project",
+			"private_key_id": "my-key-id",
+			"private_key": "my-private-key",
+			"client_email": "osd-ccs-admin@my-project.iam.gserviceaccount.com",
+			"client_id": "my-client-id",
+			"auth_uri": "https://accounts.google.com/o/oauth2/auth",
+			"token_uri": "https://oauth2.googleapis.com/token",
+			"auth_provider_x509_cert_url": "https://www.googleapis.com/oauth2/v1/certs",
+			"client_x509_cert_url": "https://www.googleapis.com/robot/v1/metadata/x509/osd"
+		}`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(keyFile.Close()).To(Succeed())
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"cluster", "credentials", "rotate",
+				"--service-account-file", keyFile.Name(),
+				"my-cluster",
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		Expect(result.OutString()).To(Equal(
+			"Updated the cloud credentials of cluster 'my-cluster'\n",
+		))
+	})
+
+	It("Fails if the cluster doesn't use a customer cloud subscription", func() {
+		respondWithCluster(apiServer, `{
+			"kind": "Cluster",
+			"id": "123",
+			"name": "my-cluster",
+			"cloud_provider": {
+				"id": "aws"
+			}
+		}`)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"cluster", "credentials", "rotate",
+				"--aws-access-key-id", "my-key",
+				"--aws-secret-access-key", "my-secret-key",
+				"my-cluster",
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.OutString()).To(BeEmpty())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Cluster 'my-cluster' doesn't use a customer cloud subscription",
+		))
+		Expect(result.ErrString()).ToNot(ContainSubstring("my-secret-key"))
+	})
+
+	It("Fails if the cluster uses AWS STS", func() {
+		respondWithCluster(apiServer, `{
+			"kind": "Cluster",
+			"id": "123",
+			"name": "my-cluster",
+			"cloud_provider": {
+				"id": "aws"
+			},
+			"ccs": {
+				"enabled": true
+			},
+			"aws": {
+				"sts": {
+					"role_arn": "arn:aws:iam::123456789012:role/my-role"
+				}
+			}
+		}`)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"cluster", "credentials", "rotate",
+				"--aws-access-key-id", "my-key",
+				"--aws-secret-access-key", "my-secret-key",
+				"my-cluster",
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.OutString()).To(BeEmpty())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Cluster 'my-cluster' uses AWS STS, it doesn't have static credentials to rotate",
+		))
+	})
+
+	It("Fails if the cluster uses GCP Workload Identity Federation", func() {
+		respondWithCluster(apiServer, `{
+			"kind": "Cluster",
+			"id": "123",
+			"name": "my-cluster",
+			"cloud_provider": {
+				"id": "gcp"
+			},
+			"ccs": {
+				"enabled": true
+			},
+			"gcp": {
+				"authentication": {
+					"kind": "WifConfig",
+					"id": "my-wif-config"
+				}
+			}
+		}`)
+
+		keyFile, err := os.CreateTemp("", "ocm-test-*.json")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.Remove(keyFile.Name())
+		Expect(keyFile.Close()).To(Succeed())
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"cluster", "credentials", "rotate",
+				"--service-account-file", keyFile.Name(),
+				"my-cluster",
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.OutString()).To(BeEmpty())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Cluster 'my-cluster' uses GCP Workload Identity Federation",
+		))
+	})
+
+	It("Fails if the AWS secret access key is missing", func() {
+		respondWithCluster(apiServer, `{
+			"kind": "Cluster",
+			"id": "123",
+			"name": "my-cluster",
+			"cloud_provider": {
+				"id": "aws"
+			},
+			"ccs": {
+				"enabled": true
+			}
+		}`)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"cluster", "credentials", "rotate",
+				"--aws-access-key-id", "my-key",
+				"my-cluster",
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Flags --aws-access-key-id and --aws-secret-access-key are required for AWS clusters",
+		))
+	})
+
+	It("Fails if the service account file is used with an AWS cluster", func() {
+		respondWithCluster(apiServer, `{
+			"kind": "Cluster",
+			"id": "123",
+			"name": "my-cluster",
+			"cloud_provider": {
+				"id": "aws"
+			},
+			"ccs": {
+				"enabled": true
+			}
+		}`)
+
+		keyFile, err := os.CreateTemp("", "ocm-test-*.json")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.Remove(keyFile.Name())
+		Expect(keyFile.Close()).To(Succeed())
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"cluster", "credentials", "rotate",
+				"--service-account-file", keyFile.Name(),
+				"my-cluster",
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Flag --service-account-file is only supported for GCP clusters",
+		))
+	})
+
+	It("Refuses to rotate the credentials in production without confirmation", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Env("OCM_URL", "https://api.openshift.com").
+			Args(
+				"cluster", "credentials", "rotate",
+				"--aws-access-key-id", "my-key",
+				"--aws-secret-access-key", "my-secret-key",
+				"my-cluster",
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Refusing to rotate the cloud credentials of cluster 'my-cluster' in the " +
+				"production environment 'https://api.openshift.com' without confirmation, " +
+				"use --confirm",
+		))
+		Expect(apiServer.ReceivedRequests()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,182 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"    // nolint
+	. "github.com/onsi/gomega"       // nolint
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Cluster events", func() {
+	var ctx context.Context
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	It("Prints the state transitions oldest first, ending with the current state", func() {
+		respondWithCluster(apiServer, `{
+			"kind": "Cluster",
+			"id": "123",
+			"name": "my-cluster",
+			"state": "error",
+			"status": {
+				"state": "error",
+				"provision_error_message": "Quota exceeded"
+			}
+		}`)
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/service_logs/v1/clusters/cluster_logs"),
+				VerifyFormKV("cluster_id", "123"),
+				VerifyFormKV("search", "log_type = 'cluster-state-updates'"),
+				VerifyFormKV("order", "timestamp asc"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "ClusterLogList",
+					"page": 1,
+					"size": 2,
+					"total": 2,
+					"items": [
+						{
+							"kind": "ClusterLog",
+							"id": "1",
+							"timestamp": "2024-05-01T10:00:00Z",
+							"summary": "installing",
+							"description": "Cluster installation started"
+						},
+						{
+							"kind": "ClusterLog",
+							"id": "2",
+							"timestamp": "2024-05-01T10:40:00Z",
+							"summary": "error",
+							"description": "Installation failed"
+						}
+					]
+				}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("cluster", "events", "my-cluster").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		Expect(result.OutString()).To(Equal(
+			"TIMESTAMP             STATE       REASON\n" +
+				"2024-05-01T10:00:00Z  installing  Cluster installation started\n" +
+				"2024-05-01T10:40:00Z  error       Installation failed\n" +
+				"now                   error       Quota exceeded\n",
+		))
+	})
+
+	It("Writes the state transitions in JSON format", func() {
+		respondWithCluster(apiServer, `{
+			"kind": "Cluster",
+			"id": "123",
+			"name": "my-cluster",
+			"state": "ready"
+		}`)
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/service_logs/v1/clusters/cluster_logs"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "ClusterLogList",
+					"page": 1,
+					"size": 1,
+					"total": 1,
+					"items": [
+						{
+							"kind": "LogEntry",
+							"id": "1",
+							"timestamp": "2024-05-01T10:00:00Z",
+							"summary": "ready",
+							"description": "Cluster is ready"
+						}
+					]
+				}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("cluster", "events", "--output", "json", "my-cluster").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		Expect(result.OutString()).To(MatchJSON(`[
+			{
+				"kind": "LogEntry",
+				"id": "1",
+				"timestamp": "2024-05-01T10:00:00Z",
+				"summary": "ready",
+				"description": "Cluster is ready"
+			}
+		]`))
+	})
+
+	It("Requires a cluster", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Args("cluster", "events").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring("accepts 1 arg(s), received 0"))
+	})
+})
@@ -0,0 +1,187 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"    // nolint
+	. "github.com/onsi/gomega"       // nolint
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Cluster kubeconfig", func() {
+	var ctx context.Context
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	kubeconfig := "apiVersion: v1\nkind: Config\n"
+
+	It("Writes the kubeconfig to the standard output", func() {
+		respondWithCluster(apiServer, `{
+			"kind": "Cluster",
+			"id": "123",
+			"name": "my-cluster",
+			"state": "ready"
+		}`)
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/credentials"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "ClusterCredentials",
+					"kubeconfig": "apiVersion: v1\nkind: Config\n"
+				}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("cluster", "kubeconfig", "my-cluster").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		Expect(result.OutString()).To(Equal(kubeconfig))
+	})
+
+	It("Writes the kubeconfig to the file given with --output-file", func() {
+		respondWithCluster(apiServer, `{
+			"kind": "Cluster",
+			"id": "123",
+			"name": "my-cluster",
+			"state": "ready"
+		}`)
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/credentials"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "ClusterCredentials",
+					"kubeconfig": "apiVersion: v1\nkind: Config\n"
+				}`),
+			),
+		)
+
+		tmpDir, err := os.MkdirTemp("", "ocm-test-*.d")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(tmpDir)
+		outFile := filepath.Join(tmpDir, "kubeconfig")
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("cluster", "kubeconfig", "--output-file", outFile, "my-cluster").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.OutString()).To(BeEmpty())
+		data, err := os.ReadFile(outFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(kubeconfig))
+		info, err := os.Stat(outFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+	})
+
+	It("Fails if the cluster isn't ready", func() {
+		respondWithCluster(apiServer, `{
+			"kind": "Cluster",
+			"id": "123",
+			"name": "my-cluster",
+			"state": "installing"
+		}`)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("cluster", "kubeconfig", "my-cluster").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.OutString()).To(BeEmpty())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Admin credentials of cluster 'my-cluster' aren't available because it isn't " +
+				"ready, its state is 'installing'",
+		))
+	})
+
+	It("Fails if the credentials can't be retrieved", func() {
+		respondWithCluster(apiServer, `{
+			"kind": "Cluster",
+			"id": "123",
+			"name": "my-cluster",
+			"state": "ready"
+		}`)
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/credentials"),
+				RespondWithJSON(http.StatusNotFound, `{
+					"kind": "Error",
+					"id": "404",
+					"reason": "Credentials for cluster '123' not found"
+				}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("cluster", "kubeconfig", "my-cluster").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.OutString()).To(BeEmpty())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Can't get admin credentials of cluster 'my-cluster'",
+		))
+	})
+})
@@ -0,0 +1,123 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"    // nolint
+	. "github.com/onsi/gomega"       // nolint
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Cluster status", func() {
+	var ctx context.Context
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	It("Prints the short status of a ready cluster", func() {
+		respondWithCluster(apiServer, `{
+			"kind": "Cluster",
+			"id": "123",
+			"name": "my-cluster",
+			"state": "ready",
+			"openshift_version": "4.15.3",
+			"region": {
+				"id": "us-east-1"
+			},
+			"cloud_provider": {
+				"id": "aws"
+			}
+		}`)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("cluster", "status", "--short", "my-cluster").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		Expect(result.OutString()).To(Equal("ready 4.15.3 us-east-1 aws\n"))
+	})
+
+	It("Fails if the cluster is in error state", func() {
+		respondWithCluster(apiServer, `{
+			"kind": "Cluster",
+			"id": "123",
+			"name": "my-cluster",
+			"state": "error",
+			"status": {
+				"state": "error",
+				"provision_error_message": "Quota exceeded"
+			},
+			"openshift_version": "4.15.3",
+			"region": {
+				"id": "us-east-1"
+			},
+			"cloud_provider": {
+				"id": "aws"
+			}
+		}`)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("cluster", "status", "--short", "my-cluster").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.OutString()).To(Equal("error (Quota exceeded) 4.15.3 us-east-1 aws\n"))
+		Expect(result.ErrString()).To(ContainSubstring("Cluster 'my-cluster' is in error state"))
+	})
+})
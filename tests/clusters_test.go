@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"net/http"
+
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+// respondWithCluster prepares the given API server to return the subscription and the cluster that
+// the commands look up when they are given a cluster name, identifier or external identifier. The
+// identifier of the given cluster must be '123'.
+func respondWithCluster(server *Server, cluster string) {
+	server.AppendHandlers(
+		CombineHandlers(
+			VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/subscriptions"),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "SubscriptionList",
+				"page": 1,
+				"size": 1,
+				"total": 1,
+				"items": [
+					{
+						"kind": "Subscription",
+						"id": "456",
+						"status": "Active",
+						"cluster_id": "123"
+					}
+				]
+			}`),
+		),
+		CombineHandlers(
+			VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+			RespondWithJSON(http.StatusOK, cluster),
+		),
+	)
+}
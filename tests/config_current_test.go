@@ -0,0 +1,94 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" // nolint
+	. "github.com/onsi/gomega"    // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Config current", func() {
+	var ctx context.Context
+	var config string
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		config = EvaluateTemplate(
+			`{
+				"access_token": "{{ .accessToken }}",
+				"refresh_token": "{{ .refreshToken }}",
+				"url": "https://api.example.com",
+				"token_url": "https://sso.example.com/token"
+			}`,
+			"accessToken", MakeTokenString("Bearer", -time.Hour),
+			"refreshToken", MakeTokenString("Refresh", 10*time.Hour),
+		)
+	})
+
+	It("Prints the current environment", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Args("config", "current").
+			Run(ctx)
+		Expect(result.ErrString()).To(BeEmpty())
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.OutLines()).To(Equal([]string{
+			"Config file:   " + result.ConfigFile(),
+			"Keyring:       N/A",
+			"URL:           https://api.example.com",
+			"Token URL:     https://sso.example.com/token",
+			"Access token:  expired 1 hour ago",
+			"Refresh token: valid, expires in 9 hours",
+		}))
+	})
+
+	It("Prints the current environment in JSON format", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Args("config", "current", "--output", "json").
+			Run(ctx)
+		Expect(result.ErrString()).To(BeEmpty())
+		Expect(result.ExitCode()).To(BeZero())
+		var env map[string]interface{}
+		err := json.Unmarshal([]byte(result.OutString()), &env)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(env).To(HaveKeyWithValue("config_file", result.ConfigFile()))
+		Expect(env).ToNot(HaveKey("keyring"))
+		Expect(env).To(HaveKeyWithValue("url", "https://api.example.com"))
+		Expect(env).To(HaveKeyWithValue("token_url", "https://sso.example.com/token"))
+		Expect(env).To(HaveKeyWithValue("access_token", HaveKeyWithValue("state", "expired")))
+		Expect(env).To(HaveKeyWithValue("refresh_token", HaveKeyWithValue("state", "valid")))
+		Expect(result.OutString()).ToNot(ContainSubstring("eyJ"))
+	})
+
+	It("Reports missing tokens", func() {
+		result := NewCommand().
+			ConfigString(`{"url": "https://api.example.com"}`).
+			Args("config", "whereami").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.OutString()).To(ContainSubstring("Token URL:     N/A\n"))
+		Expect(result.OutString()).To(ContainSubstring("Access token:  not set\n"))
+		Expect(result.OutString()).To(ContainSubstring("Refresh token: not set\n"))
+	})
+})
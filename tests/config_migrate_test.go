@@ -0,0 +1,77 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" // nolint
+	. "github.com/onsi/gomega"    // nolint
+
+	"github.com/openshift-online/ocm-cli/pkg/properties"
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Config migrate", func() {
+	var ctx context.Context
+	var config string
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		config = EvaluateTemplate(
+			`{
+				"access_token": "{{ .accessToken }}",
+				"url": "https://api.example.com"
+			}`,
+			"accessToken", MakeTokenString("Bearer", 15*time.Minute),
+		)
+	})
+
+	It("Requires the keyring flag", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Args("config", "migrate").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(`required flag(s) "keyring" not set`))
+		Expect(result.ConfigString()).To(MatchJSON(config))
+	})
+
+	It("Rejects invalid keyring and keeps the config file", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Args("config", "migrate", "--keyring", "junk").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring("keyring is invalid"))
+		Expect(result.ConfigString()).To(MatchJSON(config))
+	})
+
+	It("Fails if the configuration is already managed by a keyring", func() {
+		result := NewCommand().
+			Env(properties.KeyringEnvKey, "pass").
+			ConfigString(config).
+			Args("config", "migrate", "--keyring", "pass").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"configuration is already managed by keyring 'pass'",
+		))
+		Expect(result.ConfigString()).To(MatchJSON(config))
+	})
+})
@@ -0,0 +1,277 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"    // nolint
+	. "github.com/onsi/gomega"       // nolint
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Create cluster completion", func() {
+	var ctx context.Context
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	const regions = `{
+		"kind": "CloudRegionList",
+		"page": 1,
+		"size": 4,
+		"total": 4,
+		"items": [
+			{
+				"kind": "CloudRegion",
+				"id": "us-east1",
+				"display_name": "US East 1",
+				"enabled": true,
+				"supports_multi_az": true
+			},
+			{
+				"kind": "CloudRegion",
+				"id": "us-west1",
+				"display_name": "US West 1",
+				"enabled": true,
+				"supports_multi_az": false
+			},
+			{
+				"kind": "CloudRegion",
+				"id": "europe-west1",
+				"display_name": "Europe West 1",
+				"enabled": true,
+				"ccs_only": true,
+				"supports_multi_az": true
+			},
+			{
+				"kind": "CloudRegion",
+				"id": "asia-east1",
+				"display_name": "Asia East 1",
+				"enabled": false,
+				"supports_multi_az": true
+			}
+		]
+	}`
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+
+		// Prepare the server:
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/cloud_providers/gcp/regions"),
+				RespondWithJSON(http.StatusOK, regions),
+			),
+		)
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	It("Completes only enabled regions without --ccs", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Args("__complete", "create", "cluster", "--provider", "gcp", "--region", "").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.OutLines()).To(Equal([]string{
+			"us-east1\tUS East 1",
+			"us-west1\tUS West 1",
+			":4",
+		}))
+	})
+
+	It("Takes into account --ccs and --multi-az", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"__complete", "create", "cluster",
+				"--provider", "gcp",
+				"--ccs",
+				"--multi-az",
+				"--region", "",
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.OutLines()).To(Equal([]string{
+			"us-east1\tUS East 1",
+			"europe-west1\tEurope West 1",
+			"asia-east1\tAsia East 1",
+			":4",
+		}))
+	})
+})
+
+var _ = Describe("Create FedRAMP cluster completion", func() {
+	var ctx context.Context
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	const regions = `{
+		"kind": "CloudRegionList",
+		"page": 1,
+		"size": 3,
+		"total": 3,
+		"items": [
+			{
+				"kind": "CloudRegion",
+				"id": "us-east-1",
+				"display_name": "US East, N. Virginia",
+				"enabled": true,
+				"supports_multi_az": true
+			},
+			{
+				"kind": "CloudRegion",
+				"id": "us-gov-west-1",
+				"display_name": "AWS GovCloud (US-West)",
+				"enabled": true,
+				"ccs_only": true,
+				"govcloud": true,
+				"supports_multi_az": true
+			},
+			{
+				"kind": "CloudRegion",
+				"id": "us-gov-east-1",
+				"display_name": "AWS GovCloud (US-East)",
+				"enabled": true,
+				"ccs_only": true,
+				"govcloud": true,
+				"supports_multi_az": true
+			}
+		]
+	}`
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+
+		// Prepare the server:
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodPost,
+					"/api/clusters_mgmt/v1/cloud_providers/aws/available_regions",
+				),
+				RespondWithJSON(http.StatusOK, regions),
+			),
+		)
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	It("Completes only GovCloud regions with --fedramp", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"__complete", "create", "cluster",
+				"--provider", "aws",
+				"--ccs",
+				"--fedramp",
+				"--region", "",
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.OutLines()).To(Equal([]string{
+			"us-gov-west-1\tAWS GovCloud (US-West)",
+			"us-gov-east-1\tAWS GovCloud (US-East)",
+			":4",
+		}))
+	})
+
+	It("Completes all the regions, including GovCloud ones, without --fedramp", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"__complete", "create", "cluster",
+				"--provider", "aws",
+				"--ccs",
+				"--region", "",
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.OutLines()).To(Equal([]string{
+			"us-east-1\tUS East, N. Virginia",
+			"us-gov-west-1\tAWS GovCloud (US-West)",
+			"us-gov-east-1\tAWS GovCloud (US-East)",
+			":4",
+		}))
+	})
+})
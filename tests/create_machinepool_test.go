@@ -0,0 +1,439 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"    // nolint
+	. "github.com/onsi/gomega"       // nolint
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Create machine pool", Ordered, func() {
+	var ctx context.Context
+
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	var subscriptionInfo string = `{
+		"items": [
+		  {
+			"kind":"Subscription",
+			"cluster_id":"my-cluster",
+			"id":"subsID"
+		  }]
+	}`
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	It("Fails to use a kubelet config on a classic cluster", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, subscriptionInfo),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "ClusterList",
+				"total": 1,
+				"items": [
+					{
+					"kind":"Cluster",
+					"id":"my-cluster",
+					"subscription": {"id":"subsID"},
+					"state":"ready"
+					}]
+			  }`),
+		)
+
+		// Run the command:
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"create", "machinepool",
+				"--cluster", "my-cluster",
+				"--instance-type", "m5.xlarge",
+				"--replicas", "2",
+				"--kubelet-config", "my-kubelet-config",
+				"mp-1",
+			).Run(ctx)
+
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"--kubelet-config is only supported for Hosted Control Plane clusters"))
+	})
+
+	It("Fails to use a kubelet config that doesn't exist", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, subscriptionInfo),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "ClusterList",
+				"total": 1,
+				"items": [
+					{
+					"kind":"Cluster",
+					"id":"my-cluster",
+					"subscription": {"id":"subsID"},
+					"state":"ready",
+					"hypershift": {"enabled": true}
+					}]
+			  }`),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "KubeletConfigList",
+				"total": 1,
+				"items": [
+					{
+					"kind":"KubeletConfig",
+					"id":"kc-1",
+					"name":"other-kubelet-config",
+					"pod_pids_limit": 5000
+					}]
+			  }`),
+		)
+
+		// Run the command:
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"create", "machinepool",
+				"--cluster", "my-cluster",
+				"--instance-type", "m5.xlarge",
+				"--replicas", "2",
+				"--kubelet-config", "my-kubelet-config",
+				"mp-1",
+			).Run(ctx)
+
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Kubelet config 'my-kubelet-config' doesn't exist, valid values are: other-kubelet-config"))
+	})
+
+	It("Adds a node pool to use a kubelet config", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, subscriptionInfo),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "ClusterList",
+				"total": 1,
+				"items": [
+					{
+					"kind":"Cluster",
+					"id":"my-cluster",
+					"subscription": {"id":"subsID"},
+					"state":"ready",
+					"cloud_provider": {"id":"aws"},
+					"ccs": {"enabled": true},
+					"hypershift": {"enabled": true}
+					}]
+			  }`),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "KubeletConfigList",
+				"total": 1,
+				"items": [
+					{
+					"kind":"KubeletConfig",
+					"id":"kc-1",
+					"name":"my-kubelet-config",
+					"pod_pids_limit": 5000
+					}]
+			  }`),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "MachineTypeList",
+				"total": 1,
+				"items": [
+					{
+						"kind": "MachineType",
+						"id": "m5.xlarge",
+						"name": "General Purpose"
+					}
+				]
+			}`),
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/my-cluster/node_pools"),
+				VerifyJSON(`{
+					"kind": "NodePool",
+					"id": "mp-1",
+					"aws_node_pool": {
+						"kind": "AWSNodePool",
+						"instance_type": "m5.xlarge"
+					},
+					"kubelet_configs": ["my-kubelet-config"],
+					"labels": {},
+					"replicas": 2,
+					"taints": []
+				}`),
+				RespondWithJSON(http.StatusCreated, `{}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"create", "machinepool",
+				"--cluster", "my-cluster",
+				"--instance-type", "m5.xlarge",
+				"--replicas", "2",
+				"--kubelet-config", "my-kubelet-config",
+				"mp-1",
+			).Run(ctx)
+		Expect(result.ErrString()).To(BeEmpty())
+		Expect(result.ExitCode()).To(BeZero())
+	})
+
+	It("Adds a machine pool to a Hosted Control Plane cluster without a kubelet config", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, subscriptionInfo),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "ClusterList",
+				"total": 1,
+				"items": [
+					{
+					"kind":"Cluster",
+					"id":"my-cluster",
+					"subscription": {"id":"subsID"},
+					"state":"ready",
+					"cloud_provider": {"id":"aws"},
+					"ccs": {"enabled": true},
+					"hypershift": {"enabled": true}
+					}]
+			  }`),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "MachineTypeList",
+				"total": 1,
+				"items": [
+					{
+						"kind": "MachineType",
+						"id": "m5.xlarge",
+						"name": "General Purpose"
+					}
+				]
+			}`),
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/my-cluster/machine_pools"),
+				RespondWithJSON(http.StatusCreated, `{}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"create", "machinepool",
+				"--cluster", "my-cluster",
+				"--instance-type", "m5.xlarge",
+				"--replicas", "2",
+				"mp-1",
+			).Run(ctx)
+		Expect(result.ErrString()).To(BeEmpty())
+		Expect(result.ExitCode()).To(BeZero())
+	})
+
+	It("Merges the labels and taints files with the inline flags", func() {
+		dir := GinkgoT().TempDir()
+		labelsFile := filepath.Join(dir, "labels.yaml")
+		err := os.WriteFile(labelsFile, []byte("foo: bar\nbar: old\n"), 0600)
+		Expect(err).ToNot(HaveOccurred())
+		taintsFile := filepath.Join(dir, "taints.yaml")
+		err = os.WriteFile(taintsFile, []byte(
+			"- key: dedicated\n  value: gpu\n  effect: NoSchedule\n"+
+				"- key: spot\n  effect: NoExecute\n",
+		), 0600)
+		Expect(err).ToNot(HaveOccurred())
+
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, subscriptionInfo),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "ClusterList",
+				"total": 1,
+				"items": [
+					{
+					"kind":"Cluster",
+					"id":"my-cluster",
+					"subscription": {"id":"subsID"},
+					"state":"ready",
+					"cloud_provider": {"id":"aws"},
+					"ccs": {"enabled": true}
+					}]
+			  }`),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "MachineTypeList",
+				"total": 1,
+				"items": [
+					{
+						"kind": "MachineType",
+						"id": "m5.xlarge",
+						"name": "General Purpose"
+					}
+				]
+			}`),
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/my-cluster/machine_pools"),
+				VerifyJSON(`{
+					"kind": "MachinePool",
+					"id": "mp-1",
+					"instance_type": "m5.xlarge",
+					"replicas": 2,
+					"labels": {
+						"foo": "bar",
+						"bar": "new"
+					},
+					"taints": [
+						{"key": "spot", "value": "", "effect": "NoExecute"},
+						{"key": "dedicated", "value": "cpu", "effect": "NoSchedule"}
+					]
+				}`),
+				RespondWithJSON(http.StatusCreated, `{}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"create", "machinepool",
+				"--cluster", "my-cluster",
+				"--instance-type", "m5.xlarge",
+				"--replicas", "2",
+				"--labels-file", labelsFile,
+				"--labels", "bar=new",
+				"--taints-file", taintsFile,
+				"--taints", "dedicated=cpu:NoSchedule",
+				"mp-1",
+			).Run(ctx)
+		Expect(result.ErrString()).To(BeEmpty())
+		Expect(result.ExitCode()).To(BeZero())
+	})
+
+	It("Rejects an invalid taints file", func() {
+		taintsFile := filepath.Join(GinkgoT().TempDir(), "taints.yaml")
+		err := os.WriteFile(taintsFile, []byte("- key: spot\n  effect: Never\n"), 0600)
+		Expect(err).ToNot(HaveOccurred())
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"create", "machinepool",
+				"--cluster", "my-cluster",
+				"--instance-type", "m5.xlarge",
+				"--replicas", "2",
+				"--taints-file", taintsFile,
+				"mp-1",
+			).Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring("Invalid effect 'Never' for taint 'spot'"))
+	})
+
+	It("Completes the instance types of the provider of the cluster", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, subscriptionInfo),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "ClusterList",
+				"total": 1,
+				"items": [
+					{
+					"kind":"Cluster",
+					"id":"my-cluster",
+					"subscription": {"id":"subsID"},
+					"state":"ready",
+					"cloud_provider": {"id":"gcp"},
+					"ccs": {"enabled": false}
+					}]
+			  }`),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/machine_types"),
+				VerifyFormKV("search", "cloud_provider.id = 'gcp'"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "MachineTypeList",
+					"page": 1,
+					"size": 2,
+					"total": 2,
+					"items": [
+						{
+							"kind": "MachineType",
+							"id": "custom-4-16384",
+							"name": "Custom 4 vCPU"
+						},
+						{
+							"kind": "MachineType",
+							"id": "n2-highmem-8",
+							"name": "N2 High Memory 8 vCPU",
+							"ccs_only": true
+						}
+					]
+				}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"__complete", "create", "machinepool",
+				"--cluster", "my-cluster",
+				"--instance-type", "",
+			).Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.OutLines()).To(Equal([]string{
+			"custom-4-16384\tCustom 4 vCPU",
+			":4",
+		}))
+	})
+
+	It("Doesn't complete instance types without a cluster", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Args("__complete", "create", "machinepool", "--instance-type", "").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.OutLines()).To(Equal([]string{":4"}))
+		Expect(result.ErrString()).To(ContainSubstring("flag --cluster is needed to complete machine types"))
+	})
+})
@@ -0,0 +1,143 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"    // nolint
+	. "github.com/onsi/gomega"       // nolint
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Delete", func() {
+	var ctx context.Context
+
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	It("Refuses to delete a cluster without confirmation", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Args("delete", "cluster", "my-cluster").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Refusing to delete cluster 'my-cluster' without confirmation"))
+		Expect(apiServer.ReceivedRequests()).To(BeEmpty())
+	})
+
+	It("Deletes a cluster when --confirm is used", func() {
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodDelete, "/api/clusters_mgmt/v1/clusters/my-cluster"),
+				RespondWithJSON(http.StatusNoContent, `{}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("delete", "--confirm", "cluster", "my-cluster").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(apiServer.ReceivedRequests()).To(HaveLen(1))
+	})
+
+	It("Doesn't ask for confirmation for other resources", func() {
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodDelete, "/api/clusters_mgmt/v1/clusters/my-cluster/groups/my-group"),
+				RespondWithJSON(http.StatusNoContent, `{}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("delete", "/api/clusters_mgmt/v1/clusters/my-cluster/groups/my-group").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+	})
+
+	It("Refuses to delete other resources in production without confirmation", func() {
+		result := NewCommand().
+			ConfigString(`{
+				"url": "https://api.openshift.com"
+			}`).
+			Args("delete", "/api/clusters_mgmt/v1/clusters/my-cluster/groups/my-group").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Refusing to delete '/api/clusters_mgmt/v1/clusters/my-cluster/groups/my-group' " +
+				"in the production environment 'https://api.openshift.com' without confirmation",
+		))
+	})
+
+	It("Refuses to delete a machine pool in production without confirmation", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Env("OCM_URL", "https://api.openshift.com").
+			Args("delete", "machinepool", "--cluster", "my-cluster", "mp-1").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Refusing to delete machine pool 'mp-1' of cluster 'my-cluster' in the production " +
+				"environment",
+		))
+		Expect(apiServer.ReceivedRequests()).To(BeEmpty())
+	})
+})
@@ -319,6 +319,113 @@ var _ = Describe("Describe clusters", func() {
 
 		})
 
+		It("Describes a cluster that doesn't have a subscription", func() {
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "SubscriptionList",
+					"page": 1,
+					"size": 0,
+					"total": 0,
+					"items": []
+				}`),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "ClusterList",
+					"page": 1,
+					"size": 1,
+					"total": 1,
+					"items": [
+						{
+							"kind": "Cluster",
+							"id": "222",
+							"name": "no-metrics",
+							"creation_timestamp": "2021-07-05T03:27:18Z",
+							"cloud_provider": {
+								"id": "aws"
+							},
+							"state": "installing"
+						}
+					]
+				}`),
+				RespondWithJSON(http.StatusNotFound, `{
+					"kind": "Error",
+					"id": "404",
+					"reason": "Provision shard not found"
+				}`),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args("describe", "cluster", "no-metrics").
+				Run(ctx)
+			Expect(result.ErrString()).To(BeEmpty())
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.OutString()).To(MatchRegexp(`(?m)^Display Name:\s+N/A$`))
+			Expect(result.OutString()).To(MatchRegexp(`(?m)^Cluster History URL:\s+N/A$`))
+			Expect(result.OutString()).To(MatchRegexp(`(?m)^Organization:\s+N/A$`))
+			Expect(result.OutString()).To(MatchRegexp(`(?m)^Creator:\s+N/A$`))
+		})
+
+		It("Describes only the timestamps of the cluster", func() {
+			apiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "SubscriptionList",
+					"page": 1,
+					"size": 1,
+					"total": 1,
+					"items": [
+						{
+							"kind": "Subscription",
+							"id": "111",
+							"status": "Active",
+							"cluster_id": "111"
+						}
+					]
+				}`),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "Cluster",
+					"id": "111",
+					"name": "test",
+					"creation_timestamp": "2021-07-05T03:27:18.264654Z",
+					"subscription": {
+						"kind": "SubscriptionLink",
+						"id": "111"
+					}
+				}`),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/subscriptions/111"),
+					RespondWithJSON(http.StatusOK, `{
+						"kind": "Subscription",
+						"id": "111",
+						"updated_at": "2021-07-09T05:49:31Z"
+					}`),
+				),
+			)
+
+			result := NewCommand().
+				ConfigString(config).
+				Args("describe", "cluster", "--timestamps", "test").
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			lines := result.OutLines()
+			Expect(lines).To(HaveLen(4))
+			Expect(lines[2]).To(MatchRegexp(`^Created:\s+2021-07-05T03:27:18Z \(\d+ days ago\)$`))
+			Expect(lines[3]).To(MatchRegexp(`^Last Updated:\s+2021-07-09T05:49:31Z \(\d+ days ago\)$`))
+		})
+
+		It("Rejects --timestamps together with --json", func() {
+			result := NewCommand().
+				ConfigString(config).
+				Args("describe", "cluster", "--json", "--timestamps", "test").
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(result.ErrString()).To(ContainSubstring(
+				"Flags --json and --timestamps can't be used at the same time",
+			))
+		})
+
 		It("Describe a cluster with multiple matching subscriptions", func() {
 			// Prepare the server:
 			apiServer.AppendHandlers(
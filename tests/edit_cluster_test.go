@@ -0,0 +1,327 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"    // nolint
+	. "github.com/onsi/gomega"       // nolint
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Edit cluster", func() {
+	var ctx context.Context
+
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	var subscriptionInfo string = `{
+		"items": [
+		  {
+			"kind":"Subscription",
+			"cluster_id":"my-cluster",
+			"id":"subsID"
+		  }]
+	}`
+
+	var clustersInfo string = `{
+		"kind": "ClusterList",
+		"total": 1,
+		"items": [
+			{
+			"kind":"Cluster",
+			"id":"my-cluster",
+			"name":"my-cluster",
+			"subscription": {"id":"subsID"},
+			"state":"ready",
+			"openshift_version":"4.16.1",
+			"version": {
+				"id":"openshift-v4.16.1",
+				"raw_id":"4.16.1",
+				"channel_group":"stable"
+			}
+			}]
+	  }`
+
+	var versionsInfo string = `{
+		"kind": "VersionList",
+		"page": 1,
+		"size": 2,
+		"total": 2,
+		"items": [
+			{
+			"kind":"Version",
+			"id":"openshift-v4.16.1",
+			"raw_id":"4.16.1",
+			"channel_group":"stable",
+			"enabled":true
+			},
+			{
+			"kind":"Version",
+			"id":"openshift-v4.16.1-fast",
+			"raw_id":"4.16.1",
+			"channel_group":"fast",
+			"enabled":true
+			}]
+	  }`
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	It("Refuses to edit a cluster in production without confirmation", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Env("OCM_URL", "https://api.openshift.com").
+			Args(
+				"edit", "cluster",
+				"--channel-group", "fast",
+				"my-cluster",
+			).Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Refusing to edit cluster 'my-cluster' in the production environment " +
+				"'https://api.openshift.com' without confirmation, use --confirm",
+		))
+		Expect(apiServer.ReceivedRequests()).To(BeEmpty())
+	})
+
+	It("Changes the channel group when it is available for the cluster version", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, subscriptionInfo),
+			RespondWithJSON(http.StatusOK, clustersInfo),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+				VerifyFormKV("search", "enabled = 'true' AND raw_id = '4.16.1'"),
+				RespondWithJSON(http.StatusOK, versionsInfo),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/my-cluster"),
+				VerifyJSON(`{
+					"kind": "Cluster",
+					"version": {
+						"kind": "Version",
+						"channel_group": "fast"
+					}
+				}`),
+				RespondWithJSON(http.StatusOK, `{}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"edit", "cluster",
+				"--channel-group", "fast",
+				"my-cluster",
+			).Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+	})
+
+	It("Rejects a channel group that isn't available for the cluster version", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, subscriptionInfo),
+			RespondWithJSON(http.StatusOK, clustersInfo),
+			RespondWithJSON(http.StatusOK, versionsInfo),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"edit", "cluster",
+				"--channel-group", "candidate",
+				"my-cluster",
+			).Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Channel group 'candidate' isn't available for version '4.16.1' of cluster 'my-cluster', " +
+				"valid values are: fast, stable",
+		))
+		Expect(apiServer.ReceivedRequests()).To(HaveLen(3))
+	})
+
+	It("Sets the additional trust bundle from a PEM file", func() {
+		bundle := makeCertificateBundle()
+		file, err := os.CreateTemp("", "ocm-test-*.pem")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(os.Remove, file.Name())
+		_, err = file.Write(bundle)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(file.Close()).To(Succeed())
+
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, subscriptionInfo),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "ClusterList",
+				"total": 1,
+				"items": [
+					{
+						"kind": "Cluster",
+						"id": "my-cluster",
+						"name": "my-cluster",
+						"subscription": {"id": "subsID"},
+						"state": "ready",
+						"aws": {
+							"subnet_ids": ["subnet-123"]
+						}
+					}
+				]
+			}`),
+			CombineHandlers(
+				VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/my-cluster"),
+				VerifyJSONRepresenting(map[string]interface{}{
+					"kind":                    "Cluster",
+					"additional_trust_bundle": string(bundle),
+				}),
+				RespondWithJSON(http.StatusOK, `{}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"edit", "cluster",
+				"--additional-trust-bundle-file", file.Name(),
+				"my-cluster",
+			).Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+	})
+
+	It("Rejects an additional trust bundle file that doesn't contain certificates", func() {
+		file, err := os.CreateTemp("", "ocm-test-*.pem")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(os.Remove, file.Name())
+		_, err = file.WriteString("junk")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(file.Close()).To(Succeed())
+
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, subscriptionInfo),
+			RespondWithJSON(http.StatusOK, clustersInfo),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"edit", "cluster",
+				"--additional-trust-bundle-file", file.Name(),
+				"my-cluster",
+			).Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring("Failed to parse additional trust bundle"))
+		Expect(apiServer.ReceivedRequests()).To(HaveLen(2))
+	})
+
+	It("Removes the additional trust bundle when the file is explicitly empty", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, subscriptionInfo),
+			RespondWithJSON(http.StatusOK, clustersInfo),
+			CombineHandlers(
+				VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/my-cluster"),
+				VerifyJSON(`{
+					"kind": "Cluster",
+					"additional_trust_bundle": ""
+				}`),
+				RespondWithJSON(http.StatusOK, `{}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"edit", "cluster",
+				"--additional-trust-bundle-file", "",
+				"my-cluster",
+			).Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+	})
+})
+
+// makeCertificateBundle returns a PEM bundle containing a self-signed certificate.
+func makeCertificateBundle() []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject: pkix.Name{
+			CommonName: "my-ca",
+		},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	buffer := &bytes.Buffer{}
+	err = pem.Encode(buffer, &pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: der,
+	})
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	return buffer.Bytes()
+}
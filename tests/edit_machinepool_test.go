@@ -0,0 +1,166 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"    // nolint
+	. "github.com/onsi/gomega"       // nolint
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Edit machine pool", func() {
+	var ctx context.Context
+
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	var subscriptionInfo string = `{
+		"items": [
+		  {
+			"kind":"Subscription",
+			"cluster_id":"my-cluster",
+			"id":"subsID"
+		  }]
+	}`
+
+	var clustersInfo string = `{
+		"kind": "ClusterList",
+		"total": 1,
+		"items": [
+			{
+			"kind":"Cluster",
+			"id":"my-cluster",
+			"subscription": {"id":"subsID"},
+			"state":"ready"
+			}]
+	  }`
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	It("Refuses to edit a machine pool in production without confirmation", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Env("OCM_URL", "https://api.openshift.com").
+			Args(
+				"edit", "machinepool",
+				"--cluster", "my-cluster",
+				"--replicas", "3",
+				"mp1",
+			).Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Refusing to edit machine pool 'mp1' of cluster 'my-cluster' in the production " +
+				"environment 'https://api.openshift.com' without confirmation, use --confirm",
+		))
+		Expect(apiServer.ReceivedRequests()).To(BeEmpty())
+	})
+
+	It("Removes labels and taints when the flags are empty", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, subscriptionInfo),
+			RespondWithJSON(http.StatusOK, clustersInfo),
+			CombineHandlers(
+				VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/my-cluster/machine_pools/mp1"),
+				VerifyJSON(`{
+					"kind": "MachinePool",
+					"id": "mp1",
+					"labels": {},
+					"taints": []
+				}`),
+				RespondWithJSON(http.StatusOK, `{}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"edit", "machinepool",
+				"--cluster", "my-cluster",
+				"--labels", "",
+				"--taints", "",
+				"mp1",
+			).Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+	})
+
+	It("Leaves labels and taints unchanged when the flags aren't used", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, subscriptionInfo),
+			RespondWithJSON(http.StatusOK, clustersInfo),
+			CombineHandlers(
+				VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/my-cluster/machine_pools/mp1"),
+				VerifyJSON(`{
+					"kind": "MachinePool",
+					"id": "mp1",
+					"replicas": 3
+				}`),
+				RespondWithJSON(http.StatusOK, `{}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"edit", "machinepool",
+				"--cluster", "my-cluster",
+				"--replicas", "3",
+				"mp1",
+			).Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+	})
+})
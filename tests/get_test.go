@@ -19,6 +19,9 @@ package tests
 import (
 	"context"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"    // nolint
@@ -116,6 +119,106 @@ var _ = Describe("Get", func() {
 			Expect(result.OutString()).To(MatchJSON(`{ "my_field": "my_value" }`))
 		})
 
+		It("Sends the request through the proxy given with --ocm-proxy", func() {
+			// Prepare the proxy server, the API server has no handlers so it will fail
+			// if the request is sent to it directly:
+			proxyServer := MakeTCPServer()
+			defer proxyServer.Close()
+			proxyServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/my_service/v1/my_object"),
+					RespondWithJSON(
+						http.StatusOK,
+						`{ "my_field": "my_value" }`,
+					),
+				),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"get",
+					"--ocm-proxy", proxyServer.URL(),
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			Expect(result.OutString()).To(MatchJSON(`{ "my_field": "my_value" }`))
+			Expect(proxyServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("Fails if the --ocm-proxy URL isn't valid", func() {
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"get",
+					"--ocm-proxy", "not-a-url",
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(result.ErrString()).To(ContainSubstring("Invalid proxy URL 'not-a-url'"))
+		})
+
+		It("Trusts the CA certificates given with --ca-file", func() {
+			// Prepare a TLS server with a certificate that isn't trusted by the system:
+			tlsServer, caFile := MakeTCPTLSServer()
+			defer tlsServer.Close()
+			defer os.Remove(caFile)
+			tlsServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, `{ "my_field": "my_value" }`),
+			)
+			tlsConfig := strings.ReplaceAll(config, apiServer.URL(), tlsServer.URL())
+
+			// Run the command without the CA file, it should fail to verify the certificate:
+			result := NewCommand().
+				ConfigString(tlsConfig).
+				Args(
+					"get",
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(result.ErrString()).To(ContainSubstring("certificate"))
+
+			// Run the command with the CA file:
+			result = NewCommand().
+				ConfigString(tlsConfig).
+				Args(
+					"get",
+					"--ca-file", caFile,
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			Expect(result.OutString()).To(MatchJSON(`{ "my_field": "my_value" }`))
+		})
+
+		It("Fails if the --ca-file doesn't contain certificates", func() {
+			caFile, err := os.CreateTemp("", "*.test.ca")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.Remove(caFile.Name())
+			_, err = caFile.WriteString("junk")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(caFile.Close()).To(Succeed())
+
+			result := NewCommand().
+				ConfigString(config).
+				Env("OCM_CA_FILE", caFile.Name()).
+				Args(
+					"get",
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(result.ErrString()).To(ContainSubstring(
+				"doesn't contain any PEM encoded certificate",
+			))
+		})
+
 		It("Honours the --parameter flag", func() {
 			// Prepare the server:
 			apiServer.AppendHandlers(
@@ -138,6 +241,38 @@ var _ = Describe("Get", func() {
 			Expect(result.ErrString()).To(BeEmpty())
 		})
 
+		It("Reads the --parameter values from a file", func() {
+			// Prepare the file:
+			file := filepath.Join(GinkgoT().TempDir(), "parameters")
+			err := os.WriteFile(file, []byte(
+				"# My parameters\n"+
+					"my_param=my_value\n"+
+					"your_param=your_value\n",
+			), 0600)
+			Expect(err).ToNot(HaveOccurred())
+
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					VerifyFormKV("my_param", "my_value"),
+					VerifyFormKV("your_param", "your_value"),
+					RespondWithJSON(http.StatusOK, `{}`),
+				),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"get",
+					"--parameter", "@"+file,
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+		})
+
 		It("Honours the -p flag as alias to --parameter", func() {
 			// Prepare the server:
 			apiServer.AppendHandlers(
@@ -240,6 +375,361 @@ var _ = Describe("Get", func() {
 			)))
 		})
 
+		It("Honours the --raw flag", func() {
+			// Prepare the server:
+			body := `{ "my_field":"my_value",  "your_field": 123 }`
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					RespondWithJSON(http.StatusOK, body),
+				),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"get",
+					"--raw",
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			Expect(result.OutString()).To(Equal(body))
+		})
+
+		It("Honours the --compact flag", func() {
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					RespondWithJSON(http.StatusOK, `{
+						"your_field": "<your_value>",
+						"my_field": [1, 2.50, true]
+					}`),
+				),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"get",
+					"--compact",
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			Expect(result.OutString()).To(Equal(
+				`{"your_field":"<your_value>","my_field":[1,2.50,true]}` + "\n",
+			))
+		})
+
+		It("Fails if --compact and --raw are used together", func() {
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"get",
+					"--compact",
+					"--raw",
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(result.ErrString()).To(ContainSubstring(
+				"Flags --compact and --raw can't be used at the same time",
+			))
+		})
+
+		It("Honours the --flatten flag", func() {
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					RespondWithJSON(http.StatusOK, `{
+						"name": "my-cluster",
+						"nodes": {
+							"compute": 3,
+							"availability_zones": ["us-east-1a", "us-east-1b"]
+						},
+						"items": [
+							{ "id": "123", "ready": true },
+							{ "id": "456", "ready": false }
+						],
+						"labels": {},
+						"taints": [],
+						"expiration": null
+					}`),
+				),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"get",
+					"--flatten",
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			Expect(result.OutLines()).To(Equal([]string{
+				"name = my-cluster",
+				"nodes.compute = 3",
+				"nodes.availability_zones[0] = us-east-1a",
+				"nodes.availability_zones[1] = us-east-1b",
+				"items[0].id = 123",
+				"items[0].ready = true",
+				"items[1].id = 456",
+				"items[1].ready = false",
+				"labels = {}",
+				"taints = []",
+				"expiration = null",
+			}))
+		})
+
+		It("Flattens arrays at the top level of the response", func() {
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					RespondWithJSON(http.StatusOK, `[
+						{ "id": "123" },
+						{ "id": "456" }
+					]`),
+				),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"get",
+					"--flatten",
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.OutLines()).To(Equal([]string{
+				"[0].id = 123",
+				"[1].id = 456",
+			}))
+		})
+
+		It("Fails if --flatten and --jq are used together", func() {
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"get",
+					"--flatten",
+					"--jq", ".id",
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(result.ErrString()).To(ContainSubstring(
+				"Flags --flatten and --jq can't be used at the same time",
+			))
+		})
+
+		It("Fails if --raw and --single are used together", func() {
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"get",
+					"--raw",
+					"--single",
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(result.ErrString()).To(ContainSubstring(
+				"Flags --raw and --single can't be used at the same time",
+			))
+		})
+
+		It("Honours the --verbose-timing flag", func() {
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					RespondWithJSON(http.StatusOK, `{ "my_field": "my_value" }`),
+				),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"get",
+					"--verbose-timing",
+					"--single",
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.OutString()).To(Equal(`{"my_field":"my_value"}` + "\n"))
+			Expect(result.ErrString()).To(MatchRegexp(
+				`^GET /api/my_service/v1/my_object 200 \S+\n$`,
+			))
+		})
+
+		It("Extracts a scalar with the --jq flag", func() {
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					RespondWithJSON(http.StatusOK, `{
+						"items": [
+							{
+								"id": "123",
+								"name": "my_name"
+							},
+							{
+								"id": "456",
+								"name": "your_name"
+							}
+						]
+					}`),
+				),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"get",
+					"--jq", ".items[1].name",
+					"/api/my_service/v1/my_objects",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			Expect(result.OutString()).To(Equal("your_name\n"))
+		})
+
+		It("Extracts an object with the --jq flag", func() {
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					RespondWithJSON(http.StatusOK, `{
+						"spec": {
+							"nodes": {
+								"compute": 3
+							}
+						}
+					}`),
+				),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"get",
+					"--jq", "spec.nodes",
+					"--single",
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			Expect(result.OutString()).To(Equal(`{"compute":3}` + "\n"))
+		})
+
+		It("Returns null if the --jq path doesn't exist", func() {
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					RespondWithJSON(http.StatusOK, `{
+						"items": []
+					}`),
+				),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"get",
+					"--jq", ".items[0].id",
+					"/api/my_service/v1/my_objects",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			Expect(result.OutString()).To(Equal("null\n"))
+		})
+
+		It("Honours the --output-file flag", func() {
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					RespondWithJSON(http.StatusOK, `{
+						"my_field": "my_value"
+					}`),
+				),
+			)
+
+			// Run the command:
+			file := filepath.Join(GinkgoT().TempDir(), "my_dir", "my_object.json")
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"get",
+					"--output-file", file,
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			Expect(result.OutString()).To(BeEmpty())
+
+			// Check the file:
+			info, err := os.Stat(file)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+			data, err := os.ReadFile(file)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(RemoveLeadingTabs(
+				`{
+				  "my_field": "my_value"
+				}
+				`,
+			)))
+		})
+
+		It("Restricts the permissions of an existing --output-file", func() {
+			// Prepare the file:
+			file := filepath.Join(GinkgoT().TempDir(), "my_object.json")
+			err := os.WriteFile(file, []byte("my old content"), 0600)
+			Expect(err).ToNot(HaveOccurred())
+			err = os.Chmod(file, 0644)
+			Expect(err).ToNot(HaveOccurred())
+
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, `{}`),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"get",
+					"--output-file", file,
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+
+			// Check the file:
+			info, err := os.Stat(file)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+			data, err := os.ReadFile(file)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal("{}\n"))
+		})
+
 		It("Preserves long integers", func() {
 			// Prepare the server:
 			apiServer.AppendHandlers(
@@ -267,5 +757,59 @@ var _ = Describe("Get", func() {
 				`,
 			)))
 		})
+
+		It("Retries the request when it fails with a server error", func() {
+			apiServer.AppendHandlers(
+				RespondWithJSON(http.StatusServiceUnavailable, `{}`),
+				RespondWithJSON(http.StatusOK, `{ "my_field": "my_value" }`),
+			)
+
+			result := NewCommand().
+				ConfigString(config).
+				Args("get", "--retry", "1", "/api/my_service/v1/my_object").
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.OutString()).To(MatchJSON(`{ "my_field": "my_value" }`))
+			Expect(apiServer.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("Returns the last error when the retries are exhausted", func() {
+			apiServer.AppendHandlers(
+				RespondWithJSON(http.StatusInternalServerError, `{ "attempt": 1 }`),
+				RespondWithJSON(http.StatusInternalServerError, `{ "attempt": 2 }`),
+			)
+
+			result := NewCommand().
+				ConfigString(config).
+				Args("get", "--retry", "1", "/api/my_service/v1/my_object").
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(result.ErrString()).To(MatchJSON(`{ "attempt": 2 }`))
+			Expect(apiServer.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("Doesn't retry errors that aren't given with --retry-on", func() {
+			apiServer.AppendHandlers(
+				RespondWithJSON(http.StatusInternalServerError, `{}`),
+			)
+
+			result := NewCommand().
+				ConfigString(config).
+				Args("get", "--retry", "2", "--retry-on", "429", "/api/my_service/v1/my_object").
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(apiServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("Rejects unknown --retry-on classes", func() {
+			result := NewCommand().
+				ConfigString(config).
+				Args("get", "--retry-on", "4xx", "/api/my_service/v1/my_object").
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(result.ErrString()).To(ContainSubstring(
+				"Invalid --retry-on class '4xx', valid values are: 5xx, 429, connection",
+			))
+		})
 	})
 })
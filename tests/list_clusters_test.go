@@ -18,7 +18,9 @@ package tests
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"    // nolint
@@ -223,6 +225,102 @@ var _ = Describe("List clusters", func() {
 			))
 		})
 
+		It("Writes the clusters returned by the server as JSON with `--output json`", func() {
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				RespondWithJSON(
+					http.StatusOK,
+					`{
+						"kind": "ClusterList",
+						"page": 1,
+						"size": 2,
+						"total": 2,
+						"items": [
+							{
+								"kind": "Cluster",
+								"id": "123",
+								"name": "my_cluster",
+								"state": "ready"
+							},
+							{
+								"kind": "Cluster",
+								"id": "456",
+								"name": "your_cluster",
+								"state": "installing"
+							}
+						]
+					}`,
+				),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args("list", "clusters", "--output", "json").
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			Expect(result.OutString()).To(MatchJSON(`[
+				{
+					"kind": "Cluster",
+					"id": "123",
+					"name": "my_cluster",
+					"state": "ready"
+				},
+				{
+					"kind": "Cluster",
+					"id": "456",
+					"name": "your_cluster",
+					"state": "installing"
+				}
+			]`))
+		})
+
+		It("Writes one JSON object per line as each page is received with `--output ndjson`", func() {
+			// Prepare the server, the first page is full so that the command fetches the
+			// second one:
+			var items []string
+			for i := 0; i < 100; i++ {
+				items = append(items, fmt.Sprintf(`{"kind": "Cluster", "id": "%d"}`, i))
+			}
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					VerifyFormKV("page", "1"),
+					RespondWithJSON(http.StatusOK, fmt.Sprintf(
+						`{"kind": "ClusterList", "page": 1, "size": 100, "items": [%s]}`,
+						strings.Join(items, ","),
+					)),
+				),
+				CombineHandlers(
+					VerifyFormKV("page", "2"),
+					RespondWithJSON(http.StatusOK, `{
+						"kind": "ClusterList",
+						"page": 2,
+						"size": 1,
+						"items": [
+							{
+								"kind": "Cluster",
+								"id": "100",
+								"name": "last_cluster"
+							}
+						]
+					}`),
+				),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args("list", "clusters", "--output", "ndjson").
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			lines := result.OutLines()
+			Expect(lines).To(HaveLen(101))
+			Expect(lines[0]).To(Equal(`{"kind":"Cluster","id":"0"}`))
+			Expect(lines[100]).To(Equal(`{"kind":"Cluster","id":"100","name":"last_cluster"}`))
+		})
+
 		It("Doesn't trim `external_id` column", func() {
 			// Prepare the server:
 			apiServer.AppendHandlers(
@@ -264,5 +362,224 @@ var _ = Describe("List clusters", func() {
 				`^\s*123\s+e30bac0b-b337-47d7-a378-2c302b4c868a\s+my_cluster\s*$`,
 			))
 		})
+
+		It("Writes a footer with the number of clusters with `--count`", func() {
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				RespondWithJSON(
+					http.StatusOK,
+					`{
+						"kind": "ClusterList",
+						"page": 1,
+						"size": 2,
+						"total": 2,
+						"items": [
+							{
+								"kind": "Cluster",
+								"id": "123",
+								"name": "my_cluster"
+							},
+							{
+								"kind": "Cluster",
+								"id": "456",
+								"name": "your_cluster"
+							}
+						]
+					}`,
+				),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"list", "clusters",
+					"--columns", "id,name",
+					"--count",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			lines := result.OutLines()
+			Expect(lines).To(HaveLen(4))
+			Expect(lines[1]).To(MatchRegexp(`^123\s+my_cluster\s*$`))
+			Expect(lines[2]).To(MatchRegexp(`^456\s+your_cluster\s*$`))
+			Expect(lines[3]).To(MatchRegexp(`^Total: 2\s*$`))
+		})
+
+		It("Fails if `--count` is used with a structured output format", func() {
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"list", "clusters",
+					"--count",
+					"--output", "json",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(result.ErrString()).To(ContainSubstring(
+				"Flag --count can't be used with output format 'json'",
+			))
+		})
+
+		It("Fails if `--watch` is used with a structured output format", func() {
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"list", "clusters",
+					"--watch",
+					"--output", "json",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(result.ErrString()).To(ContainSubstring(
+				"Flag --watch can't be used with output format 'json'",
+			))
+			Expect(apiServer.ReceivedRequests()).To(BeEmpty())
+		})
+
+		It("Displays the fields of the describe command with `--columns-from describe`", func() {
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				RespondWithJSON(
+					http.StatusOK,
+					`{
+						"kind": "ClusterList",
+						"page": 1,
+						"size": 1,
+						"total": 1,
+						"items": [
+							{
+								"kind": "Cluster",
+								"id": "123",
+								"external_id": "e30bac0b-b337-47d7-a378-2c302b4c868a",
+								"name": "my_cluster",
+								"domain_prefix": "my-prefix",
+								"state": "ready",
+								"api": {
+									"url": "http://api.my-cluster.com",
+									"listening": "external"
+								},
+								"console": {
+									"url": "http://console.my-cluster.com"
+								},
+								"product": {
+									"id": "osd"
+								},
+								"billing_model": "standard",
+								"cloud_provider": {
+									"id": "aws"
+								},
+								"openshift_version": "4.16.1",
+								"region": {
+									"id": "us-east-1"
+								},
+								"multi_az": true,
+								"network": {
+									"type": "OVNKubernetes"
+								},
+								"ccs": {
+									"enabled": true
+								},
+								"hypershift": {
+									"enabled": false
+								},
+								"version": {
+									"channel_group": "stable"
+								},
+								"creation_timestamp": "2024-05-01T10:00:00Z"
+							}
+						]
+					}`,
+				),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"list", "clusters",
+					"--columns-from", "describe",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			lines := result.OutLines()
+			Expect(lines).To(HaveLen(2))
+			Expect(lines[0]).To(MatchRegexp(
+				`^\s*ID\s+EXTERNAL ID\s+NAME\s+DOMAIN PREFIX\s+STATE\s+API URL\s+API LISTENING\s+` +
+					`CONSOLE URL\s+PRODUCT ID\s+BILLING MODEL\s+CLOUD_PROVIDER\s+OPENSHIFT_VERSION\s+` +
+					`REGION ID\s+MULTI AZ\s+NETWORK TYPE\s+CCS\s+HCP\s+VERSION CHANNEL GROUP\s+CREATED\s*$`,
+			))
+			Expect(lines[1]).To(MatchRegexp(
+				`^\s*123\s+e30bac0b-b337-47d7-a378-2c302b4c868a\s+my_cluster\s+my-prefix\s+ready\s+` +
+					`http://api.my-cluster.com\s+external\s+http://console.my-cluster.com\s+osd\s+` +
+					`standard\s+aws\s+4\.16\.1\s+us-east-1\s+true\s+OVNKubernetes\s+true\s+false\s+` +
+					`stable\s+2024-05-01.*$`,
+			))
+		})
+
+		It("Lists only the clusters of the current user with `--mine`", func() {
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/current_account"),
+					RespondWithJSON(http.StatusOK, `{
+						"kind": "Account",
+						"id": "my-account",
+						"username": "my-user"
+					}`),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters"),
+					VerifyFormKV("search", "(creator.id = 'my-account') and (state = 'ready')"),
+					RespondWithJSON(http.StatusOK, `{
+						"kind": "ClusterList",
+						"page": 1,
+						"size": 1,
+						"total": 1,
+						"items": [
+							{
+								"kind": "Cluster",
+								"id": "123",
+								"name": "my_cluster",
+								"state": "ready"
+							}
+						]
+					}`),
+				),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"list", "clusters",
+					"--mine",
+					"--parameter", "search=state = 'ready'",
+					"--columns", "id, name",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			lines := result.OutLines()
+			Expect(lines).To(HaveLen(2))
+			Expect(lines[1]).To(MatchRegexp(`^123\s+my_cluster\s*$`))
+		})
+
+		It("Fails if `--columns-from` is used together with `--columns`", func() {
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"list", "clusters",
+					"--columns", "id",
+					"--columns-from", "describe",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(result.ErrString()).To(ContainSubstring(
+				"Flags --columns and --columns-from can't be used at the same time",
+			))
+		})
 	})
 })
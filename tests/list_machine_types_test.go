@@ -0,0 +1,177 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"    // nolint
+	. "github.com/onsi/gomega"       // nolint
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("List machine types", func() {
+	var ctx context.Context
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	const machineTypes = `{
+		"kind": "MachineTypeList",
+		"page": 1,
+		"size": 3,
+		"total": 3,
+		"items": [
+			{
+				"kind": "MachineType",
+				"id": "r5.xlarge",
+				"category": "memory_optimized",
+				"cpu": {"value": 4, "unit": "vCPU"},
+				"memory": {"value": 34359738368, "unit": "B"}
+			},
+			{
+				"kind": "MachineType",
+				"id": "r5.large",
+				"category": "memory_optimized",
+				"ccs_only": true,
+				"cpu": {"value": 2, "unit": "vCPU"},
+				"memory": {"value": 17179869184, "unit": "B"}
+			},
+			{
+				"kind": "MachineType",
+				"id": "m5.xlarge",
+				"category": "general_purpose",
+				"cpu": {"value": 4, "unit": "vCPU"},
+				"memory": {"value": 17179869184, "unit": "B"}
+			}
+		]
+	}`
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	It("Lists only the machine types of the requested category", func() {
+		// Prepare the server:
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/machine_types"),
+				RespondWithJSON(http.StatusOK, machineTypes),
+			),
+		)
+
+		// Run the command:
+		result := NewCommand().
+			ConfigString(config).
+			Args("list", "machine-types", "--provider", "aws", "--category", "memory_optimized").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		lines := result.OutLines()
+		Expect(lines).To(HaveLen(2))
+		Expect(lines[0]).To(MatchRegexp(`^ID\s+CATEGORY\s+CPU\s+MEMORY\s+CCS ONLY$`))
+		Expect(lines[1]).To(MatchRegexp(`^r5\.xlarge\s+memory_optimized\s+4 vCPU\s+32 GiB\s+false$`))
+	})
+
+	It("Includes the CCS only machine types with --ccs", func() {
+		// Prepare the server:
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, machineTypes),
+		)
+
+		// Run the command:
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"list", "machine-types",
+				"--provider", "aws",
+				"--ccs",
+				"--category", "memory_optimized",
+				"--output", "json",
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		Expect(result.OutString()).To(MatchJSON(`[
+			{
+				"kind": "MachineType",
+				"id": "r5.xlarge",
+				"category": "memory_optimized",
+				"cpu": {"value": 4, "unit": "vCPU"},
+				"memory": {"value": 34359738368, "unit": "B"}
+			},
+			{
+				"kind": "MachineType",
+				"id": "r5.large",
+				"category": "memory_optimized",
+				"ccs_only": true,
+				"cpu": {"value": 2, "unit": "vCPU"},
+				"memory": {"value": 17179869184, "unit": "B"}
+			}
+		]`))
+	})
+
+	It("Fails with an invalid category", func() {
+		// Run the command:
+		result := NewCommand().
+			ConfigString(config).
+			Args("list", "machine-types", "--provider", "aws", "--category", "cheap").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Invalid machine type category 'cheap', valid values are: general_purpose, " +
+				"memory_optimized, compute_optimized, accelerated_computing",
+		))
+		Expect(apiServer.ReceivedRequests()).To(BeEmpty())
+	})
+})
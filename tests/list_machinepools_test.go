@@ -187,6 +187,110 @@ var _ = Describe("List machine pools", Ordered, func() {
 		))
 	})
 
+	It("Writes the machine pools as JSON with `--output json`", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, subscriptionInfo),
+			RespondWithJSON(http.StatusOK, clustersInfo),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "MachinePoolList",
+				"total": 1,
+				"items": [
+				  {
+					"kind": "MachinePool",
+					"id": "worker",
+					"replicas": 4,
+					"instance_type": "m5.xlarge"
+				  }
+				]
+			  }`),
+		)
+
+		// Run the command:
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"list", "machinepools",
+				"--cluster", "my-cluster",
+				"--output", "json",
+			).Run(ctx)
+
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.OutString()).To(MatchJSON(`[
+			{
+				"kind": "MachinePool",
+				"id": "worker",
+				"replicas": 4,
+				"instance_type": "m5.xlarge"
+			}
+		]`))
+	})
+
+	It("Writes the machine pools as YAML with `--output yaml`", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, subscriptionInfo),
+			RespondWithJSON(http.StatusOK, clustersInfo),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "MachinePoolList",
+				"total": 1,
+				"items": [
+				  {
+					"kind": "MachinePool",
+					"id": "worker",
+					"replicas": 4,
+					"instance_type": "m5.xlarge"
+				  }
+				]
+			  }`),
+		)
+
+		// Run the command:
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"list", "machinepools",
+				"--cluster", "my-cluster",
+				"--output", "yaml",
+			).Run(ctx)
+
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.OutString()).To(MatchYAML(`
+- kind: MachinePool
+  id: worker
+  instance_type: m5.xlarge
+  replicas: 4
+`))
+	})
+
+	It("Fails with an unsupported output format", func() {
+		// Run the command:
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"list", "machinepools",
+				"--cluster", "my-cluster",
+				"--output", "xml",
+			).Run(ctx)
+
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring("Unsupported output format 'xml'"))
+	})
+
+	It("Fails if `--watch` is used with a structured output format", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Args(
+				"list", "machinepools",
+				"--cluster", "my-cluster",
+				"--watch",
+				"--output", "json",
+			).Run(ctx)
+
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Flag --watch can't be used with output format 'json'",
+		))
+	})
+
 	It("Fail on invalid cluster key", func() {
 		apiServer.AppendHandlers(
 			RespondWithJSON(http.StatusOK, subscriptionInfo),
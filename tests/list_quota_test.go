@@ -0,0 +1,210 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"    // nolint
+	. "github.com/onsi/gomega"       // nolint
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("List quota", func() {
+	var ctx context.Context
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+
+		// Prepare the server so that it returns quota costs for clusters and add-ons:
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/current_account"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "Account",
+					"id": "123",
+					"organization": {
+						"kind": "Organization",
+						"id": "456"
+					}
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/organizations/456/quota_cost"),
+				VerifyFormKV("fetchRelatedResources", "true"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "QuotaCostList",
+					"page": 1,
+					"size": 2,
+					"total": 2,
+					"items": [
+						{
+							"kind": "QuotaCost",
+							"quota_id": "cluster|byoc|osd",
+							"allowed": 10,
+							"consumed": 2,
+							"related_resources": [
+								{
+									"resource_type": "cluster",
+									"resource_name": "osd"
+								}
+							]
+						},
+						{
+							"kind": "QuotaCost",
+							"quota_id": "add-on|my-addon",
+							"allowed": 5,
+							"consumed": 1,
+							"related_resources": [
+								{
+									"resource_type": "add-on",
+									"resource_name": "my-addon"
+								}
+							]
+						}
+					]
+				}`),
+			),
+		)
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	It("Lists all the quota without filter", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Args("list", "quota").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		lines := result.OutLines()
+		Expect(lines).To(HaveLen(3))
+		Expect(lines[1]).To(MatchRegexp(`^2\s+10\s+cluster\|byoc\|osd$`))
+		Expect(lines[2]).To(MatchRegexp(`^1\s+5\s+add-on\|my-addon$`))
+	})
+
+	It("Filters by resource type with `--resource-type`", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Args("list", "quotas", "--resource-type", "add-on", "--output", "json").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		Expect(result.OutString()).To(MatchJSON(`[
+			{
+				"quota_id": "add-on|my-addon",
+				"allowed": 5,
+				"consumed": 1,
+				"related_resources": [
+					{
+						"resource_type": "add-on",
+						"resource_name": "my-addon"
+					}
+				]
+			}
+		]`))
+	})
+
+	It("Writes an empty list when no quota matches the resource type", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Args("list", "quota", "--resource-type", "compute.node", "--output", "json").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.OutString()).To(MatchJSON(`[]`))
+	})
+
+	It("Writes the resource quota with the deprecated `--json` flag", func() {
+		apiServer.SetHandler(1, CombineHandlers(
+			VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/organizations/456/resource_quota"),
+			VerifyFormKV("fetchRelatedResources", "true"),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "ResourceQuotaList",
+				"page": 1,
+				"size": 1,
+				"total": 1,
+				"items": [
+					{
+						"kind": "ResourceQuota",
+						"id": "789",
+						"organization_id": "456",
+						"sku": "MW00530",
+						"sku_count": 10
+					}
+				]
+			}`),
+		))
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("list", "quota", "--json").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.OutString()).To(MatchJSON(`{
+			"kind": "ResourceQuotaList",
+			"page": 1,
+			"size": 1,
+			"total": 1,
+			"items": [
+				{
+					"kind": "ResourceQuota",
+					"id": "789",
+					"organization_id": "456",
+					"sku": "MW00530",
+					"sku_count": 10
+				}
+			]
+		}`))
+	})
+})
@@ -0,0 +1,130 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"    // nolint
+	. "github.com/onsi/gomega"       // nolint
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("List regions", func() {
+	var ctx context.Context
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	It("Writes the capabilities of the enabled regions in JSON", func() {
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/cloud_providers/aws/regions"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "CloudRegionList",
+					"page": 1,
+					"size": 3,
+					"total": 3,
+					"items": [
+						{
+							"kind": "CloudRegion",
+							"id": "us-east-1",
+							"enabled": true,
+							"supports_multi_az": true,
+							"kms_location_id": "us-east-1-kms"
+						},
+						{
+							"kind": "CloudRegion",
+							"id": "ap-east-1",
+							"enabled": true,
+							"ccs_only": true
+						},
+						{
+							"kind": "CloudRegion",
+							"id": "eu-south-2"
+						}
+					]
+				}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("list", "regions", "--provider", "aws", "--output", "json").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		Expect(result.OutString()).To(MatchJSON(`[
+			{
+				"kind": "CloudRegion",
+				"id": "us-east-1",
+				"ccs_only": false,
+				"enabled": true,
+				"kms_location_id": "us-east-1-kms",
+				"supports_multi_az": true
+			},
+			{
+				"kind": "CloudRegion",
+				"id": "ap-east-1",
+				"ccs_only": true,
+				"enabled": true,
+				"kms_location_id": "",
+				"supports_multi_az": false
+			}
+		]`))
+	})
+})
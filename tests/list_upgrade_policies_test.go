@@ -0,0 +1,256 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"    // nolint
+	. "github.com/onsi/gomega"       // nolint
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("List upgrade policies", func() {
+	var ctx context.Context
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	// respondWithPolicies prepares the API server to return the ready cluster and its upgrade
+	// policies:
+	respondWithPolicies := func() {
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/subscriptions"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "SubscriptionList",
+					"page": 1,
+					"size": 1,
+					"total": 1,
+					"items": [
+						{
+							"kind": "Subscription",
+							"id": "456",
+							"status": "Active",
+							"cluster_id": "123"
+						}
+					]
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "Cluster",
+					"id": "123",
+					"name": "my-cluster",
+					"state": "ready"
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "UpgradePolicyList",
+					"page": 1,
+					"size": 2,
+					"total": 2,
+					"items": [
+						{
+							"kind": "UpgradePolicy",
+							"id": "my-manual-policy",
+							"schedule_type": "manual",
+							"next_run": "2026-10-20T10:00:00Z",
+							"version": "4.16.3"
+						},
+						{
+							"kind": "UpgradePolicy",
+							"id": "my-automatic-policy",
+							"schedule_type": "automatic",
+							"schedule": "0 10 * * 1",
+							"next_run": "2026-10-26T10:00:00Z",
+							"version": "4.16.5"
+						}
+					]
+				}`),
+			),
+		)
+	}
+
+	It("Writes the state of each policy", func() {
+		respondWithPolicies()
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodGet,
+					"/api/clusters_mgmt/v1/clusters/123/upgrade_policies/my-manual-policy/state",
+				),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "UpgradePolicyState",
+					"value": "scheduled"
+				}`),
+			),
+			CombineHandlers(
+				VerifyRequest(
+					http.MethodGet,
+					"/api/clusters_mgmt/v1/clusters/123/upgrade_policies/my-automatic-policy/state",
+				),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "UpgradePolicyState",
+					"value": "pending"
+				}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("list", "upgrade-policies", "--cluster", "my-cluster").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		lines := result.OutLines()
+		Expect(lines).To(HaveLen(3))
+		Expect(lines[0]).To(MatchRegexp(`^ID\s+SCHEDULE TYPE\s+NEXT RUN\s+VERSION\s+STATE$`))
+		Expect(lines[1]).To(MatchRegexp(
+			`^my-manual-policy\s+manual\s+2026-10-20 10:00:00 \+0000 UTC\s+4\.16\.3\s+scheduled$`,
+		))
+		Expect(lines[2]).To(MatchRegexp(
+			`^my-automatic-policy\s+automatic\s+2026-10-26 10:00:00 \+0000 UTC\s+4\.16\.5\s+pending$`,
+		))
+	})
+
+	It("Writes N/A if a policy has no state", func() {
+		respondWithPolicies()
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusNotFound, `{
+				"kind": "Error",
+				"id": "404",
+				"reason": "State of upgrade policy 'my-manual-policy' not found"
+			}`),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "UpgradePolicyState",
+				"value": "pending"
+			}`),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("list", "upgrade-policies", "--cluster", "my-cluster").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		lines := result.OutLines()
+		Expect(lines).To(HaveLen(3))
+		Expect(lines[1]).To(MatchRegexp(`^my-manual-policy\s+.*\s+N/A$`))
+		Expect(lines[2]).To(MatchRegexp(`^my-automatic-policy\s+.*\s+pending$`))
+	})
+
+	It("Fails if the state of a policy can't be retrieved", func() {
+		respondWithPolicies()
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusForbidden, `{
+				"kind": "Error",
+				"id": "403",
+				"reason": "Forbidden"
+			}`),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("list", "upgrade-policies", "--cluster", "my-cluster").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Failed to get state of upgrade policy 'my-manual-policy' for cluster '123'",
+		))
+	})
+
+	It("Writes the policies as JSON with `--output json`", func() {
+		respondWithPolicies()
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("list", "upgrade-policies", "--cluster", "my-cluster", "--output", "json").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.OutString()).To(MatchJSON(`[
+			{
+				"kind": "UpgradePolicy",
+				"id": "my-manual-policy",
+				"schedule_type": "manual",
+				"next_run": "2026-10-20T10:00:00Z",
+				"version": "4.16.3"
+			},
+			{
+				"kind": "UpgradePolicy",
+				"id": "my-automatic-policy",
+				"schedule_type": "automatic",
+				"schedule": "0 10 * * 1",
+				"next_run": "2026-10-26T10:00:00Z",
+				"version": "4.16.5"
+			}
+		]`))
+	})
+
+	It("Fails if `--watch` is used with a structured output format", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Args("list", "upgrade-policies", "--cluster", "my-cluster", "--watch", "--output", "json").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Flag --watch can't be used with output format 'json'",
+		))
+	})
+})
@@ -156,6 +156,135 @@ var _ = Describe("Login", func() {
 				"accessToken", accessToken,
 			))
 		})
+
+		It("Requests new tokens and doesn't reuse previous credentials", func() {
+			// Create the tokens:
+			oldAccessToken := MakeTokenString("Bearer", 15*time.Minute)
+			oldRefreshToken := MakeTokenString("Refresh", 10*time.Hour)
+			accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+			// Prepare the server:
+			ssoServer.AppendHandlers(
+				CombineHandlers(
+					VerifyFormKV("grant_type", "client_credentials"),
+					RespondWithAccessToken(accessToken),
+				),
+			)
+
+			// Run the command with the configuration of a previous login with user and
+			// password:
+			result := NewCommand().
+				ConfigString(
+					`{
+						"client_id": "cloud-services",
+						"user": "my-user",
+						"password": "my-password",
+						"access_token": "{{ .accessToken }}",
+						"refresh_token": "{{ .refreshToken }}"
+					}`,
+					"accessToken", oldAccessToken,
+					"refreshToken", oldRefreshToken,
+				).
+				Args(
+					"login",
+					"--client-id", "my-client",
+					"--client-secret", "my-secret",
+					"--token-url", ssoServer.URL(),
+				).
+				Run(ctx)
+
+			// Check that only the new access token is saved, and that there is no warning
+			// about user and password authentication:
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			Expect(result.OutString()).To(Equal(
+				"Login successful with the client credentials of 'my-client'\n",
+			))
+			Expect(result.ConfigString()).To(MatchJSONTemplate(
+				`{
+					"url": "{{ .url }}",
+					"token_url": "{{ .tokenURL }}",
+					"client_id": "my-client",
+					"client_secret": "my-secret",
+					"scopes": [
+						{{ range $i, $scope := .scopes }}
+							{{ if gt $i 0 }},{{ end }}
+							"{{ $scope }}"
+						{{ end }}
+					],
+					"access_token": "{{ .accessToken }}"
+				}`,
+				"url", sdk.DefaultURL,
+				"tokenURL", ssoServer.URL(),
+				"scopes", sdk.DefaultScopes,
+				"accessToken", accessToken,
+			))
+		})
+	})
+
+	When("Adding scopes", func() {
+		It("Appends the added scopes to the default ones", func() {
+			// Create the token:
+			accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+			// Prepare the server:
+			ssoServer.AppendHandlers(
+				RespondWithAccessToken(accessToken),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				Args(
+					"login",
+					"--client-id", "my-client",
+					"--client-secret", "my-secret",
+					"--token-url", ssoServer.URL(),
+					"--add-scope", "my-scope",
+					"--add-scope", sdk.DefaultScopes[0],
+				).
+				Run(ctx)
+
+			// Check the content of the configuration file:
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			Expect(result.ConfigString()).To(MatchJSONTemplate(
+				`{
+					"url": "{{ .url }}",
+					"token_url": "{{ .tokenURL }}",
+					"client_id": "my-client",
+					"client_secret": "my-secret",
+					"scopes": [
+						{{ range $i, $scope := .scopes }}
+							"{{ $scope }}",
+						{{ end }}
+						"my-scope"
+					],
+					"access_token": "{{ .accessToken }}"
+				}`,
+				"url", sdk.DefaultURL,
+				"tokenURL", ssoServer.URL(),
+				"scopes", sdk.DefaultScopes,
+				"accessToken", accessToken,
+			))
+		})
+
+		It("Fails if --scope is also used", func() {
+			result := NewCommand().
+				Args(
+					"login",
+					"--client-id", "my-client",
+					"--client-secret", "my-secret",
+					"--token-url", ssoServer.URL(),
+					"--scope", "openid",
+					"--add-scope", "my-scope",
+				).
+				Run(ctx)
+
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(result.ErrString()).To(ContainSubstring(
+				"Flags --scope and --add-scope can't be used at the same time",
+			))
+		})
 	})
 
 	When("Using password grant", func() {
@@ -18,6 +18,8 @@ package tests
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2" // nolint
@@ -112,6 +114,86 @@ var _ = Describe("Logout", func() {
 		Expect(result.ConfigString()).To(MatchJSON(`{}`))
 	})
 
+	It("Keeps URLs and client identifier with --keep-url", func() {
+		// Generate the tokens:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+		refreshToken := MakeTokenString("Refresh", 10*time.Hour)
+
+		// Run the command:
+		result := NewCommand().
+			ConfigString(
+				`{
+					"access_token": "{{ .accessToken }}",
+					"refresh_token": "{{ .refreshToken }}",
+					"client_id": "my_client",
+					"client_secret": "my_secret",
+					"user": "my_user",
+					"password": "my_password",
+					"scopes": [
+						"my_scope"
+					],
+					"token_url": "http://my-sso.example.com",
+					"url": "http://my-api.example.com"
+				}`,
+				"accessToken", accessToken,
+				"refreshToken", refreshToken,
+			).
+			Args(
+				"logout",
+				"--keep-url",
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ConfigString()).To(MatchJSON(`{
+			"client_id": "my_client",
+			"scopes": [
+				"my_scope"
+			],
+			"token_url": "http://my-sso.example.com",
+			"url": "http://my-api.example.com"
+		}`))
+	})
+
+	It("Uses the configuration file given with --config", func() {
+		// Create the explicit configuration file:
+		tmpDir, err := os.MkdirTemp("", "ocm-test-*.d")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(tmpDir)
+		configFile := filepath.Join(tmpDir, "my.json")
+		err = os.WriteFile(configFile, []byte(`{
+			"client_id": "my_client",
+			"client_secret": "my_secret",
+			"pager": "less"
+		}`), 0600)
+		Expect(err).ToNot(HaveOccurred())
+
+		// Run the command:
+		result := NewCommand().
+			ConfigString(`{
+				"client_id": "your_client",
+				"client_secret": "your_secret"
+			}`).
+			Args(
+				"logout",
+				"--config", configFile,
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+
+		// Check that the configuration file given in the environment hasn't changed:
+		Expect(result.ConfigString()).To(MatchJSON(`{
+			"client_id": "your_client",
+			"client_secret": "your_secret"
+		}`))
+
+		// Check that the explicit configuration file has been updated:
+		data, err := os.ReadFile(configFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(MatchJSON(`{
+			"pager": "less"
+		}`))
+	})
+
 	It("Doesn't remove settings not related to authentication", func() {
 		result := NewCommand().
 			ConfigString(`{
@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"    // nolint
+	. "github.com/onsi/gomega"       // nolint
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Patch", func() {
+	var ctx context.Context
+
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	It("Sends the request outside of production without confirmation", func() {
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/my-cluster"),
+				VerifyJSON(`{
+					"display_name": "my-name"
+				}`),
+				RespondWithJSON(http.StatusOK, `{}`),
+			),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("patch", "/api/clusters_mgmt/v1/clusters/my-cluster").
+			InString(`{"display_name": "my-name"}`).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+	})
+
+	It("Refuses to patch in production without confirmation", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Env("OCM_URL", "https://api.openshift.com").
+			Args("patch", "/api/clusters_mgmt/v1/clusters/my-cluster").
+			InString(`{"display_name": "my-name"}`).
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Refusing to patch '/api/clusters_mgmt/v1/clusters/my-cluster' in the production " +
+				"environment 'https://api.openshift.com' without confirmation, use --confirm",
+		))
+		Expect(apiServer.ReceivedRequests()).To(BeEmpty())
+	})
+
+	It("Refuses to patch in production when the body is read from a file", func() {
+		body := filepath.Join(GinkgoT().TempDir(), "body.json")
+		err := os.WriteFile(body, []byte(`{"display_name": "my-name"}`), 0600)
+		Expect(err).ToNot(HaveOccurred())
+
+		result := NewCommand().
+			ConfigString(config).
+			Env("OCM_URL", "https://api.openshift.com").
+			Args("patch", "--body", body, "/api/clusters_mgmt/v1/clusters/my-cluster").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"without confirmation, use --confirm or set OCM_SKIP_PRODUCTION_CONFIRMATION=true",
+		))
+		Expect(apiServer.ReceivedRequests()).To(BeEmpty())
+	})
+})
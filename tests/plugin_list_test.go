@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2" // nolint
 	. "github.com/onsi/gomega"    // nolint
@@ -138,4 +139,46 @@ var _ = Describe("Plugin list", func() {
 			`^\s*ocm-your-plugin\s*$`,
 		))
 	})
+
+	It("Honors the --paths option", func() {
+		// Create another directory containing a plugin with the same name than one of the
+		// plugins of the first directory, so that it will be shadowed:
+		other, err := os.MkdirTemp("", "ocm-test-*.d")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(other)
+		suffix := ""
+		if runtime.GOOS == "windows" {
+			suffix = ".exe"
+		}
+		file := filepath.Join(other, "ocm-my-plugin"+suffix)
+		err = os.WriteFile(file, nil, 0700)
+		Expect(err).ToNot(HaveOccurred())
+
+		// Run the command replacing the `PATH` environment variable with the temporary
+		// directories for plugins, so that it will not accidentally find other plugins that
+		// may be available in the machine where the tests run.
+		result := NewCommand().
+			Env("PATH", strings.Join([]string{tmp, other}, string(os.PathListSeparator))).
+			Args(
+				"plugin", "list",
+				"--paths",
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		lines := result.OutLines()
+		Expect(lines).To(HaveLen(4))
+		Expect(lines[0]).To(MatchRegexp(
+			`^\s*NAME\s+FILE\s+STATUS\s*$`,
+		))
+		Expect(lines[1]).To(MatchRegexp(
+			`^\s*ocm-my-plugin\s+%s\s+active\s*$`, regexp.QuoteMeta(filepath.Join(tmp, "ocm-my-plugin"+suffix)),
+		))
+		Expect(lines[2]).To(MatchRegexp(
+			`^\s*ocm-your-plugin\s+%s\s+active\s*$`, regexp.QuoteMeta(filepath.Join(tmp, "ocm-your-plugin"+suffix)),
+		))
+		Expect(lines[3]).To(MatchRegexp(
+			`^\s*ocm-my-plugin\s+%s\s+shadowed\s*$`, regexp.QuoteMeta(file),
+		))
+	})
 })
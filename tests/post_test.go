@@ -104,6 +104,60 @@ var _ = Describe("Post", func() {
 			Expect(result.ErrString()).To(BeEmpty())
 		})
 
+		It("Downloads the body from the URL given with --body", func() {
+			// Prepare the server that contains the body:
+			bodyServer := MakeTCPServer()
+			defer bodyServer.Close()
+			bodyServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/template.json"),
+					RespondWith(http.StatusOK, `{ "my_field": "my_value" }`),
+				),
+			)
+
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					VerifyBody([]byte(`{ "my_field": "my_value" }`)),
+					RespondWithJSON(http.StatusOK, `{}`),
+				),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"post",
+					"--body", bodyServer.URL()+"/template.json",
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+		})
+
+		It("Fails if the body URL doesn't respond with success", func() {
+			// Prepare the server that should contain the body:
+			bodyServer := MakeTCPServer()
+			defer bodyServer.Close()
+			bodyServer.AppendHandlers(
+				RespondWith(http.StatusNotFound, "Not found"),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"post",
+					"--body", bodyServer.URL()+"/template.json",
+					"/api/my_service/v1/my_object",
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(result.ErrString()).To(ContainSubstring("server responded with status '404 Not Found'"))
+			Expect(apiServer.ReceivedRequests()).To(BeEmpty())
+		})
+
 		It("Honours the --parameter flag", func() {
 			// Prepare the server:
 			apiServer.AppendHandlers(
@@ -169,5 +223,63 @@ var _ = Describe("Post", func() {
 			Expect(result.ExitCode()).To(BeZero())
 			Expect(result.ErrString()).To(BeEmpty())
 		})
+
+		It("Honours the --dry-run flag", func() {
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					VerifyFormKV("dryRun", "true"),
+					VerifyBody([]byte(`{ "my_field": "my_value" }`)),
+					RespondWithJSON(http.StatusBadRequest, `{
+						"kind": "Error",
+						"reason": "Field 'my_field' isn't valid"
+					}`),
+				),
+			)
+
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args(
+					"post",
+					"--dry-run",
+					"/api/my_service/v1/my_object",
+				).
+				InString(`{ "my_field": "my_value" }`).
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(result.ErrString()).To(ContainSubstring("Field 'my_field' isn't valid"))
+		})
+
+		It("Doesn't retry without --retry", func() {
+			apiServer.AppendHandlers(
+				RespondWithJSON(http.StatusBadGateway, `{}`),
+			)
+
+			result := NewCommand().
+				ConfigString(config).
+				Args("post", "--retry-on", "5xx", "--body", "/dev/null", "/api/my_service/v1/my_object").
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(apiServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("Retries when --retry is given", func() {
+			apiServer.AppendHandlers(
+				RespondWithJSON(http.StatusServiceUnavailable, `{}`),
+				CombineHandlers(
+					VerifyBody([]byte(`{ "my_field": "my_value" }`)),
+					RespondWithJSON(http.StatusOK, `{}`),
+				),
+			)
+
+			result := NewCommand().
+				ConfigString(config).
+				Args("post", "--retry", "1", "/api/my_service/v1/my_object").
+				InString(`{ "my_field": "my_value" }`).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(apiServer.ReceivedRequests()).To(HaveLen(2))
+		})
 	})
 })
@@ -0,0 +1,141 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"    // nolint
+	. "github.com/onsi/gomega"       // nolint
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Request", func() {
+	var ctx context.Context
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	It("Sends a PUT request with the standard input as body", func() {
+		// Prepare the server:
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPut, "/api/my_service/v1/my_objects/123"),
+				VerifyBody([]byte(`{ "my_field": "my_value" }`)),
+				RespondWithJSON(http.StatusOK, `{ "my_field": "my_value" }`),
+			),
+		)
+
+		// Run the command:
+		result := NewCommand().
+			ConfigString(config).
+			Args("request", "--method", "put", "/api/my_service/v1/my_objects/123").
+			InString(`{ "my_field": "my_value" }`).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+		Expect(result.OutString()).To(MatchJSON(`{ "my_field": "my_value" }`))
+	})
+
+	It("Sends a GET request by default without reading the standard input", func() {
+		// Prepare the server:
+		apiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/api/my_service/v1/my_objects/123"),
+				VerifyBody([]byte{}),
+				RespondWithJSON(http.StatusOK, `{}`),
+			),
+		)
+
+		// Run the command:
+		result := NewCommand().
+			ConfigString(config).
+			Args("request", "/api/my_service/v1/my_objects/123").
+			InString(`{ "my_field": "my_value" }`).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.ErrString()).To(BeEmpty())
+	})
+
+	It("Rejects the HEAD and OPTIONS methods with a clear error", func() {
+		for _, method := range []string{"head", "OPTIONS"} {
+			// Run the command:
+			result := NewCommand().
+				ConfigString(config).
+				Args("request", "--method", method, "/api/my_service/v1/my_objects/123").
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(result.ErrString()).To(ContainSubstring(
+				"isn't supported because the request builder of the SDK has no request type",
+			))
+		}
+		Expect(apiServer.ReceivedRequests()).To(BeEmpty())
+	})
+
+	It("Fails with an unsupported method", func() {
+		// Run the command:
+		result := NewCommand().
+			ConfigString(config).
+			Args("request", "--method", "TRACE", "/api/my_service/v1/my_objects/123").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Unsupported method 'TRACE', valid values are: DELETE, GET, PATCH, POST, PUT",
+		))
+		Expect(apiServer.ReceivedRequests()).To(BeEmpty())
+	})
+})
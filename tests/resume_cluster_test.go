@@ -0,0 +1,197 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"    // nolint
+	. "github.com/onsi/gomega"       // nolint
+	. "github.com/onsi/gomega/ghttp" // nolint
+
+	. "github.com/openshift-online/ocm-sdk-go/testing" // nolint
+)
+
+var _ = Describe("Resume cluster", func() {
+	var ctx context.Context
+
+	var ssoServer *Server
+	var apiServer *Server
+	var config string
+
+	var subscriptionInfo string = `{
+		"items": [
+		  {
+			"kind":"Subscription",
+			"cluster_id":"my-cluster",
+			"id":"subsID"
+		  }]
+	}`
+
+	BeforeEach(func() {
+		// Create a context:
+		ctx = context.Background()
+
+		// Create the servers:
+		ssoServer = MakeTCPServer()
+		apiServer = MakeTCPServer()
+
+		// Create the token:
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+
+		// Prepare the server:
+		ssoServer.AppendHandlers(
+			RespondWithAccessToken(accessToken),
+		)
+
+		// Login:
+		result := NewCommand().
+			Args(
+				"login",
+				"--client-id", "my-client",
+				"--client-secret", "my-secret",
+				"--token-url", ssoServer.URL(),
+				"--url", apiServer.URL(),
+			).
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		config = result.ConfigString()
+	})
+
+	AfterEach(func() {
+		// Close the servers:
+		ssoServer.Close()
+		apiServer.Close()
+	})
+
+	It("Fails if the cluster isn't hibernating", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, subscriptionInfo),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "ClusterList",
+				"total": 1,
+				"items": [
+					{
+					"kind":"Cluster",
+					"id":"my-cluster",
+					"subscription": {"id":"subsID"},
+					"state":"ready"
+					}]
+			  }`),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("resume", "cluster", "my-cluster").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"Cluster 'my-cluster' can't be resumed because it isn't hibernating, its current state is 'ready'"))
+	})
+
+	It("Rejects --wait-timeout without --wait", func() {
+		result := NewCommand().
+			ConfigString(config).
+			Args("resume", "cluster", "--wait-timeout", "10m", "my-cluster").
+			Run(ctx)
+		Expect(result.ExitCode()).ToNot(BeZero())
+		Expect(result.ErrString()).To(ContainSubstring(
+			"--wait-timeout flag is meaningless without --wait"))
+		Expect(apiServer.ReceivedRequests()).To(BeEmpty())
+	})
+
+	It("Waits till the cluster is ready", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, subscriptionInfo),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "ClusterList",
+				"total": 1,
+				"items": [
+					{
+					"kind":"Cluster",
+					"id":"my-cluster",
+					"subscription": {"id":"subsID"},
+					"state":"hibernating"
+					}]
+			  }`),
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/my-cluster/resume"),
+				RespondWithJSON(http.StatusAccepted, `{}`),
+			),
+			RespondWithJSON(http.StatusOK, `{
+				"kind":"Cluster",
+				"id":"my-cluster",
+				"state":"ready"
+			}`),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("resume", "cluster", "--wait", "my-cluster").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.OutString()).To(ContainSubstring(
+			"Cluster 'my-cluster' state changed from 'hibernating' to 'ready'"))
+	})
+
+	It("Keeps waiting after a transient error", func() {
+		// The SDK retries 503 responses twice by itself, so three of them are needed for the
+		// error to reach the command:
+		unavailable := RespondWithJSON(http.StatusServiceUnavailable, `{
+			"kind": "Error",
+			"id": "503",
+			"href": "/api/clusters_mgmt/v1/errors/503",
+			"reason": "Service unavailable"
+		}`)
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, subscriptionInfo),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "ClusterList",
+				"total": 1,
+				"items": [
+					{
+					"kind":"Cluster",
+					"id":"my-cluster",
+					"subscription": {"id":"subsID"},
+					"state":"hibernating"
+					}]
+			  }`),
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters/my-cluster/resume"),
+				RespondWithJSON(http.StatusAccepted, `{}`),
+			),
+			unavailable,
+			unavailable,
+			unavailable,
+			RespondWithJSON(http.StatusOK, `{
+				"kind":"Cluster",
+				"id":"my-cluster",
+				"state":"ready"
+			}`),
+		)
+
+		result := NewCommand().
+			ConfigString(config).
+			Args("resume", "cluster", "--wait", "--wait-interval", "1ms", "my-cluster").
+			Run(ctx)
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.OutString()).To(ContainSubstring(
+			"Cluster 'my-cluster' state changed from 'hibernating' to 'ready'"))
+	})
+})
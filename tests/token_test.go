@@ -71,6 +71,63 @@ var _ = Describe("Token", func() {
 			Expect(result.ErrString()).To(BeEmpty())
 			Expect(result.ExitCode()).To(BeZero())
 		})
+
+		It("Displays current refresh token with `--type refresh`", func() {
+			result := cmd.Args("--type", "refresh").Run(ctx)
+			Expect(result.OutString()).To(Equal(refreshToken + "\n"))
+			Expect(result.ErrString()).To(ContainSubstring(
+				"Warning: the refresh token gives long lived access to your account",
+			))
+			Expect(result.ExitCode()).To(BeZero())
+		})
+
+		It("Rejects unknown token types", func() {
+			result := cmd.Args("--type", "offline").Run(ctx)
+			Expect(result.OutString()).To(BeEmpty())
+			Expect(result.ErrString()).To(ContainSubstring(
+				"Invalid --type 'offline', valid values are: access, refresh",
+			))
+			Expect(result.ExitCode()).ToNot(BeZero())
+		})
+
+		It("Rejects `--refresh` with `--type access`", func() {
+			result := cmd.Args("--refresh", "--type", "access").Run(ctx)
+			Expect(result.OutString()).To(BeEmpty())
+			Expect(result.ErrString()).To(ContainSubstring(
+				"Flag --refresh can't be used with --type=access",
+			))
+			Expect(result.ExitCode()).ToNot(BeZero())
+		})
+	})
+
+	When("Logged in without refresh token", func() {
+		var accessToken string
+
+		BeforeEach(func() {
+			// Create the token:
+			accessToken = MakeTokenString("Bearer", 10*time.Minute)
+
+			// Create the command:
+			cmd = NewCommand().
+				ConfigString(
+					`{
+						"access_token": "{{ .accessToken }}",
+						"url": "http://my-server.example.com",
+						"token_url": "http://my-sso.example.com"
+					}`,
+					"accessToken", accessToken,
+				).
+				Arg("token")
+		})
+
+		It("Explains that there is no refresh token", func() {
+			result := cmd.Args("--type", "refresh").Run(ctx)
+			Expect(result.OutString()).To(BeEmpty())
+			Expect(result.ErrString()).To(ContainSubstring(
+				"There is no refresh token, the credentials used to log in don't provide one",
+			))
+			Expect(result.ExitCode()).ToNot(BeZero())
+		})
 	})
 
 	When("Not logged in", func() {
@@ -19,6 +19,7 @@ package tests
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 
@@ -36,6 +37,198 @@ var _ = Describe("Whoami", func() {
 		ctx = context.Background()
 	})
 
+	When("Logged in", func() {
+		var ssoServer *Server
+		var apiServer *Server
+		var config string
+
+		BeforeEach(func() {
+			// Create the servers:
+			ssoServer = MakeTCPServer()
+			apiServer = MakeTCPServer()
+
+			// Prepare the server:
+			ssoServer.AppendHandlers(
+				RespondWithAccessToken(MakeTokenString("Bearer", 15*time.Minute)),
+			)
+
+			// Login:
+			result := NewCommand().
+				Args(
+					"login",
+					"--client-id", "my-client",
+					"--client-secret", "my-secret",
+					"--token-url", ssoServer.URL(),
+					"--url", apiServer.URL(),
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			config = result.ConfigString()
+
+			// Prepare the server:
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/current_account"),
+					RespondWithJSON(http.StatusOK, `{
+						"kind": "Account",
+						"id": "123",
+						"username": "my-user"
+					}`),
+				),
+			)
+		})
+
+		AfterEach(func() {
+			// Close the servers:
+			ssoServer.Close()
+			apiServer.Close()
+		})
+
+		It("Adds the roles of the account with `--show-roles`", func() {
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/role_bindings"),
+					VerifyFormKV("search", "account_id in ('123')"),
+					RespondWithJSON(http.StatusOK, `{
+						"kind": "RoleBindingList",
+						"page": 1,
+						"size": 3,
+						"items": [
+							{
+								"kind": "RoleBinding",
+								"account": {"id": "123"},
+								"role": {"id": "OrganizationAdmin"}
+							},
+							{
+								"kind": "RoleBinding",
+								"account": {"id": "123"},
+								"role": {"id": "ClusterEditor"}
+							},
+							{
+								"kind": "RoleBinding",
+								"account": {"id": "123"},
+								"role": {"id": "OrganizationAdmin"}
+							}
+						]
+					}`),
+				),
+			)
+
+			result := NewCommand().
+				ConfigString(config).
+				Args("whoami", "--show-roles").
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.ErrString()).To(BeEmpty())
+			Expect(result.OutString()).To(MatchJSON(`{
+				"kind": "Account",
+				"id": "123",
+				"username": "my-user",
+				"roles": ["OrganizationAdmin", "ClusterEditor"]
+			}`))
+		})
+
+		It("Doesn't retrieve the roles without `--show-roles`", func() {
+			result := NewCommand().
+				ConfigString(config).
+				Args("whoami").
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.OutString()).To(MatchJSON(`{
+				"kind": "Account",
+				"id": "123",
+				"username": "my-user"
+			}`))
+			Expect(apiServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	When("Impersonating another user", func() {
+		var ssoServer *Server
+		var apiServer *Server
+		var config string
+
+		BeforeEach(func() {
+			// Create the servers:
+			ssoServer = MakeTCPServer()
+			apiServer = MakeTCPServer()
+
+			// Prepare the server:
+			ssoServer.AppendHandlers(
+				RespondWithAccessToken(MakeTokenString("Bearer", 15*time.Minute)),
+			)
+
+			// Login:
+			result := NewCommand().
+				Args(
+					"login",
+					"--client-id", "my-client",
+					"--client-secret", "my-secret",
+					"--token-url", ssoServer.URL(),
+					"--url", apiServer.URL(),
+				).
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			config = result.ConfigString()
+		})
+
+		AfterEach(func() {
+			// Close the servers:
+			ssoServer.Close()
+			apiServer.Close()
+		})
+
+		It("Sends the impersonation header", func() {
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/current_account"),
+					VerifyHeaderKV("Impersonate-User", "other-user"),
+					RespondWithJSON(http.StatusOK, `{
+						"kind": "Account",
+						"id": "456",
+						"username": "other-user"
+					}`),
+				),
+			)
+
+			result := NewCommand().
+				ConfigString(config).
+				Args("whoami", "--impersonate", "other-user").
+				Run(ctx)
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(result.OutString()).To(MatchJSON(`{
+				"kind": "Account",
+				"id": "456",
+				"username": "other-user"
+			}`))
+		})
+
+		It("Reports that elevated privileges are required when forbidden", func() {
+			apiServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/current_account"),
+					VerifyHeaderKV("Impersonate-User", "other-user"),
+					RespondWithJSON(http.StatusForbidden, `{
+						"kind": "Error",
+						"id": "403",
+						"href": "/api/accounts_mgmt/v1/errors/403",
+						"code": "ACCT-MGMT-403",
+						"reason": "Forbidden to impersonate"
+					}`),
+				),
+			)
+
+			result := NewCommand().
+				ConfigString(config).
+				Args("whoami", "--impersonate", "other-user").
+				Run(ctx)
+			Expect(result.ExitCode()).ToNot(BeZero())
+			Expect(result.ErrString()).To(ContainSubstring(
+				"Can't impersonate user 'other-user', elevated privileges are required",
+			))
+		})
+	})
+
 	When("Offline user session not found", func() {
 		var ssoServer *Server
 		var apiServer *Server